@@ -6,6 +6,7 @@ require (
 	github.com/docker/distribution v2.8.1+incompatible
 	github.com/emicklei/go-restful/v3 v3.7.4
 	github.com/golang/glog v1.0.0
+	github.com/google/gnostic v0.6.9
 	github.com/opencontainers/go-digest v1.0.0 // indirect
 	github.com/prometheus/client_golang v1.12.2
 	github.com/spf13/pflag v1.0.5
@@ -19,6 +20,7 @@ require (
 	k8s.io/apimachinery v0.24.1
 	k8s.io/client-go v0.24.1
 	k8s.io/heapster v1.5.4
+	sigs.k8s.io/yaml v1.3.0
 )
 
 require (
@@ -33,7 +35,6 @@ require (
 	github.com/go-openapi/swag v0.21.1 // indirect
 	github.com/gogo/protobuf v1.3.2 // indirect
 	github.com/golang/protobuf v1.5.2 // indirect
-	github.com/google/gnostic v0.6.9 // indirect
 	github.com/google/go-cmp v0.5.5 // indirect
 	github.com/google/gofuzz v1.2.0 // indirect
 	github.com/gorilla/websocket v1.5.0 // indirect
@@ -64,5 +65,4 @@ require (
 	k8s.io/utils v0.0.0-20220210201930-3a6ce19ff2f9 // indirect
 	sigs.k8s.io/json v0.0.0-20220525155127-227cbc7cc124 // indirect
 	sigs.k8s.io/structured-merge-diff/v4 v4.2.1 // indirect
-	sigs.k8s.io/yaml v1.3.0 // indirect
 )
@@ -168,6 +168,270 @@ func (self *holderBuilder) SetLocaleConfig(localeConfig string) *holderBuilder {
 	return self
 }
 
+// SetJWETokenHeader 'jwe-token-header' argument of Dashboard binary.
+func (self *holderBuilder) SetJWETokenHeader(jweTokenHeader string) *holderBuilder {
+	self.holder.jweTokenHeader = jweTokenHeader
+	return self
+}
+
+// SetMaxRequestTimeout 'max-request-timeout' argument of Dashboard binary.
+func (self *holderBuilder) SetMaxRequestTimeout(maxRequestTimeout int) *holderBuilder {
+	self.holder.maxRequestTimeout = maxRequestTimeout
+	return self
+}
+
+// SetStartupRBACCheck 'startup-rbac-check' argument of Dashboard binary.
+func (self *holderBuilder) SetStartupRBACCheck(startupRBACCheck bool) *holderBuilder {
+	self.holder.startupRBACCheck = startupRBACCheck
+	return self
+}
+
+// SetEgressProxyClientCert 'egress-proxy-client-cert' argument of Dashboard binary.
+func (self *holderBuilder) SetEgressProxyClientCert(egressProxyClientCert string) *holderBuilder {
+	self.holder.egressProxyClientCert = egressProxyClientCert
+	return self
+}
+
+// SetEgressProxyClientKey 'egress-proxy-client-key' argument of Dashboard binary.
+func (self *holderBuilder) SetEgressProxyClientKey(egressProxyClientKey string) *holderBuilder {
+	self.holder.egressProxyClientKey = egressProxyClientKey
+	return self
+}
+
+// SetEgressProxyCA 'egress-proxy-ca' argument of Dashboard binary.
+func (self *holderBuilder) SetEgressProxyCA(egressProxyCA string) *holderBuilder {
+	self.holder.egressProxyCA = egressProxyCA
+	return self
+}
+
+// SetClusterDomain 'cluster-domain' argument of Dashboard binary.
+func (self *holderBuilder) SetClusterDomain(clusterDomain string) *holderBuilder {
+	self.holder.clusterDomain = clusterDomain
+	return self
+}
+
+// SetUsernameExtractionRegex 'username-extraction-regex' argument of Dashboard binary.
+func (self *holderBuilder) SetUsernameExtractionRegex(usernameExtractionRegex string) *holderBuilder {
+	self.holder.usernameExtractionRegex = usernameExtractionRegex
+	return self
+}
+
+// SetAPIProxyAllowedPaths 'api-proxy-allowed-paths' argument of Dashboard binary.
+func (self *holderBuilder) SetAPIProxyAllowedPaths(apiProxyAllowedPaths []string) *holderBuilder {
+	self.holder.apiProxyAllowedPaths = apiProxyAllowedPaths
+	return self
+}
+
+// SetMaxWatchesPerUser 'max-watches-per-user' argument of Dashboard binary.
+func (self *holderBuilder) SetMaxWatchesPerUser(maxWatchesPerUser int) *holderBuilder {
+	self.holder.maxWatchesPerUser = maxWatchesPerUser
+	return self
+}
+
+// SetBlockServiceAccountImpersonation 'block-service-account-impersonation' argument of Dashboard binary.
+func (self *holderBuilder) SetBlockServiceAccountImpersonation(blockServiceAccountImpersonation bool) *holderBuilder {
+	self.holder.blockServiceAccountImpersonation = blockServiceAccountImpersonation
+	return self
+}
+
+// SetMaxResponseSize 'max-response-size' argument of Dashboard binary.
+func (self *holderBuilder) SetMaxResponseSize(maxResponseSize int64) *holderBuilder {
+	self.holder.maxResponseSize = maxResponseSize
+	return self
+}
+
+// SetInClusterQPS 'in-cluster-qps' argument of Dashboard binary.
+func (self *holderBuilder) SetInClusterQPS(inClusterQPS float32) *holderBuilder {
+	self.holder.inClusterQPS = inClusterQPS
+	return self
+}
+
+// SetInClusterBurst 'in-cluster-burst' argument of Dashboard binary.
+func (self *holderBuilder) SetInClusterBurst(inClusterBurst int) *holderBuilder {
+	self.holder.inClusterBurst = inClusterBurst
+	return self
+}
+
+// SetOutOfClusterQPS 'out-of-cluster-qps' argument of Dashboard binary.
+func (self *holderBuilder) SetOutOfClusterQPS(outOfClusterQPS float32) *holderBuilder {
+	self.holder.outOfClusterQPS = outOfClusterQPS
+	return self
+}
+
+// SetOutOfClusterBurst 'out-of-cluster-burst' argument of Dashboard binary.
+func (self *holderBuilder) SetOutOfClusterBurst(outOfClusterBurst int) *holderBuilder {
+	self.holder.outOfClusterBurst = outOfClusterBurst
+	return self
+}
+
+// SetTokenClockSkewLeeway 'token-clock-skew-leeway' argument of Dashboard binary.
+func (self *holderBuilder) SetTokenClockSkewLeeway(tokenClockSkewLeeway int) *holderBuilder {
+	self.holder.tokenClockSkewLeeway = tokenClockSkewLeeway
+	return self
+}
+
+// SetImpersonationAssertionSecret 'impersonation-assertion-secret' argument of Dashboard binary.
+func (self *holderBuilder) SetImpersonationAssertionSecret(impersonationAssertionSecret string) *holderBuilder {
+	self.holder.impersonationAssertionSecret = impersonationAssertionSecret
+	return self
+}
+
+// SetPropagatedRequestHeaders 'propagated-request-headers' argument of Dashboard binary.
+func (self *holderBuilder) SetPropagatedRequestHeaders(propagatedRequestHeaders []string) *holderBuilder {
+	self.holder.propagatedRequestHeaders = propagatedRequestHeaders
+	return self
+}
+
+// SetBatchGetConcurrency 'batch-get-concurrency' argument of Dashboard binary.
+func (self *holderBuilder) SetBatchGetConcurrency(batchGetConcurrency int) *holderBuilder {
+	self.holder.batchGetConcurrency = batchGetConcurrency
+	return self
+}
+
+// SetDefaultPageSize 'default-page-size' argument of Dashboard binary.
+func (self *holderBuilder) SetDefaultPageSize(defaultPageSize int64) *holderBuilder {
+	self.holder.defaultPageSize = defaultPageSize
+	return self
+}
+
+// SetMaxPageSize 'max-page-size' argument of Dashboard binary.
+func (self *holderBuilder) SetMaxPageSize(maxPageSize int64) *holderBuilder {
+	self.holder.maxPageSize = maxPageSize
+	return self
+}
+
+// SetMaxInformerFactories 'max-informer-factories' argument of Dashboard binary.
+func (self *holderBuilder) SetMaxInformerFactories(maxInformerFactories int) *holderBuilder {
+	self.holder.maxInformerFactories = maxInformerFactories
+	return self
+}
+
+// SetInClusterConfigAttempts 'in-cluster-config-attempts' argument of Dashboard binary.
+func (self *holderBuilder) SetInClusterConfigAttempts(inClusterConfigAttempts int) *holderBuilder {
+	self.holder.inClusterConfigAttempts = inClusterConfigAttempts
+	return self
+}
+
+// SetInClusterConfigRetryInterval 'in-cluster-config-retry-interval' argument of Dashboard binary.
+func (self *holderBuilder) SetInClusterConfigRetryInterval(inClusterConfigRetryInterval int) *holderBuilder {
+	self.holder.inClusterConfigRetryInterval = inClusterConfigRetryInterval
+	return self
+}
+
+// SetEnableKubeConfigExport 'enable-kubeconfig-export' argument of Dashboard binary.
+func (self *holderBuilder) SetEnableKubeConfigExport(enableKubeConfigExport bool) *holderBuilder {
+	self.holder.enableKubeConfigExport = enableKubeConfigExport
+	return self
+}
+
+// SetKubeConfigExportIncludeToken 'kubeconfig-export-include-token' argument of Dashboard binary.
+func (self *holderBuilder) SetKubeConfigExportIncludeToken(kubeConfigExportIncludeToken bool) *holderBuilder {
+	self.holder.kubeConfigExportIncludeToken = kubeConfigExportIncludeToken
+	return self
+}
+
+// SetMaxPortForwardsPerUser 'max-port-forwards-per-user' argument of Dashboard binary.
+func (self *holderBuilder) SetMaxPortForwardsPerUser(maxPortForwardsPerUser int) *holderBuilder {
+	self.holder.maxPortForwardsPerUser = maxPortForwardsPerUser
+	return self
+}
+
+// SetMinRefreshInterval 'min-refresh-interval' argument of Dashboard binary.
+func (self *holderBuilder) SetMinRefreshInterval(minRefreshInterval int) *holderBuilder {
+	self.holder.minRefreshInterval = minRefreshInterval
+	return self
+}
+
+// SetMaxRefreshInterval 'max-refresh-interval' argument of Dashboard binary.
+func (self *holderBuilder) SetMaxRefreshInterval(maxRefreshInterval int) *holderBuilder {
+	self.holder.maxRefreshInterval = maxRefreshInterval
+	return self
+}
+
+// SetNamespaceDenylist 'namespace-denylist' argument of Dashboard binary.
+func (self *holderBuilder) SetNamespaceDenylist(namespaceDenylist string) *holderBuilder {
+	self.holder.namespaceDenylist = namespaceDenylist
+	return self
+}
+
+// SetRESTMapperResetWindow 'rest-mapper-reset-window' argument of Dashboard binary.
+func (self *holderBuilder) SetRESTMapperResetWindow(restMapperResetWindow int) *holderBuilder {
+	self.holder.restMapperResetWindow = restMapperResetWindow
+	return self
+}
+
+// SetStuckTerminatingThreshold 'stuck-terminating-threshold' argument of Dashboard binary.
+func (self *holderBuilder) SetStuckTerminatingThreshold(stuckTerminatingThreshold int) *holderBuilder {
+	self.holder.stuckTerminatingThreshold = stuckTerminatingThreshold
+	return self
+}
+
+// SetEnableForceDelete 'enable-force-delete' argument of Dashboard binary.
+func (self *holderBuilder) SetEnableForceDelete(enableForceDelete bool) *holderBuilder {
+	self.holder.enableForceDelete = enableForceDelete
+	return self
+}
+
+// SetServiceProxyAllowedServices 'service-proxy-allowed-services' argument of Dashboard binary.
+func (self *holderBuilder) SetServiceProxyAllowedServices(serviceProxyAllowedServices []string) *holderBuilder {
+	self.holder.serviceProxyAllowedServices = serviceProxyAllowedServices
+	return self
+}
+
+// SetDefaultLogTailLines 'default-log-tail-lines' argument of Dashboard binary.
+func (self *holderBuilder) SetDefaultLogTailLines(defaultLogTailLines int) *holderBuilder {
+	self.holder.defaultLogTailLines = defaultLogTailLines
+	return self
+}
+
+// SetMaxLogTailLines 'max-log-tail-lines' argument of Dashboard binary.
+func (self *holderBuilder) SetMaxLogTailLines(maxLogTailLines int) *holderBuilder {
+	self.holder.maxLogTailLines = maxLogTailLines
+	return self
+}
+
+// SetApplyManifestConcurrency 'apply-manifest-concurrency' argument of Dashboard binary.
+func (self *holderBuilder) SetApplyManifestConcurrency(applyManifestConcurrency int) *holderBuilder {
+	self.holder.applyManifestConcurrency = applyManifestConcurrency
+	return self
+}
+
+// SetShowTerminalPods 'show-terminal-pods' argument of Dashboard binary.
+func (self *holderBuilder) SetShowTerminalPods(showTerminalPods bool) *holderBuilder {
+	self.holder.showTerminalPods = showTerminalPods
+	return self
+}
+
+// SetDefaultListTimeoutSeconds 'default-list-timeout-seconds' argument of Dashboard binary.
+func (self *holderBuilder) SetDefaultListTimeoutSeconds(defaultListTimeoutSeconds int64) *holderBuilder {
+	self.holder.defaultListTimeoutSeconds = defaultListTimeoutSeconds
+	return self
+}
+
+// SetMaxListTimeoutSeconds 'max-list-timeout-seconds' argument of Dashboard binary.
+func (self *holderBuilder) SetMaxListTimeoutSeconds(maxListTimeoutSeconds int64) *holderBuilder {
+	self.holder.maxListTimeoutSeconds = maxListTimeoutSeconds
+	return self
+}
+
+// SetStampManagedBy 'stamp-managed-by' argument of Dashboard binary.
+func (self *holderBuilder) SetStampManagedBy(stampManagedBy bool) *holderBuilder {
+	self.holder.stampManagedBy = stampManagedBy
+	return self
+}
+
+// SetSensitiveEnvPatterns 'sensitive-env-patterns' argument of Dashboard binary.
+func (self *holderBuilder) SetSensitiveEnvPatterns(sensitiveEnvPatterns []string) *holderBuilder {
+	self.holder.sensitiveEnvPatterns = sensitiveEnvPatterns
+	return self
+}
+
+// SetEnableTransportStats 'enable-transport-stats' argument of Dashboard binary.
+func (self *holderBuilder) SetEnableTransportStats(enableTransportStats bool) *holderBuilder {
+	self.holder.enableTransportStats = enableTransportStats
+	return self
+}
+
 // GetHolderBuilder returns singleton instance of argument holder builder.
 func GetHolderBuilder() *holderBuilder {
 	return builder
@@ -55,6 +55,92 @@ type holder struct {
 	enableSkipLogin bool
 
 	localeConfig string
+
+	jweTokenHeader string
+
+	maxRequestTimeout int
+
+	startupRBACCheck bool
+
+	egressProxyClientCert string
+	egressProxyClientKey  string
+	egressProxyCA         string
+
+	clusterDomain string
+
+	usernameExtractionRegex string
+
+	apiProxyAllowedPaths []string
+
+	maxWatchesPerUser int
+
+	blockServiceAccountImpersonation bool
+
+	maxResponseSize int64
+
+	inClusterQPS float32
+
+	inClusterBurst int
+
+	outOfClusterQPS float32
+
+	outOfClusterBurst int
+
+	tokenClockSkewLeeway int
+
+	impersonationAssertionSecret string
+
+	propagatedRequestHeaders []string
+
+	batchGetConcurrency int
+
+	defaultPageSize int64
+
+	maxPageSize int64
+
+	maxInformerFactories int
+
+	inClusterConfigAttempts int
+
+	inClusterConfigRetryInterval int
+
+	enableKubeConfigExport bool
+
+	kubeConfigExportIncludeToken bool
+
+	maxPortForwardsPerUser int
+
+	minRefreshInterval int
+
+	maxRefreshInterval int
+
+	namespaceDenylist string
+
+	restMapperResetWindow int
+
+	stuckTerminatingThreshold int
+
+	enableForceDelete bool
+
+	serviceProxyAllowedServices []string
+
+	defaultLogTailLines int
+
+	maxLogTailLines int
+
+	applyManifestConcurrency int
+
+	showTerminalPods bool
+
+	defaultListTimeoutSeconds int64
+
+	maxListTimeoutSeconds int64
+
+	stampManagedBy bool
+
+	sensitiveEnvPatterns []string
+
+	enableTransportStats bool
 }
 
 // GetInsecurePort 'insecure-port' argument of Dashboard binary.
@@ -184,3 +270,223 @@ func (self *holder) GetNamespace() string {
 func (self *holder) GetLocaleConfig() string {
 	return self.localeConfig
 }
+
+// GetJWETokenHeader 'jwe-token-header' argument of Dashboard binary.
+func (self *holder) GetJWETokenHeader() string {
+	return self.jweTokenHeader
+}
+
+// GetMaxRequestTimeout 'max-request-timeout' argument of Dashboard binary.
+func (self *holder) GetMaxRequestTimeout() int {
+	return self.maxRequestTimeout
+}
+
+// GetStartupRBACCheck 'startup-rbac-check' argument of Dashboard binary.
+func (self *holder) GetStartupRBACCheck() bool {
+	return self.startupRBACCheck
+}
+
+// GetEgressProxyClientCert 'egress-proxy-client-cert' argument of Dashboard binary.
+func (self *holder) GetEgressProxyClientCert() string {
+	return self.egressProxyClientCert
+}
+
+// GetEgressProxyClientKey 'egress-proxy-client-key' argument of Dashboard binary.
+func (self *holder) GetEgressProxyClientKey() string {
+	return self.egressProxyClientKey
+}
+
+// GetEgressProxyCA 'egress-proxy-ca' argument of Dashboard binary.
+func (self *holder) GetEgressProxyCA() string {
+	return self.egressProxyCA
+}
+
+// GetClusterDomain 'cluster-domain' argument of Dashboard binary.
+func (self *holder) GetClusterDomain() string {
+	return self.clusterDomain
+}
+
+// GetUsernameExtractionRegex 'username-extraction-regex' argument of Dashboard binary.
+func (self *holder) GetUsernameExtractionRegex() string {
+	return self.usernameExtractionRegex
+}
+
+// GetAPIProxyAllowedPaths 'api-proxy-allowed-paths' argument of Dashboard binary.
+func (self *holder) GetAPIProxyAllowedPaths() []string {
+	return self.apiProxyAllowedPaths
+}
+
+// GetMaxWatchesPerUser 'max-watches-per-user' argument of Dashboard binary.
+func (self *holder) GetMaxWatchesPerUser() int {
+	return self.maxWatchesPerUser
+}
+
+// GetBlockServiceAccountImpersonation 'block-service-account-impersonation' argument of Dashboard binary.
+func (self *holder) GetBlockServiceAccountImpersonation() bool {
+	return self.blockServiceAccountImpersonation
+}
+
+// GetMaxResponseSize 'max-response-size' argument of Dashboard binary.
+func (self *holder) GetMaxResponseSize() int64 {
+	return self.maxResponseSize
+}
+
+// GetInClusterQPS 'in-cluster-qps' argument of Dashboard binary.
+func (self *holder) GetInClusterQPS() float32 {
+	return self.inClusterQPS
+}
+
+// GetInClusterBurst 'in-cluster-burst' argument of Dashboard binary.
+func (self *holder) GetInClusterBurst() int {
+	return self.inClusterBurst
+}
+
+// GetOutOfClusterQPS 'out-of-cluster-qps' argument of Dashboard binary.
+func (self *holder) GetOutOfClusterQPS() float32 {
+	return self.outOfClusterQPS
+}
+
+// GetOutOfClusterBurst 'out-of-cluster-burst' argument of Dashboard binary.
+func (self *holder) GetOutOfClusterBurst() int {
+	return self.outOfClusterBurst
+}
+
+// GetTokenClockSkewLeeway 'token-clock-skew-leeway' argument of Dashboard binary.
+func (self *holder) GetTokenClockSkewLeeway() int {
+	return self.tokenClockSkewLeeway
+}
+
+// GetImpersonationAssertionSecret 'impersonation-assertion-secret' argument of Dashboard binary.
+func (self *holder) GetImpersonationAssertionSecret() string {
+	return self.impersonationAssertionSecret
+}
+
+// GetPropagatedRequestHeaders 'propagated-request-headers' argument of Dashboard binary.
+func (self *holder) GetPropagatedRequestHeaders() []string {
+	return self.propagatedRequestHeaders
+}
+
+// GetBatchGetConcurrency 'batch-get-concurrency' argument of Dashboard binary.
+func (self *holder) GetBatchGetConcurrency() int {
+	return self.batchGetConcurrency
+}
+
+// GetDefaultPageSize 'default-page-size' argument of Dashboard binary.
+func (self *holder) GetDefaultPageSize() int64 {
+	return self.defaultPageSize
+}
+
+// GetMaxPageSize 'max-page-size' argument of Dashboard binary.
+func (self *holder) GetMaxPageSize() int64 {
+	return self.maxPageSize
+}
+
+// GetMaxInformerFactories 'max-informer-factories' argument of Dashboard binary.
+func (self *holder) GetMaxInformerFactories() int {
+	return self.maxInformerFactories
+}
+
+// GetInClusterConfigAttempts 'in-cluster-config-attempts' argument of Dashboard binary.
+func (self *holder) GetInClusterConfigAttempts() int {
+	return self.inClusterConfigAttempts
+}
+
+// GetInClusterConfigRetryInterval 'in-cluster-config-retry-interval' argument of Dashboard binary.
+func (self *holder) GetInClusterConfigRetryInterval() int {
+	return self.inClusterConfigRetryInterval
+}
+
+// GetEnableKubeConfigExport 'enable-kubeconfig-export' argument of Dashboard binary.
+func (self *holder) GetEnableKubeConfigExport() bool {
+	return self.enableKubeConfigExport
+}
+
+// GetKubeConfigExportIncludeToken 'kubeconfig-export-include-token' argument of Dashboard binary.
+func (self *holder) GetKubeConfigExportIncludeToken() bool {
+	return self.kubeConfigExportIncludeToken
+}
+
+// GetMaxPortForwardsPerUser 'max-port-forwards-per-user' argument of Dashboard binary.
+func (self *holder) GetMaxPortForwardsPerUser() int {
+	return self.maxPortForwardsPerUser
+}
+
+// GetMinRefreshInterval 'min-refresh-interval' argument of Dashboard binary.
+func (self *holder) GetMinRefreshInterval() int {
+	return self.minRefreshInterval
+}
+
+// GetMaxRefreshInterval 'max-refresh-interval' argument of Dashboard binary.
+func (self *holder) GetMaxRefreshInterval() int {
+	return self.maxRefreshInterval
+}
+
+// GetNamespaceDenylist 'namespace-denylist' argument of Dashboard binary.
+func (self *holder) GetNamespaceDenylist() string {
+	return self.namespaceDenylist
+}
+
+// GetRESTMapperResetWindow 'rest-mapper-reset-window' argument of Dashboard binary.
+func (self *holder) GetRESTMapperResetWindow() int {
+	return self.restMapperResetWindow
+}
+
+// GetStuckTerminatingThreshold 'stuck-terminating-threshold' argument of Dashboard binary.
+func (self *holder) GetStuckTerminatingThreshold() int {
+	return self.stuckTerminatingThreshold
+}
+
+// GetEnableForceDelete 'enable-force-delete' argument of Dashboard binary.
+func (self *holder) GetEnableForceDelete() bool {
+	return self.enableForceDelete
+}
+
+// GetServiceProxyAllowedServices 'service-proxy-allowed-services' argument of Dashboard binary.
+func (self *holder) GetServiceProxyAllowedServices() []string {
+	return self.serviceProxyAllowedServices
+}
+
+// GetDefaultLogTailLines 'default-log-tail-lines' argument of Dashboard binary.
+func (self *holder) GetDefaultLogTailLines() int {
+	return self.defaultLogTailLines
+}
+
+// GetMaxLogTailLines 'max-log-tail-lines' argument of Dashboard binary.
+func (self *holder) GetMaxLogTailLines() int {
+	return self.maxLogTailLines
+}
+
+// GetApplyManifestConcurrency 'apply-manifest-concurrency' argument of Dashboard binary.
+func (self *holder) GetApplyManifestConcurrency() int {
+	return self.applyManifestConcurrency
+}
+
+// GetShowTerminalPods 'show-terminal-pods' argument of Dashboard binary.
+func (self *holder) GetShowTerminalPods() bool {
+	return self.showTerminalPods
+}
+
+// GetDefaultListTimeoutSeconds 'default-list-timeout-seconds' argument of Dashboard binary.
+func (self *holder) GetDefaultListTimeoutSeconds() int64 {
+	return self.defaultListTimeoutSeconds
+}
+
+// GetMaxListTimeoutSeconds 'max-list-timeout-seconds' argument of Dashboard binary.
+func (self *holder) GetMaxListTimeoutSeconds() int64 {
+	return self.maxListTimeoutSeconds
+}
+
+// GetStampManagedBy 'stamp-managed-by' argument of Dashboard binary.
+func (self *holder) GetStampManagedBy() bool {
+	return self.stampManagedBy
+}
+
+// GetSensitiveEnvPatterns 'sensitive-env-patterns' argument of Dashboard binary.
+func (self *holder) GetSensitiveEnvPatterns() []string {
+	return self.sensitiveEnvPatterns
+}
+
+// GetEnableTransportStats 'enable-transport-stats' argument of Dashboard binary.
+func (self *holder) GetEnableTransportStats() bool {
+	return self.enableTransportStats
+}
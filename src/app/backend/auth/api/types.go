@@ -29,6 +29,9 @@ const (
 
 	// Expiration time (in seconds) of tokens generated by dashboard. Default: 15 min.
 	DefaultTokenTTL = 900
+
+	// Time (in seconds) for which expired tokens are still accepted to tolerate clock skew. Default: 30 sec.
+	DefaultClockSkewLeeway = 30
 )
 
 // AuthenticationModes represents auth modes supported by dashboard.
@@ -103,6 +106,8 @@ type TokenManager interface {
 	Refresh(string) (string, error)
 	// SetTokenTTL sets expiration time (in seconds) of generated tokens.
 	SetTokenTTL(time.Duration)
+	// SetClockSkewLeeway sets time (in seconds) for which tokens are still accepted after their expiration.
+	SetClockSkewLeeway(time.Duration)
 }
 
 // Authenticator represents authentication methods supported by Dashboard. Currently supported types are:
@@ -29,6 +29,10 @@ import (
 type jweTokenManager struct {
 	keyHolder KeyHolder
 	tokenTTL  time.Duration
+	// Time for which tokens are still accepted after their expiration to tolerate clock skew between replicas.
+	clockSkewLeeway time.Duration
+	// Used to override current time in tests.
+	now func() time.Time
 }
 
 // AdditionalAuthData contains information required to validate token. It is integrity protected.
@@ -121,6 +125,15 @@ func (self *jweTokenManager) SetTokenTTL(ttl time.Duration) {
 	self.tokenTTL = ttl * time.Second
 }
 
+// SetClockSkewLeeway implements token manager interface. See TokenManager for more information.
+func (self *jweTokenManager) SetClockSkewLeeway(leeway time.Duration) {
+	if leeway < 0 {
+		leeway = 0
+	}
+
+	self.clockSkewLeeway = leeway * time.Second
+}
+
 func (self *jweTokenManager) getEncrypter() jose.Encrypter {
 	return self.keyHolder.Encrypter()
 }
@@ -147,8 +160,8 @@ func (self *jweTokenManager) validate(jweToken string) (*jose.JSONWebEncryption,
 	return jwe, nil
 }
 
-// Returns true if token has expired. In case time could not be parsed it might mean that token was tampered with and
-// token will be marked as expired. This will force user to log in again.
+// Returns true if token has expired, taking clock skew leeway into account. In case time could not be parsed it might
+// mean that token was tampered with and token will be marked as expired. This will force user to log in again.
 func (self *jweTokenManager) isExpired(iatStr, expStr string) bool {
 	iat, err := time.Parse(timeFormat, iatStr)
 	if err != nil {
@@ -160,12 +173,12 @@ func (self *jweTokenManager) isExpired(iatStr, expStr string) bool {
 		return true
 	}
 
-	age := time.Now().Sub(iat.Local())
-	return iat.Add(age).After(exp)
+	age := self.now().Sub(iat.Local())
+	return iat.Add(age).After(exp.Add(self.clockSkewLeeway))
 }
 
 func (self *jweTokenManager) generateAAD() []byte {
-	now := time.Now()
+	now := self.now()
 	aad := AdditionalAuthData{
 		IAT: now.Format(timeFormat),
 	}
@@ -180,6 +193,11 @@ func (self *jweTokenManager) generateAAD() []byte {
 
 // Creates and returns default JWE token manager instance.
 func NewJWETokenManager(holder KeyHolder) authApi.TokenManager {
-	manager := &jweTokenManager{keyHolder: holder, tokenTTL: authApi.DefaultTokenTTL * time.Second}
+	manager := &jweTokenManager{
+		keyHolder:       holder,
+		tokenTTL:        authApi.DefaultTokenTTL * time.Second,
+		clockSkewLeeway: authApi.DefaultClockSkewLeeway * time.Second,
+		now:             time.Now,
+	}
 	return manager
 }
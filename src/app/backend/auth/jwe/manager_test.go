@@ -132,6 +132,7 @@ func TestJweTokenManager_Refresh(t *testing.T) {
 	for _, c := range cases {
 		tokenManager := getTokenManager()
 		tokenManager.SetTokenTTL(1)
+		tokenManager.SetClockSkewLeeway(0)
 		token, _ := tokenManager.Generate(c.authInfo)
 
 		if len(c.authInfo.Token) == 0 {
@@ -154,3 +155,38 @@ func TestJweTokenManager_Refresh(t *testing.T) {
 		}
 	}
 }
+
+func TestJweTokenManager_ClockSkewLeeway(t *testing.T) {
+	cases := []struct {
+		info        string
+		leeway      time.Duration
+		elapsed     time.Duration
+		expectedErr error
+	}{
+		{"Should accept token before expiration", 30, 59 * time.Second, nil},
+		{"Should accept token expired within leeway", 30, 80 * time.Second, nil},
+		{"Should reject token expired beyond leeway", 30, 100 * time.Second,
+			errors.NewTokenExpired(errors.MsgTokenExpiredError)},
+		{"Should reject expired token without leeway", 0, 61 * time.Second,
+			errors.NewTokenExpired(errors.MsgTokenExpiredError)},
+	}
+
+	for _, c := range cases {
+		now := time.Now()
+		tokenManager := getTokenManager().(*jweTokenManager)
+		tokenManager.now = func() time.Time { return now }
+		tokenManager.SetTokenTTL(60)
+		tokenManager.SetClockSkewLeeway(c.leeway)
+
+		token, err := tokenManager.Generate(api.AuthInfo{Token: "test-token"})
+		if err != nil {
+			t.Fatalf("Test Case: %s. Unexpected error: %v", c.info, err)
+		}
+
+		now = now.Add(c.elapsed)
+		_, err = tokenManager.Decrypt(token)
+		if !areErrorsEqual(err, c.expectedErr) {
+			t.Errorf("Test Case: %s. Expected error to be: %v, but got %v.", c.info, c.expectedErr, err)
+		}
+	}
+}
@@ -15,11 +15,14 @@
 package auth
 
 import (
+	"io"
 	"reflect"
+	"regexp"
 	"testing"
 	"time"
 
 	restful "github.com/emicklei/go-restful/v3"
+	openapi_v2 "github.com/google/gnostic/openapiv2"
 
 	authApi "github.com/CAPS-Cloud/dashboard/src/app/backend/auth/api"
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/client"
@@ -27,8 +30,26 @@ import (
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
 
 	pluginclientset "github.com/CAPS-Cloud/dashboard/src/app/backend/plugin/client/clientset/versioned"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/common"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/container"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/event"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/horizontalpodautoscaler"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/ingress"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/namespace"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/networkpolicy"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/node"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/persistentvolumeclaim"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/pod"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/resourcequota"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/service"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/storageclass"
 	v1 "k8s.io/api/authorization/v1"
+	coreV1 "k8s.io/api/core/v1"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -94,6 +115,275 @@ func (self *fakeClientManager) CanI(req *restful.Request, ssar *v1.SelfSubjectAc
 	return true
 }
 
+func (self *fakeClientManager) AccessibleNamespaces(req *restful.Request) ([]string, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) OpenAPISchema(req *restful.Request) (*openapi_v2.Document, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) ClusterDomain() string {
+	return ""
+}
+
+func (self *fakeClientManager) BuildInfo() clientapi.BuildInfo {
+	return clientapi.BuildInfo{}
+}
+
+func (self *fakeClientManager) ProxyRequest(req *restful.Request, path string) (io.ReadCloser, int, error) {
+	return nil, 0, nil
+}
+
+func (self *fakeClientManager) Watch(req *restful.Request, gvr schema.GroupVersionResource, namespace string,
+	opts metaV1.ListOptions) (watch.Interface, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) TransportStats() map[string]clientapi.ConnectionStats {
+	return nil
+}
+
+func (self *fakeClientManager) APIServiceHealth(req *restful.Request) (map[string]bool, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) SetUsernameResolver(resolver clientapi.UsernameResolver) {}
+
+func (self *fakeClientManager) StreamPodLogs(req *restful.Request, namespace, pod, container string,
+	opts clientapi.LogStreamOptions) (io.ReadCloser, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) AuthMode() clientapi.AuthMode {
+	return clientapi.AuthModeNone
+}
+
+func (self *fakeClientManager) ResourceEvents(req *restful.Request, namespace, kind, name string) (*coreV1.EventList, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) InformerFactory(req *restful.Request, namespace string) (clientapi.InformerFactory, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) PodSecurityLevels(req *restful.Request) (map[string]namespace.PSALevels, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) ResolveOwners(req *restful.Request, obj metaV1.Object, namespace string) ([]clientapi.OwnerRef, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) RequestToken(req *restful.Request, serviceAccount, namespace string,
+	audiences []string, ttl time.Duration) (string, error) {
+	return "", nil
+}
+
+func (self *fakeClientManager) NamespaceAccessSummary(req *restful.Request, namespace string) (map[string]bool, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) ExportKubeConfig(req *restful.Request) ([]byte, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) ListCustomResources(req *restful.Request, gvr schema.GroupVersionResource,
+	namespace string, opts metaV1.ListOptions, listSort *common.ListSort) (*unstructured.UnstructuredList, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) ListMetadata(req *restful.Request, gvr schema.GroupVersionResource, namespace string,
+	opts metaV1.ListOptions, listSort *common.ListSort) (*metaV1.PartialObjectMetadataList, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) EvictPod(req *restful.Request, namespace, name string, gracePeriod *int64) error {
+	return nil
+}
+
+func (self *fakeClientManager) ScaleResource(req *restful.Request, gvr schema.GroupVersionResource, namespace,
+	name string, replicas int32) (int32, error) {
+	return 0, nil
+}
+
+func (self *fakeClientManager) NamespaceQuota(req *restful.Request, namespace string) (*resourcequota.QuotaSummary, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) SetNodeSchedulable(req *restful.Request, nodeName string, schedulable bool) error {
+	return nil
+}
+
+func (self *fakeClientManager) NodeDrainReadiness(req *restful.Request, nodeName string) (*node.DrainReadiness, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) WorkloadImages(req *restful.Request, namespace string) ([]container.ImageRef, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) RolloutStatus(req *restful.Request, gvr schema.GroupVersionResource, namespace,
+	name string) (clientapi.RolloutStatus, error) {
+	return clientapi.RolloutStatus{}, nil
+}
+
+func (self *fakeClientManager) RestartWorkload(req *restful.Request, gvr schema.GroupVersionResource, namespace,
+	name string) error {
+	return nil
+}
+
+func (self *fakeClientManager) GetSecretDecoded(req *restful.Request, namespace, name string) (map[string]string, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) AttachToContainer(req *restful.Request, namespace, pod, container string,
+	opts clientapi.AttachOptions) error {
+	return nil
+}
+
+func (self *fakeClientManager) PortForward(req *restful.Request, namespace, pod string, port int32) (io.ReadWriteCloser, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) ContainerRestartInfo(req *restful.Request, namespace, pod string) ([]container.ContainerRestart, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) RefreshHint(req *restful.Request) (time.Duration, error) {
+	return 0, nil
+}
+
+func (self *fakeClientManager) DiffResource(req *restful.Request, gvr schema.GroupVersionResource, namespace string,
+	proposed *unstructured.Unstructured) (string, error) {
+	return "", nil
+}
+
+func (self *fakeClientManager) InsecureClientMetrics() map[string]clientapi.EndpointMetrics {
+	return nil
+}
+
+func (self *fakeClientManager) NamespaceDenylist(req *restful.Request) (*regexp.Regexp, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) NodeStatsSummary(req *restful.Request, nodeName string) (*node.StatsSummary, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) FilterPresets(req *restful.Request) ([]clientapi.FilterPreset, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) ServiceBackends(req *restful.Request, namespace, name string) ([]service.PodRef, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) WorkloadHPA(req *restful.Request, namespace, targetKind,
+	targetName string) (*horizontalpodautoscaler.HPASummary, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) StreamPodLogsAllContainers(req *restful.Request, namespace, pod string,
+	opts clientapi.LogStreamOptions) (io.ReadCloser, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) ValidateImagePullSecrets(req *restful.Request, namespace string,
+	secretNames []string) ([]clientapi.PullSecretStatus, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) ListTable(req *restful.Request, gvr schema.GroupVersionResource, namespace string,
+	opts metaV1.ListOptions) (*metaV1.Table, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) GroupedEvents(req *restful.Request, namespace string,
+	opts event.GroupedEventsOptions) ([]event.EventGroup, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) StuckTerminating(req *restful.Request, namespace string) ([]clientapi.StuckResource, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) ForceDelete(req *restful.Request, gvr schema.GroupVersionResource, namespace, name string) error {
+	return nil
+}
+
+func (self *fakeClientManager) PVCDetails(req *restful.Request, namespace, name string) (*persistentvolumeclaim.PVCDetail, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) EffectivePermissions(req *restful.Request, subject clientapi.Subject) ([]clientapi.PolicyRule, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) NodeAllocationSummary(req *restful.Request, nodeName string) (*node.AllocationSummary, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) ProxyService(req *restful.Request, namespace, service, path string) (io.ReadCloser, int, error) {
+	return nil, 0, nil
+}
+
+func (self *fakeClientManager) JobStatuses(req *restful.Request, namespace string) ([]clientapi.JobStatus, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) IngressRoutes(req *restful.Request, namespace, name string) ([]ingress.IngressRoute, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) ExportResourceYAML(req *restful.Request, gvr schema.GroupVersionResource, namespace, name string) ([]byte, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) PoliciesForPod(req *restful.Request, namespace, pod string) ([]networkpolicy.NetworkPolicySummary, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) ResourcesInCategory(req *restful.Request, category string) ([]schema.GroupVersionResource, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) ValidateManifest(req *restful.Request, manifest []byte) ([]clientapi.ValidationError, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) ApplyManifest(req *restful.Request, manifest []byte, opts clientapi.ApplyOptions) ([]clientapi.ApplyResult, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) ContainerProbes(req *restful.Request, namespace, pod, container string) (*container.ProbeSummary, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) PodConfigReferences(req *restful.Request, namespace, podName string) (*pod.ConfigReferences, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) StorageClasses(req *restful.Request) ([]storageclass.StorageClassSummary, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) PodUsage(req *restful.Request, namespace string) (pod.PodUsage, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) AdmissionWebhooks(req *restful.Request) ([]clientapi.WebhookSummary, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) PodSchedulingStatus(req *restful.Request, namespace, podName string) (*pod.SchedulingStatus, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) CronJobSchedules(req *restful.Request, namespace string) ([]clientapi.CronJobSummary, error) {
+	return nil, nil
+}
+
 type fakeTokenManager struct {
 	GeneratedToken string
 	Error          error
@@ -105,6 +395,8 @@ func (self *fakeTokenManager) Refresh(string) (string, error) {
 
 func (self *fakeTokenManager) SetTokenTTL(time.Duration) {}
 
+func (self *fakeTokenManager) SetClockSkewLeeway(time.Duration) {}
+
 func (self *fakeTokenManager) Generate(authInfo api.AuthInfo) (string, error) {
 	return self.GeneratedToken, self.Error
 }
@@ -0,0 +1,168 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"sort"
+
+	admission "k8s.io/api/admissionregistration/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/emicklei/go-restful/v3"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+// Types of the admission webhooks.
+const (
+	ValidatingWebhookType = "Validating"
+	MutatingWebhookType   = "Mutating"
+)
+
+// Values used by the apiserver when webhook does not set them.
+const (
+	defaultWebhookTimeoutSeconds = 10
+	defaultWebhookServicePort    = 443
+)
+
+// AdmissionWebhooks returns summaries of the webhooks of all validating and mutating webhook configurations using
+// credentials of the user, sorted by configuration and webhook name. Fail-closed webhooks are flagged, as requests
+// they match are rejected whenever the webhook is not reachable.
+func (self *clientManager) AdmissionWebhooks(req *restful.Request) ([]clientapi.WebhookSummary, error) {
+	client, err := self.Client(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return admissionWebhooks(client)
+}
+
+func admissionWebhooks(client kubernetes.Interface) ([]clientapi.WebhookSummary, error) {
+	api := client.AdmissionregistrationV1()
+	validating, err := api.ValidatingWebhookConfigurations().List(context.TODO(), metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	mutating, err := api.MutatingWebhookConfigurations().List(context.TODO(), metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]clientapi.WebhookSummary, 0)
+	for _, configuration := range validating.Items {
+		for _, webhook := range configuration.Webhooks {
+			result = append(result, toWebhookSummary(configuration.Name, ValidatingWebhookType, webhook))
+		}
+	}
+
+	for _, configuration := range mutating.Items {
+		for _, webhook := range configuration.Webhooks {
+			// Fields shared with validating webhooks are copied, so that both types are summarized the same way.
+			result = append(result, toWebhookSummary(configuration.Name, MutatingWebhookType,
+				admission.ValidatingWebhook{
+					Name:              webhook.Name,
+					ClientConfig:      webhook.ClientConfig,
+					Rules:             webhook.Rules,
+					FailurePolicy:     webhook.FailurePolicy,
+					NamespaceSelector: webhook.NamespaceSelector,
+					ObjectSelector:    webhook.ObjectSelector,
+					SideEffects:       webhook.SideEffects,
+					TimeoutSeconds:    webhook.TimeoutSeconds,
+				}))
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Configuration != result[j].Configuration {
+			return result[i].Configuration < result[j].Configuration
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// Failure policy, timeout and port are reported with the values used by the apiserver when they are not set.
+func toWebhookSummary(configuration, webhookType string, webhook admission.ValidatingWebhook) clientapi.WebhookSummary {
+	summary := clientapi.WebhookSummary{
+		Configuration:  configuration,
+		Type:           webhookType,
+		Name:           webhook.Name,
+		Rules:          make([]clientapi.WebhookRule, 0, len(webhook.Rules)),
+		FailurePolicy:  string(admission.Fail),
+		TimeoutSeconds: defaultWebhookTimeoutSeconds,
+	}
+
+	for _, rule := range webhook.Rules {
+		webhookRule := clientapi.WebhookRule{
+			APIGroups:   rule.APIGroups,
+			APIVersions: rule.APIVersions,
+			Resources:   rule.Resources,
+			Scope:       string(admission.AllScopes),
+		}
+		for _, operation := range rule.Operations {
+			webhookRule.Operations = append(webhookRule.Operations, string(operation))
+		}
+		if rule.Scope != nil {
+			webhookRule.Scope = string(*rule.Scope)
+		}
+		summary.Rules = append(summary.Rules, webhookRule)
+	}
+
+	if webhook.FailurePolicy != nil {
+		summary.FailurePolicy = string(*webhook.FailurePolicy)
+	}
+	summary.FailClosed = summary.FailurePolicy == string(admission.Fail)
+
+	if webhook.TimeoutSeconds != nil {
+		summary.TimeoutSeconds = *webhook.TimeoutSeconds
+	}
+
+	if webhook.SideEffects != nil {
+		summary.SideEffects = string(*webhook.SideEffects)
+	}
+
+	summary.NamespaceSelector = formatWebhookSelector(webhook.NamespaceSelector)
+	summary.ObjectSelector = formatWebhookSelector(webhook.ObjectSelector)
+
+	if service := webhook.ClientConfig.Service; service != nil {
+		summary.Service = &clientapi.WebhookService{Namespace: service.Namespace, Name: service.Name,
+			Port: defaultWebhookServicePort}
+		if service.Path != nil {
+			summary.Service.Path = *service.Path
+		}
+		if service.Port != nil {
+			summary.Service.Port = *service.Port
+		}
+	}
+
+	if webhook.ClientConfig.URL != nil {
+		summary.URL = *webhook.ClientConfig.URL
+	}
+
+	return summary
+}
+
+// Returns selector in the canonical form, empty selector matching everything is returned as empty string.
+func formatWebhookSelector(selector *metaV1.LabelSelector) string {
+	if selector == nil || len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0 {
+		return ""
+	}
+
+	return metaV1.FormatLabelSelector(selector)
+}
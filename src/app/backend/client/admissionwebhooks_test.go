@@ -0,0 +1,100 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	admission "k8s.io/api/admissionregistration/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+func TestAdmissionWebhooks(t *testing.T) {
+	ignore := admission.Ignore
+	none := admission.SideEffectClassNone
+	namespaced := admission.NamespacedScope
+	path := "/validate"
+	port := int32(8443)
+	timeout := int32(5)
+	url := "https://policy.example.com/mutate"
+	client := fake.NewSimpleClientset(
+		&admission.ValidatingWebhookConfiguration{
+			ObjectMeta: metaV1.ObjectMeta{Name: "policy"},
+			Webhooks: []admission.ValidatingWebhook{
+				{
+					Name: "pods.policy.example.com",
+					ClientConfig: admission.WebhookClientConfig{Service: &admission.ServiceReference{
+						Namespace: "policy", Name: "webhook", Path: &path, Port: &port}},
+					Rules: []admission.RuleWithOperations{{
+						Operations: []admission.OperationType{admission.Create, admission.Update},
+						Rule: admission.Rule{APIGroups: []string{""}, APIVersions: []string{"v1"},
+							Resources: []string{"pods"}, Scope: &namespaced},
+					}},
+					SideEffects: &none,
+					NamespaceSelector: &metaV1.LabelSelector{
+						MatchLabels: map[string]string{"policy": "enforced"}},
+				},
+				{
+					Name: "audit.policy.example.com",
+					ClientConfig: admission.WebhookClientConfig{Service: &admission.ServiceReference{
+						Namespace: "policy", Name: "audit"}},
+					FailurePolicy:  &ignore,
+					TimeoutSeconds: &timeout,
+					ObjectSelector: &metaV1.LabelSelector{},
+				},
+			},
+		},
+		&admission.MutatingWebhookConfiguration{
+			ObjectMeta: metaV1.ObjectMeta{Name: "defaults"},
+			Webhooks: []admission.MutatingWebhook{{
+				Name:         "defaults.example.com",
+				ClientConfig: admission.WebhookClientConfig{URL: &url},
+				Rules: []admission.RuleWithOperations{{
+					Operations: []admission.OperationType{admission.OperationAll},
+					Rule: admission.Rule{APIGroups: []string{"apps"}, APIVersions: []string{"*"},
+						Resources: []string{"deployments"}},
+				}},
+			}},
+		},
+	)
+
+	actual, err := admissionWebhooks(client)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []clientapi.WebhookSummary{
+		{Configuration: "defaults", Type: MutatingWebhookType, Name: "defaults.example.com",
+			Rules: []clientapi.WebhookRule{{Operations: []string{"*"}, APIGroups: []string{"apps"},
+				APIVersions: []string{"*"}, Resources: []string{"deployments"}, Scope: "*"}},
+			FailurePolicy: "Fail", FailClosed: true, TimeoutSeconds: 10, URL: url},
+		{Configuration: "policy", Type: ValidatingWebhookType, Name: "audit.policy.example.com",
+			Rules: []clientapi.WebhookRule{}, FailurePolicy: "Ignore", TimeoutSeconds: 5,
+			Service: &clientapi.WebhookService{Namespace: "policy", Name: "audit", Port: 443}},
+		{Configuration: "policy", Type: ValidatingWebhookType, Name: "pods.policy.example.com",
+			Rules: []clientapi.WebhookRule{{Operations: []string{"CREATE", "UPDATE"}, APIGroups: []string{""},
+				APIVersions: []string{"v1"}, Resources: []string{"pods"}, Scope: "Namespaced"}},
+			FailurePolicy: "Fail", FailClosed: true, TimeoutSeconds: 10, SideEffects: "None",
+			NamespaceSelector: "policy=enforced",
+			Service:           &clientapi.WebhookService{Namespace: "policy", Name: "webhook", Path: path, Port: 8443}},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected webhooks:\n%+v\ngot:\n%+v", expected, actual)
+	}
+}
@@ -15,18 +15,46 @@
 package api
 
 import (
+	"context"
+	"io"
+	"regexp"
+	"time"
+
+	openapi_v2 "github.com/google/gnostic/openapiv2"
 	v1 "k8s.io/api/authorization/v1"
+	coreV1 "k8s.io/api/core/v1"
+	rbac "k8s.io/api/rbac/v1"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/tools/remotecommand"
 
 	"github.com/emicklei/go-restful/v3"
 
 	authApi "github.com/CAPS-Cloud/dashboard/src/app/backend/auth/api"
 	pluginclientset "github.com/CAPS-Cloud/dashboard/src/app/backend/plugin/client/clientset/versioned"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/common"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/container"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/event"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/horizontalpodautoscaler"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/ingress"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/namespace"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/networkpolicy"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/node"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/persistentvolumeclaim"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/pod"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/resourcequota"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/service"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/storageclass"
 )
 
 const (
@@ -53,14 +81,100 @@ type ClientManager interface {
 	HasAccess(authInfo api.AuthInfo) (string, error)
 	VerberClient(req *restful.Request, config *rest.Config) (ResourceVerber, error)
 	SetTokenManager(manager authApi.TokenManager)
+	AccessibleNamespaces(req *restful.Request) ([]string, error)
+	OpenAPISchema(req *restful.Request) (*openapi_v2.Document, error)
+	ClusterDomain() string
+	BuildInfo() BuildInfo
+	ProxyRequest(req *restful.Request, path string) (io.ReadCloser, int, error)
+	Watch(req *restful.Request, gvr schema.GroupVersionResource, namespace string, opts metaV1.ListOptions) (
+		watch.Interface, error)
+	TransportStats() map[string]ConnectionStats
+	APIServiceHealth(req *restful.Request) (map[string]bool, error)
+	SetUsernameResolver(resolver UsernameResolver)
+	StreamPodLogs(req *restful.Request, namespace, pod, container string,
+		opts LogStreamOptions) (io.ReadCloser, error)
+	AuthMode() AuthMode
+	ResourceEvents(req *restful.Request, namespace, kind, name string) (*coreV1.EventList, error)
+	InformerFactory(req *restful.Request, namespace string) (InformerFactory, error)
+	PodSecurityLevels(req *restful.Request) (map[string]namespace.PSALevels, error)
+	ResolveOwners(req *restful.Request, obj metaV1.Object, namespace string) ([]OwnerRef, error)
+	RequestToken(req *restful.Request, serviceAccount, namespace string, audiences []string,
+		ttl time.Duration) (string, error)
+	NamespaceAccessSummary(req *restful.Request, namespace string) (map[string]bool, error)
+	ExportKubeConfig(req *restful.Request) ([]byte, error)
+	ListCustomResources(req *restful.Request, gvr schema.GroupVersionResource, namespace string,
+		opts metaV1.ListOptions, listSort *common.ListSort) (*unstructured.UnstructuredList, error)
+	ListMetadata(req *restful.Request, gvr schema.GroupVersionResource, namespace string, opts metaV1.ListOptions,
+		listSort *common.ListSort) (*metaV1.PartialObjectMetadataList, error)
+	EvictPod(req *restful.Request, namespace, name string, gracePeriod *int64) error
+	ScaleResource(req *restful.Request, gvr schema.GroupVersionResource, namespace, name string, replicas int32) (
+		int32, error)
+	NamespaceQuota(req *restful.Request, namespace string) (*resourcequota.QuotaSummary, error)
+	SetNodeSchedulable(req *restful.Request, nodeName string, schedulable bool) error
+	NodeDrainReadiness(req *restful.Request, nodeName string) (*node.DrainReadiness, error)
+	WorkloadImages(req *restful.Request, namespace string) ([]container.ImageRef, error)
+	RolloutStatus(req *restful.Request, gvr schema.GroupVersionResource, namespace, name string) (RolloutStatus,
+		error)
+	RestartWorkload(req *restful.Request, gvr schema.GroupVersionResource, namespace, name string) error
+	GetSecretDecoded(req *restful.Request, namespace, name string) (map[string]string, error)
+	AttachToContainer(req *restful.Request, namespace, pod, container string, opts AttachOptions) error
+	PortForward(req *restful.Request, namespace, pod string, port int32) (io.ReadWriteCloser, error)
+	ContainerRestartInfo(req *restful.Request, namespace, pod string) ([]container.ContainerRestart, error)
+	RefreshHint(req *restful.Request) (time.Duration, error)
+	DiffResource(req *restful.Request, gvr schema.GroupVersionResource, namespace string,
+		proposed *unstructured.Unstructured) (string, error)
+	InsecureClientMetrics() map[string]EndpointMetrics
+	NamespaceDenylist(req *restful.Request) (*regexp.Regexp, error)
+	NodeStatsSummary(req *restful.Request, nodeName string) (*node.StatsSummary, error)
+	FilterPresets(req *restful.Request) ([]FilterPreset, error)
+	ServiceBackends(req *restful.Request, namespace, name string) ([]service.PodRef, error)
+	WorkloadHPA(req *restful.Request, namespace, targetKind, targetName string) (
+		*horizontalpodautoscaler.HPASummary, error)
+	StreamPodLogsAllContainers(req *restful.Request, namespace, pod string, opts LogStreamOptions) (io.ReadCloser,
+		error)
+	ValidateImagePullSecrets(req *restful.Request, namespace string, secretNames []string) ([]PullSecretStatus,
+		error)
+	ListTable(req *restful.Request, gvr schema.GroupVersionResource, namespace string, opts metaV1.ListOptions) (
+		*metaV1.Table, error)
+	GroupedEvents(req *restful.Request, namespace string, opts event.GroupedEventsOptions) ([]event.EventGroup,
+		error)
+	StuckTerminating(req *restful.Request, namespace string) ([]StuckResource, error)
+	ForceDelete(req *restful.Request, gvr schema.GroupVersionResource, namespace, name string) error
+	PVCDetails(req *restful.Request, namespace, name string) (*persistentvolumeclaim.PVCDetail, error)
+	EffectivePermissions(req *restful.Request, subject Subject) ([]PolicyRule, error)
+	NodeAllocationSummary(req *restful.Request, nodeName string) (*node.AllocationSummary, error)
+	ProxyService(req *restful.Request, namespace, service, path string) (io.ReadCloser, int, error)
+	JobStatuses(req *restful.Request, namespace string) ([]JobStatus, error)
+	IngressRoutes(req *restful.Request, namespace, name string) ([]ingress.IngressRoute, error)
+	ExportResourceYAML(req *restful.Request, gvr schema.GroupVersionResource, namespace, name string) ([]byte, error)
+	PoliciesForPod(req *restful.Request, namespace, pod string) ([]networkpolicy.NetworkPolicySummary, error)
+	ResourcesInCategory(req *restful.Request, category string) ([]schema.GroupVersionResource, error)
+	ValidateManifest(req *restful.Request, manifest []byte) ([]ValidationError, error)
+	ApplyManifest(req *restful.Request, manifest []byte, opts ApplyOptions) ([]ApplyResult, error)
+	ContainerProbes(req *restful.Request, namespace, pod, container string) (*container.ProbeSummary, error)
+	PodConfigReferences(req *restful.Request, namespace, podName string) (*pod.ConfigReferences, error)
+	StorageClasses(req *restful.Request) ([]storageclass.StorageClassSummary, error)
+	PodUsage(req *restful.Request, namespace string) (pod.PodUsage, error)
+	AdmissionWebhooks(req *restful.Request) ([]WebhookSummary, error)
+	PodSchedulingStatus(req *restful.Request, namespace, podName string) (*pod.SchedulingStatus, error)
+	CronJobSchedules(req *restful.Request, namespace string) ([]CronJobSummary, error)
+}
+
+// UsernameResolver is responsible for mapping usernames extracted from the apiserver responses to the user-friendly
+// names, i.e. based on the LDAP directory.
+type UsernameResolver interface {
+	// Resolve returns name that should be displayed for the given username.
+	Resolve(username string) string
 }
 
 // ResourceVerber is responsible for performing generic CRUD operations on all supported resources.
 type ResourceVerber interface {
-	Put(kind string, namespaceSet bool, namespace string, name string,
-		object *runtime.Unknown) error
-	Get(kind string, namespaceSet bool, namespace string, name string) (runtime.Object, error)
-	Delete(kind string, namespaceSet bool, namespace string, name string) error
+	Put(kind string, namespaceSet bool, namespace string, name string, subresource string,
+		object *runtime.Unknown, dryRun bool) (runtime.Object, error)
+	Get(kind string, namespaceSet bool, namespace string, name string, subresource string) (runtime.Object, error)
+	Delete(kind string, namespaceSet bool, namespace string, name string, dryRun bool) (runtime.Object, error)
+	Apply(kind string, namespaceSet bool, namespace string, name string, object *runtime.Unknown, force,
+		dryRun bool) (runtime.Object, error)
 }
 
 // CanIResponse is used to as response to check whether or not user is allowed to access given endpoint.
@@ -73,3 +187,294 @@ type CsrfTokenManager interface {
 	// Token returns current csrf token used for csrf signing.
 	Token() string
 }
+
+// BuildInfo contains version of the dashboard and effective configuration of the clients used to communicate with
+// the apiserver. It is used for support diagnostics and must never contain credentials or content of any files.
+type BuildInfo struct {
+	// Version of the dashboard binary.
+	Version string `json:"version"`
+	// APIServerHost is address of the apiserver with credentials removed.
+	APIServerHost string `json:"apiServerHost"`
+	// InCluster is true if in-cluster config is used to connect to the apiserver.
+	InCluster bool `json:"inCluster"`
+	// KubeConfigProvided is true if kubeconfig file was passed to the dashboard.
+	KubeConfigProvided bool    `json:"kubeConfigProvided"`
+	QPS                float32 `json:"qps"`
+	Burst              int     `json:"burst"`
+	ContentType        string  `json:"contentType"`
+	UserAgent          string  `json:"userAgent"`
+	// AuthenticationModes lists enabled login options.
+	AuthenticationModes []string `json:"authenticationModes"`
+	EnableSkipLogin     bool     `json:"enableSkipLogin"`
+	EnableInsecureLogin bool     `json:"enableInsecureLogin"`
+	// EgressProxyMutualTLS is true if client certificate is used to connect to the egress proxy.
+	EgressProxyMutualTLS bool `json:"egressProxyMutualTLS"`
+}
+
+// ConnectionStats contains number of connections to a single host held by the client transports.
+type ConnectionStats struct {
+	Active int `json:"active"`
+	Idle   int `json:"idle"`
+}
+
+// EndpointMetrics contains number of requests sent to a single apiserver endpoint and their latencies. Latency is
+// measured until response headers are received.
+type EndpointMetrics struct {
+	Requests       int64         `json:"requests"`
+	Errors         int64         `json:"errors"`
+	AverageLatency time.Duration `json:"averageLatency"`
+	MaxLatency     time.Duration `json:"maxLatency"`
+}
+
+// LogStreamOptions contains options of the pod log stream.
+type LogStreamOptions struct {
+	// Follow keeps the stream open and sends new log lines as they are written.
+	Follow bool `json:"follow"`
+	// Timestamps prefixes every log line with its timestamp.
+	Timestamps bool `json:"timestamps"`
+	// SinceTime limits the stream to the log lines written after given time.
+	SinceTime *time.Time `json:"sinceTime,omitempty"`
+	// TailLines limits the stream to the given number of last log lines.
+	TailLines *int64 `json:"tailLines,omitempty"`
+}
+
+// AuthMode describes mechanism used by the dashboard to authenticate to the apiserver.
+type AuthMode string
+
+const (
+	// AuthModeInCluster is used when dashboard authenticates with its in-cluster service account.
+	AuthModeInCluster AuthMode = "in-cluster"
+	// AuthModeClientCertificate is used when dashboard authenticates with x509 client certificate.
+	AuthModeClientCertificate AuthMode = "client-certificate"
+	// AuthModeTokenFile is used when dashboard authenticates with bearer token read from a file.
+	AuthModeTokenFile AuthMode = "token-file"
+	// AuthModeToken is used when dashboard authenticates with bearer token.
+	AuthModeToken AuthMode = "token"
+	// AuthModeBasic is used when dashboard authenticates with username and password.
+	AuthModeBasic AuthMode = "basic"
+	// AuthModeExec is used when credentials are provided by the exec plugin.
+	AuthModeExec AuthMode = "exec"
+	// AuthModeAuthProvider is used when credentials are provided by the auth provider plugin.
+	AuthModeAuthProvider AuthMode = "auth-provider"
+	// AuthModeNone is used when dashboard does not authenticate to the apiserver.
+	AuthModeNone AuthMode = "none"
+)
+
+// BatchGetRequest describes single get operation executed as a part of the batch.
+type BatchGetRequest struct {
+	// ID identifies the result of the operation in the batch.
+	ID string
+	// Get fetches the resource. Context is shared by all operations in the batch.
+	Get func(ctx context.Context) (interface{}, error)
+}
+
+// BatchGetResult contains result of single get operation executed as a part of the batch.
+type BatchGetResult struct {
+	Object interface{}
+	Error  error
+}
+
+// InformerFactory provides access to the shared informer factory scoped to a single namespace. Factory is shared by
+// all requests of the same user to the same namespace and its informers are stopped once it is released by all of
+// them.
+type InformerFactory interface {
+	// Factory returns underlying shared informer factory. Informers have to be requested before calling Start.
+	Factory() informers.SharedInformerFactory
+	// Start starts all informers requested from the factory so far.
+	Start()
+	// Release marks factory as no longer used by the caller. Calling it more than once has no effect.
+	Release()
+}
+
+// OwnerRef describes single owner in the chain of the object owners.
+type OwnerRef struct {
+	APIVersion string    `json:"apiVersion"`
+	Kind       string    `json:"kind"`
+	Name       string    `json:"name"`
+	Namespace  string    `json:"namespace,omitempty"`
+	UID        types.UID `json:"uid"`
+	// Missing is set when the owner referenced by the object does not exist.
+	Missing bool `json:"missing,omitempty"`
+}
+
+// RolloutStatus describes progress of the workload rollout.
+type RolloutStatus struct {
+	// Message describes the progress, in the same way as 'kubectl rollout status' does.
+	Message string `json:"message"`
+	// Complete is true when the rollout finished.
+	Complete bool `json:"complete"`
+}
+
+// AttachOptions describes streams connected to the main process of the container.
+type AttachOptions struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+	// TerminalSizeQueue is used only when container has TTY allocated.
+	TerminalSizeQueue remotecommand.TerminalSizeQueue
+}
+
+// FilterPreset is a named label selector that can be used as a quick filter of resource lists.
+type FilterPreset struct {
+	Name string `json:"name"`
+	// LabelSelector in the canonical form accepted by 'labelSelector' list parameter.
+	LabelSelector string `json:"labelSelector"`
+}
+
+// PullSecretStatus describes whether the image pull secret is well-formed. Credentials are never included.
+type PullSecretStatus struct {
+	Name  string `json:"name"`
+	Found bool   `json:"found"`
+	Type  string `json:"type,omitempty"`
+	// Valid is true when secret could be parsed and every registry has credentials.
+	Valid      bool                 `json:"valid"`
+	Registries []PullSecretRegistry `json:"registries"`
+	// Error describes why the secret is not valid.
+	Error string `json:"error,omitempty"`
+}
+
+// PullSecretRegistry is a registry covered by the image pull secret.
+type PullSecretRegistry struct {
+	Server         string `json:"server"`
+	Username       string `json:"username,omitempty"`
+	HasCredentials bool   `json:"hasCredentials"`
+}
+
+// StuckResource is an object that is being deleted but is kept by its finalizers.
+type StuckResource struct {
+	Group             string      `json:"group"`
+	Version           string      `json:"version"`
+	Resource          string      `json:"resource"`
+	Namespace         string      `json:"namespace,omitempty"`
+	Name              string      `json:"name"`
+	DeletionTimestamp metaV1.Time `json:"deletionTimestamp"`
+	// Finalizers that have to be removed before the object is deleted.
+	Finalizers []string `json:"finalizers"`
+}
+
+// Subject is a user, group or service account whose effective permissions are resolved.
+type Subject struct {
+	// Kind is one of rbac.UserKind, rbac.GroupKind or rbac.ServiceAccountKind.
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	// Groups the subject belongs to. Bindings referencing any of them are taken into account as well.
+	Groups []string `json:"groups,omitempty"`
+}
+
+// PolicyRule is a rule granted to the subject. Empty namespace means that the rule applies cluster-wide.
+type PolicyRule struct {
+	rbac.PolicyRule `json:",inline"`
+	Namespace       string `json:"namespace,omitempty"`
+}
+
+// JobStatus is a concise summary of the job and its pods.
+type JobStatus struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Status is one of Running, Complete or Failed.
+	Status    string `json:"status"`
+	Active    int32  `json:"active"`
+	Succeeded int32  `json:"succeeded"`
+	Failed    int32  `json:"failed"`
+	// Completions is the desired number of successfully finished pods, nil means any one pod succeeding is enough.
+	Completions    *int32 `json:"completions,omitempty"`
+	CompletionMode string `json:"completionMode"`
+	BackoffLimit   int32  `json:"backoffLimit"`
+	// BackoffLimitExceeded is set when the job was given up after too many failed pods.
+	BackoffLimitExceeded bool `json:"backoffLimitExceeded"`
+	// CronJob is the name of the cron job that created the job, if any.
+	CronJob string `json:"cronJob,omitempty"`
+}
+
+// ValidationError describes a single problem found in a document of the validated manifest.
+type ValidationError struct {
+	// Document is the index of the document in the multi-document manifest.
+	Document int    `json:"document"`
+	Kind     string `json:"kind,omitempty"`
+	Name     string `json:"name,omitempty"`
+	// Field is the path of the invalid field reported by the apiserver, i.e. "spec.replicas".
+	Field string `json:"field,omitempty"`
+	// Reason is the type of the cause, i.e. "FieldValueInvalid", or the reason of the error if it has no causes.
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// ApplyOptions describes how the documents of the manifest are applied.
+type ApplyOptions struct {
+	// Namespace of the namespaced objects that do not specify one.
+	Namespace string `json:"namespace"`
+	// Force takes ownership of the fields managed by other field managers instead of failing with a conflict.
+	Force  bool `json:"force"`
+	DryRun bool `json:"dryRun"`
+}
+
+// ApplyResult describes the outcome of applying a single document of the manifest.
+type ApplyResult struct {
+	// Document is the index of the document in the multi-document manifest.
+	Document   int    `json:"document"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+	// Error describes why the document was not applied. Empty error means that it was applied successfully.
+	Error string `json:"error,omitempty"`
+}
+
+// WebhookSummary describes a single webhook of the validating or mutating webhook configuration.
+type WebhookSummary struct {
+	// Configuration is the name of the webhook configuration that contains the webhook.
+	Configuration string `json:"configuration"`
+	// Type is either Validating or Mutating.
+	Type          string        `json:"type"`
+	Name          string        `json:"name"`
+	Rules         []WebhookRule `json:"rules"`
+	FailurePolicy string        `json:"failurePolicy"`
+	// FailClosed is true when requests matching the rules are rejected if the webhook cannot be called.
+	FailClosed        bool   `json:"failClosed"`
+	TimeoutSeconds    int32  `json:"timeoutSeconds"`
+	SideEffects       string `json:"sideEffects,omitempty"`
+	NamespaceSelector string `json:"namespaceSelector,omitempty"`
+	ObjectSelector    string `json:"objectSelector,omitempty"`
+	// Service called by the webhook, nil when webhook calls an URL.
+	Service *WebhookService `json:"service,omitempty"`
+	URL     string          `json:"url,omitempty"`
+}
+
+// WebhookRule describes operations on resources that are sent to the webhook.
+type WebhookRule struct {
+	Operations  []string `json:"operations"`
+	APIGroups   []string `json:"apiGroups"`
+	APIVersions []string `json:"apiVersions"`
+	Resources   []string `json:"resources"`
+	Scope       string   `json:"scope"`
+}
+
+// WebhookService is the service called by the webhook.
+type WebhookService struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Path      string `json:"path,omitempty"`
+	Port      int32  `json:"port"`
+}
+
+// CronJobSummary describes when the cron job runs next and how its last run went.
+type CronJobSummary struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Schedule  string `json:"schedule"`
+	// TimeZone of the schedule, empty means the time zone of the controller manager, which is assumed to be UTC.
+	TimeZone  string `json:"timeZone,omitempty"`
+	Suspended bool   `json:"suspended"`
+	// NextSchedule is nil when the cron job is suspended or its schedule cannot be parsed.
+	NextSchedule       *metaV1.Time `json:"nextSchedule"`
+	LastSchedule       *metaV1.Time `json:"lastSchedule"`
+	LastSuccessfulTime *metaV1.Time `json:"lastSuccessfulTime"`
+	Active             int          `json:"active"`
+	// LastJob is the name of the most recent job created by the cron job and LastRunStatus is its status, one of
+	// Running, Complete or Failed. Both are empty when the cron job has not run yet.
+	LastJob       string `json:"lastJob,omitempty"`
+	LastRunStatus string `json:"lastRunStatus,omitempty"`
+	// Error explains why the next schedule could not be computed.
+	Error string `json:"error,omitempty"`
+}
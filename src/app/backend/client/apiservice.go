@@ -0,0 +1,72 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// APIServicesGVR identifies APIService resources registered in the aggregation layer.
+var APIServicesGVR = schema.GroupVersionResource{Group: "apiregistration.k8s.io", Version: "v1", Resource: "apiservices"}
+
+// APIServiceHealth returns availability of every APIService registered in the cluster keyed by its name, i.e.
+// 'v1beta1.metrics.k8s.io'. APIService is considered available only if its Available condition is true, so the
+// UI can warn about degraded aggregated apiservers instead of showing empty lists.
+func (self *clientManager) APIServiceHealth(req *restful.Request) (map[string]bool, error) {
+	cfg, err := self.Config(req)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiServiceHealth(client)
+}
+
+func apiServiceHealth(client dynamic.Interface) (map[string]bool, error) {
+	list, err := client.Resource(APIServicesGVR).List(context.TODO(), metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]bool, len(list.Items))
+	for _, item := range list.Items {
+		result[item.GetName()] = isAPIServiceAvailable(item)
+	}
+
+	return result, nil
+}
+
+func isAPIServiceAvailable(apiService unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(apiService.Object, "status", "conditions")
+	for _, condition := range conditions {
+		c, ok := condition.(map[string]interface{})
+		if ok && c["type"] == "Available" {
+			return c["status"] == string(metaV1.ConditionTrue)
+		}
+	}
+
+	return false
+}
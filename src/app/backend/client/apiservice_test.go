@@ -0,0 +1,65 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func newAPIService(name string, conditions ...interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiregistration.k8s.io/v1",
+		"kind":       "APIService",
+		"metadata":   map[string]interface{}{"name": name},
+	}}
+
+	if len(conditions) > 0 {
+		obj.Object["status"] = map[string]interface{}{"conditions": conditions}
+	}
+
+	return obj
+}
+
+func TestAPIServiceHealth(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{APIServicesGVR: "APIServiceList"},
+		newAPIService("v1.apps", map[string]interface{}{"type": "Available", "status": "True"}),
+		newAPIService("v1beta1.metrics.k8s.io", map[string]interface{}{
+			"type": "Available", "status": "False", "reason": "FailedDiscoveryCheck"}),
+		newAPIService("v1alpha1.custom.example.com", map[string]interface{}{"type": "Unknown", "status": "True"}),
+		newAPIService("v1.pending.example.com"),
+	)
+
+	actual, err := apiServiceHealth(client)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]bool{
+		"v1.apps":                     true,
+		"v1beta1.metrics.k8s.io":      false,
+		"v1alpha1.custom.example.com": false,
+		"v1.pending.example.com":      false,
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("apiServiceHealth() == %v, expected %v", actual, expected)
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+const (
+	// Time for which custom resources of the manifest wait for their custom resource definition to be established.
+	CRDEstablishedTimeout = 30 * time.Second
+	// Interval in which custom resource definitions are checked while waiting for them to be established.
+	CRDEstablishedPollInterval = 500 * time.Millisecond
+)
+
+var crdGroupKind = schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+
+// ApplyManifest applies every document of the YAML or JSON manifest with server-side apply using credentials of the
+// user. Custom resource definitions and namespaces are applied before the documents that depend on them, all other
+// documents are applied concurrently, limited by the 'apply-manifest-concurrency' argument. Failures are reported per
+// document, error is returned only when manifest could not be parsed.
+func (self *clientManager) ApplyManifest(req *restful.Request, manifest []byte, opts clientapi.ApplyOptions) (
+	[]clientapi.ApplyResult, error) {
+	cfg, err := self.Config(req)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return applyManifest(client, self.restMapper(), manifest, opts, args.Holder.GetApplyManifestConcurrency())
+}
+
+func applyManifest(client dynamic.Interface, mapper meta.ResettableRESTMapper, manifest []byte,
+	opts clientapi.ApplyOptions, concurrency int) ([]clientapi.ApplyResult, error) {
+	objects, err := decodeManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.Namespace) == 0 {
+		opts.Namespace = metaV1.NamespaceDefault
+	}
+
+	results := make([]clientapi.ApplyResult, len(objects))
+	for i, obj := range objects {
+		results[i] = clientapi.ApplyResult{Document: i, APIVersion: obj.GetAPIVersion(), Kind: obj.GetKind(),
+			Name: obj.GetName(), Namespace: obj.GetNamespace()}
+	}
+
+	// Documents are applied in waves. Every wave contains documents whose dependencies were already applied.
+	dependencies := manifestDependencies(objects)
+	done := make([]bool, len(objects))
+	for remaining := len(objects); remaining > 0; {
+		wave := make([]int, 0, remaining)
+		for i := range objects {
+			if !done[i] && dependenciesDone(dependencies[i], done) {
+				wave = append(wave, i)
+			}
+		}
+
+		if len(wave) == 0 {
+			for i := range objects {
+				if !done[i] {
+					results[i].Error = "dependencies of the document could not be resolved"
+				}
+			}
+			break
+		}
+
+		applyWave(client, mapper, objects, dependencies, results, wave, opts, concurrency)
+		for _, i := range wave {
+			done[i] = true
+		}
+		remaining -= len(wave)
+	}
+
+	return results, nil
+}
+
+// Returns indexes of the documents every document depends on. Custom resources depend on the custom resource
+// definition of their kind and namespaced objects depend on their namespace, if they are part of the manifest.
+func manifestDependencies(objects []*unstructured.Unstructured) [][]int {
+	crds := make(map[schema.GroupKind]int)
+	namespaces := make(map[string]int)
+	for i, obj := range objects {
+		gk := obj.GroupVersionKind().GroupKind()
+		if gk == crdGroupKind {
+			group, _, _ := unstructured.NestedString(obj.Object, "spec", "group")
+			kind, _, _ := unstructured.NestedString(obj.Object, "spec", "names", "kind")
+			crds[schema.GroupKind{Group: group, Kind: kind}] = i
+		} else if gk == (schema.GroupKind{Kind: "Namespace"}) {
+			namespaces[obj.GetName()] = i
+		}
+	}
+
+	result := make([][]int, len(objects))
+	for i, obj := range objects {
+		if crd, ok := crds[obj.GroupVersionKind().GroupKind()]; ok && crd != i {
+			result[i] = append(result[i], crd)
+		}
+
+		if namespace, ok := namespaces[obj.GetNamespace()]; ok && namespace != i {
+			result[i] = append(result[i], namespace)
+		}
+	}
+
+	return result
+}
+
+func dependenciesDone(dependencies []int, done []bool) bool {
+	for _, dependency := range dependencies {
+		if !done[dependency] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Applies documents of the wave concurrently. Documents whose dependencies failed are not applied.
+func applyWave(client dynamic.Interface, mapper meta.ResettableRESTMapper, objects []*unstructured.Unstructured,
+	dependencies [][]int, results []clientapi.ApplyResult, wave []int, opts clientapi.ApplyOptions, concurrency int) {
+	if concurrency <= 0 || concurrency > len(wave) {
+		concurrency = len(wave)
+	}
+
+	semaphore := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	for _, i := range wave {
+		if failed := failedDependency(dependencies[i], results); failed != nil {
+			results[i].Error = fmt.Sprintf("not applied because %s %s failed", failed.Kind, failed.Name)
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			if err := applyObject(client, mapper, objects[i], opts); err != nil {
+				results[i].Error = err.Error()
+			}
+			results[i].Namespace = objects[i].GetNamespace()
+		}(i)
+	}
+	wg.Wait()
+}
+
+func failedDependency(dependencies []int, results []clientapi.ApplyResult) *clientapi.ApplyResult {
+	for _, dependency := range dependencies {
+		if len(results[dependency].Error) > 0 {
+			return &results[dependency]
+		}
+	}
+
+	return nil
+}
+
+// Applies single object. Custom resource definitions are waited for until they are established, so that their
+// custom resources can be applied right after. Nothing is created in dry-run mode, so there is nothing to wait for.
+func applyObject(client dynamic.Interface, mapper meta.ResettableRESTMapper, obj *unstructured.Unstructured,
+	opts clientapi.ApplyOptions) error {
+	if len(obj.GetName()) == 0 {
+		return errors.NewBadRequest("name is required to apply the object")
+	}
+
+	resource, err := manifestResource(client, mapper, obj, opts.Namespace)
+	if err != nil {
+		return err
+	}
+
+	body, err := obj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	patchOptions := metaV1.PatchOptions{FieldManager: ApplyFieldManager}
+	if opts.Force {
+		patchOptions.Force = &opts.Force
+	}
+	if opts.DryRun {
+		patchOptions.DryRun = []string{metaV1.DryRunAll}
+	}
+
+	result, err := resource.Patch(context.TODO(), obj.GetName(), types.ApplyPatchType, body, patchOptions)
+	if err != nil || opts.DryRun || obj.GroupVersionKind().GroupKind() != crdGroupKind {
+		return err
+	}
+
+	return waitForCRDEstablished(resource, obj.GetName(), result)
+}
+
+func waitForCRDEstablished(resource dynamic.ResourceInterface, name string, crd *unstructured.Unstructured) error {
+	if isCRDEstablished(crd) {
+		return nil
+	}
+
+	err := wait.PollImmediate(CRDEstablishedPollInterval, CRDEstablishedTimeout, func() (bool, error) {
+		crd, err := resource.Get(context.TODO(), name, metaV1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		return isCRDEstablished(crd), nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("custom resource definition %s was not established within %s", name, CRDEstablishedTimeout)
+	}
+
+	return err
+}
+
+func isCRDEstablished(crd *unstructured.Unstructured) bool {
+	if crd == nil {
+		return false
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if ok && condition["type"] == "Established" && condition["status"] == "True" {
+			return true
+		}
+	}
+
+	return false
+}
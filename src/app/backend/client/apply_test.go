@@ -0,0 +1,159 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clientTesting "k8s.io/client-go/testing"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+const crdBundleManifest = `
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: first
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+    plural: widgets
+  scope: Namespaced
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+`
+
+const partialFailureManifest = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: team-settings
+  namespace: team
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: team
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+`
+
+// Returns client that records names of the applied objects and fails objects with the given names.
+func newApplyClient(applied *[]string, failing ...string) *dynamicfake.FakeDynamicClient {
+	mux := sync.Mutex{}
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	client.PrependReactor("patch", "*", func(action clientTesting.Action) (bool, runtime.Object, error) {
+		patch := action.(clientTesting.PatchAction)
+		for _, name := range failing {
+			if patch.GetName() == name {
+				return true, nil, errors.NewForbidden("denied")
+			}
+		}
+
+		mux.Lock()
+		*applied = append(*applied, patch.GetResource().Resource+"/"+patch.GetName())
+		mux.Unlock()
+
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(patch.GetPatch()); err != nil {
+			return true, nil, err
+		}
+		if patch.GetResource().Resource == "customresourcedefinitions" {
+			obj.Object["status"] = map[string]interface{}{
+				"conditions": []interface{}{map[string]interface{}{"type": "Established", "status": "True"}},
+			}
+		}
+		return true, obj, nil
+	})
+	return client
+}
+
+func TestApplyManifestCRDBundle(t *testing.T) {
+	applied := make([]string, 0)
+	mapper := newTestRESTMapper(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"})
+	mapper.Add(schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"},
+		meta.RESTScopeRoot)
+
+	results, err := applyManifest(newApplyClient(&applied), mapper, []byte(crdBundleManifest),
+		clientapi.ApplyOptions{Namespace: "team"}, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []clientapi.ApplyResult{
+		{Document: 0, APIVersion: "example.com/v1", Kind: "Widget", Name: "first", Namespace: "team"},
+		{Document: 1, APIVersion: "apiextensions.k8s.io/v1", Kind: "CustomResourceDefinition",
+			Name: "widgets.example.com"},
+		{Document: 2, APIVersion: "v1", Kind: "ConfigMap", Name: "settings", Namespace: "team"},
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("Expected results %v, got %v", expected, results)
+	}
+
+	// Custom resource is applied in the second wave, after its definition.
+	if len(applied) != 3 || applied[2] != "widgets/first" {
+		t.Errorf("Expected custom resource to be applied after its definition, got %v", applied)
+	}
+}
+
+func TestApplyManifestPartialFailure(t *testing.T) {
+	applied := make([]string, 0)
+	results, err := applyManifest(newApplyClient(&applied, "team", "web"), newTestRESTMapper(),
+		[]byte(partialFailureManifest), clientapi.ApplyOptions{}, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []clientapi.ApplyResult{
+		{Document: 0, APIVersion: "v1", Kind: "ConfigMap", Name: "team-settings", Namespace: "team",
+			Error: "not applied because Namespace team failed"},
+		{Document: 1, APIVersion: "v1", Kind: "Namespace", Name: "team", Error: "denied"},
+		{Document: 2, APIVersion: "apps/v1", Kind: "Deployment", Name: "web", Namespace: "default", Error: "denied"},
+		{Document: 3, APIVersion: "v1", Kind: "ConfigMap", Name: "settings", Namespace: "default"},
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("Expected results %v, got %v", expected, results)
+	}
+
+	if !reflect.DeepEqual(applied, []string{"configmaps/settings"}) {
+		t.Errorf("Expected only the independent config map to be applied, got %v", applied)
+	}
+}
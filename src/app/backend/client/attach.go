@@ -0,0 +1,118 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/emicklei/go-restful/v3"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/container"
+)
+
+// newExecutor creates executor streaming to the given URL. Replaced in tests.
+var newExecutor = remotecommand.NewSPDYExecutor
+
+// AttachToContainer attaches to the main process of the container using the attach subresource and credentials of
+// the user, the same as 'kubectl attach' does. Default container is used when container name is empty. Terminal size
+// and stdin are only streamed when the container allocates TTY and keeps stdin open respectively.
+func (self *clientManager) AttachToContainer(req *restful.Request, namespace, pod, container string,
+	opts clientapi.AttachOptions) error {
+	cfg, err := self.Config(req)
+	if err != nil {
+		return err
+	}
+
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	return attachToContainer(client, client.CoreV1().RESTClient(), cfg, namespace, pod, container, opts)
+}
+
+func attachToContainer(client kubernetes.Interface, restClient rest.Interface, cfg *rest.Config, namespace,
+	podName, containerName string, opts clientapi.AttachOptions) error {
+	pod, err := client.CoreV1().Pods(namespace).Get(context.TODO(), podName, metaV1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	container, err := findContainer(pod, containerName)
+	if err != nil {
+		return err
+	}
+
+	stdin := container.Stdin && opts.Stdin != nil
+	req := restClient.Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(namespace).
+		SubResource("attach")
+
+	req.VersionedParams(&v1.PodAttachOptions{
+		Container: container.Name,
+		Stdin:     stdin,
+		Stdout:    opts.Stdout != nil,
+		// Stderr is merged into stdout by the terminal when TTY is allocated.
+		Stderr: !container.TTY && opts.Stderr != nil,
+		TTY:    container.TTY,
+	}, scheme.ParameterCodec)
+
+	exec, err := newExecutor(cfg, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	streamOptions := remotecommand.StreamOptions{Stdout: opts.Stdout, Tty: container.TTY}
+	if stdin {
+		streamOptions.Stdin = opts.Stdin
+	}
+
+	if container.TTY {
+		streamOptions.TerminalSizeQueue = opts.TerminalSizeQueue
+	} else {
+		streamOptions.Stderr = opts.Stderr
+	}
+
+	return exec.Stream(streamOptions)
+}
+
+func findContainer(pod *v1.Pod, name string) (*v1.Container, error) {
+	if len(name) == 0 {
+		if len(pod.Spec.Containers) == 0 {
+			return nil, errors.NewBadRequest(fmt.Sprintf("pod %s has no containers", pod.Name))
+		}
+		name = container.DefaultContainerName(pod)
+	}
+
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == name {
+			return &pod.Spec.Containers[i], nil
+		}
+	}
+
+	return nil, errors.NewBadRequest(fmt.Sprintf("container %s is not valid for pod %s", name, pod.Name))
+}
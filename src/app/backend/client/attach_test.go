@@ -0,0 +1,148 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+type fakeSizeQueue struct{}
+
+func (fakeSizeQueue) Next() *remotecommand.TerminalSize {
+	return nil
+}
+
+// fakeAttachStream writes output of the process to the attached streams.
+type fakeAttachStream struct {
+	url     *url.URL
+	options remotecommand.StreamOptions
+}
+
+func (self *fakeAttachStream) Stream(options remotecommand.StreamOptions) error {
+	self.options = options
+	if options.Stdout != nil {
+		options.Stdout.Write([]byte("started\n"))
+	}
+	if options.Stderr != nil {
+		options.Stderr.Write([]byte("warning\n"))
+	}
+	return nil
+}
+
+func newAttachTest(t *testing.T) (*fake.Clientset, rest.Interface, *fakeAttachStream) {
+	pod := &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1.PodSpec{Containers: []v1.Container{
+			{Name: "app"},
+			{Name: "console", TTY: true, Stdin: true},
+		}},
+	}
+	restClient, err := rest.RESTClientFor(&rest.Config{
+		Host:    "https://apiserver",
+		APIPath: "/api",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion:         &v1.SchemeGroupVersion,
+			NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream := &fakeAttachStream{}
+	original := newExecutor
+	newExecutor = func(cfg *rest.Config, method string, url *url.URL) (remotecommand.Executor, error) {
+		stream.url = url
+		return stream, nil
+	}
+	t.Cleanup(func() { newExecutor = original })
+
+	return fake.NewSimpleClientset(pod), restClient, stream
+}
+
+func TestAttachToContainerWithoutTTY(t *testing.T) {
+	client, restClient, stream := newAttachTest(t)
+	var stdout, stderr bytes.Buffer
+	opts := clientapi.AttachOptions{Stdin: strings.NewReader("input"), Stdout: &stdout, Stderr: &stderr,
+		TerminalSizeQueue: fakeSizeQueue{}}
+
+	if err := attachToContainer(client, restClient, &rest.Config{}, "default", "web", "", opts); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	query := stream.url.Query()
+	if stream.url.Path != "/api/v1/namespaces/default/pods/web/attach" || query.Get("container") != "app" ||
+		query.Get("tty") != "" || query.Get("stdin") != "" || query.Get("stderr") != "true" {
+		t.Errorf("Unexpected attach URL: %s", stream.url)
+	}
+
+	if stream.options.Tty || stream.options.Stdin != nil || stream.options.TerminalSizeQueue != nil {
+		t.Errorf("Expected stdin and terminal size not to be streamed without TTY, got %+v", stream.options)
+	}
+
+	if stdout.String() != "started\n" || stderr.String() != "warning\n" {
+		t.Errorf("Expected output to be streamed separately, got stdout %q and stderr %q", stdout.String(),
+			stderr.String())
+	}
+}
+
+func TestAttachToContainerWithTTY(t *testing.T) {
+	client, restClient, stream := newAttachTest(t)
+	var stdout, stderr bytes.Buffer
+	opts := clientapi.AttachOptions{Stdin: strings.NewReader("input"), Stdout: &stdout, Stderr: &stderr,
+		TerminalSizeQueue: fakeSizeQueue{}}
+
+	if err := attachToContainer(client, restClient, &rest.Config{}, "default", "web", "console", opts); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	query := stream.url.Query()
+	if query.Get("container") != "console" || query.Get("tty") != "true" || query.Get("stdin") != "true" ||
+		query.Get("stderr") != "" {
+		t.Errorf("Unexpected attach URL: %s", stream.url)
+	}
+
+	if !stream.options.Tty || stream.options.Stdin == nil || stream.options.TerminalSizeQueue == nil ||
+		stream.options.Stderr != nil {
+		t.Errorf("Expected TTY stream, got %+v", stream.options)
+	}
+
+	if stdout.String() != "started\n" || stderr.Len() != 0 {
+		t.Errorf("Expected output to be streamed to stdout only, got stdout %q and stderr %q", stdout.String(),
+			stderr.String())
+	}
+}
+
+func TestAttachToContainerInvalidContainer(t *testing.T) {
+	client, restClient, _ := newAttachTest(t)
+
+	err := attachToContainer(client, restClient, &rest.Config{}, "default", "web", "missing", clientapi.AttachOptions{})
+	if !errors.IsBadRequest(err) {
+		t.Errorf("Expected bad request error, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"k8s.io/client-go/rest"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+// AuthMode returns mechanism used by the insecure client to authenticate to the apiserver. It is computed on first
+// use, as the config does not change during the dashboard lifetime.
+func (self *clientManager) AuthMode() clientapi.AuthMode {
+	self.authModeOnce.Do(func() {
+		self.authMode = authModeForConfig(self.insecureConfig, self.usesInClusterConfig())
+	})
+
+	return self.authMode
+}
+
+// Detects authentication mechanism from the resolved config. Client certificate takes precedence, as it is verified
+// by the apiserver before any other credentials.
+func authModeForConfig(cfg *rest.Config, inCluster bool) clientapi.AuthMode {
+	switch {
+	case cfg == nil:
+		return clientapi.AuthModeNone
+	case inCluster:
+		return clientapi.AuthModeInCluster
+	case len(cfg.CertData) > 0 || len(cfg.CertFile) > 0:
+		return clientapi.AuthModeClientCertificate
+	case cfg.ExecProvider != nil:
+		return clientapi.AuthModeExec
+	case cfg.AuthProvider != nil:
+		return clientapi.AuthModeAuthProvider
+	case len(cfg.BearerTokenFile) > 0:
+		return clientapi.AuthModeTokenFile
+	case len(cfg.BearerToken) > 0:
+		return clientapi.AuthModeToken
+	case len(cfg.Username) > 0 || len(cfg.Password) > 0:
+		return clientapi.AuthModeBasic
+	default:
+		return clientapi.AuthModeNone
+	}
+}
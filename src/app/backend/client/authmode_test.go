@@ -0,0 +1,119 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+const testKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://localhost:6443
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+`
+
+func TestAuthMode(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("token"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		info     string
+		user     string
+		expected clientapi.AuthMode
+	}{
+		{"kubeconfig with client certificate",
+			"    client-certificate-data: Y2VydA==\n    client-key-data: a2V5\n", clientapi.AuthModeClientCertificate},
+		{"kubeconfig with token file", "    tokenFile: " + tokenFile + "\n", clientapi.AuthModeTokenFile},
+		{"kubeconfig with token", "    token: secret\n", clientapi.AuthModeToken},
+		{"kubeconfig with basic auth", "    username: admin\n    password: secret\n", clientapi.AuthModeBasic},
+	}
+
+	for _, c := range cases {
+		kubeConfigPath := filepath.Join(dir, "kubeconfig")
+		if err := os.WriteFile(kubeConfigPath, []byte(testKubeConfig+c.user), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		manager := &clientManager{kubeConfigPath: kubeConfigPath}
+		cfg, err := manager.buildConfigFromFlags("", kubeConfigPath)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.info, err)
+		}
+
+		manager.insecureConfig = cfg
+		if actual := manager.AuthMode(); actual != c.expected {
+			t.Errorf("%s: expected auth mode %s, but got %s", c.info, c.expected, actual)
+		}
+	}
+}
+
+func TestAuthModeInCluster(t *testing.T) {
+	manager := &clientManager{
+		inClusterConfig: &rest.Config{BearerTokenFile: "/var/run/secrets/kubernetes.io/serviceaccount/token"},
+	}
+	manager.insecureConfig = manager.inClusterConfig
+
+	if actual := manager.AuthMode(); actual != clientapi.AuthModeInCluster {
+		t.Fatalf("Expected auth mode %s, but got %s", clientapi.AuthModeInCluster, actual)
+	}
+
+	// Auth mode is computed only once.
+	manager.insecureConfig = nil
+	if actual := manager.AuthMode(); actual != clientapi.AuthModeInCluster {
+		t.Fatalf("Expected cached auth mode %s, but got %s", clientapi.AuthModeInCluster, actual)
+	}
+}
+
+func TestAuthModeForConfig(t *testing.T) {
+	cases := []struct {
+		cfg      *rest.Config
+		expected clientapi.AuthMode
+	}{
+		{nil, clientapi.AuthModeNone},
+		{&rest.Config{}, clientapi.AuthModeNone},
+		{&rest.Config{
+			TLSClientConfig: rest.TLSClientConfig{CertFile: "/tmp/cert"}, BearerToken: "token",
+		}, clientapi.AuthModeClientCertificate},
+		{&rest.Config{ExecProvider: &api.ExecConfig{Command: "login"}}, clientapi.AuthModeExec},
+		{&rest.Config{AuthProvider: &api.AuthProviderConfig{Name: "oidc"}}, clientapi.AuthModeAuthProvider},
+	}
+
+	for _, c := range cases {
+		if actual := authModeForConfig(c.cfg, false); actual != c.expected {
+			t.Errorf("authModeForConfig(%#v) == %s, expected %s", c.cfg, actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+// BatchGet executes given get operations concurrently and returns their results keyed by the request ID. Number of
+// operations executed at the same time is limited by the 'batch-get-concurrency' argument. Errors of all failed
+// operations are aggregated into the returned error, in order of the requests.
+func BatchGet(ctx context.Context, requests []clientapi.BatchGetRequest) (map[string]clientapi.BatchGetResult, error) {
+	return batchGet(ctx, requests, args.Holder.GetBatchGetConcurrency())
+}
+
+func batchGet(ctx context.Context, requests []clientapi.BatchGetRequest, concurrency int) (
+	map[string]clientapi.BatchGetResult, error) {
+	if concurrency <= 0 || concurrency > len(requests) {
+		concurrency = len(requests)
+	}
+
+	results := make([]clientapi.BatchGetResult, len(requests))
+	semaphore := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	for i := range requests {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			if err := ctx.Err(); err != nil {
+				results[i].Error = err
+				return
+			}
+
+			results[i].Object, results[i].Error = requests[i].Get(ctx)
+		}(i)
+	}
+	wg.Wait()
+
+	resultMap := make(map[string]clientapi.BatchGetResult, len(requests))
+	errs := make([]error, 0)
+	for i, request := range requests {
+		resultMap[request.ID] = results[i]
+		if results[i].Error != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", request.ID, results[i].Error))
+		}
+	}
+
+	return resultMap, utilerrors.NewAggregate(errs)
+}
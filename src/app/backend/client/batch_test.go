@@ -0,0 +1,103 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+func TestBatchGet(t *testing.T) {
+	get := func(object interface{}, err error, delay time.Duration) func(context.Context) (interface{}, error) {
+		return func(context.Context) (interface{}, error) {
+			time.Sleep(delay)
+			return object, err
+		}
+	}
+
+	requests := []clientapi.BatchGetRequest{
+		{ID: "pod", Get: get("pod", nil, 20*time.Millisecond)},
+		{ID: "events", Get: get(nil, fmt.Errorf("events failed"), 10*time.Millisecond)},
+		{ID: "owner", Get: get("deployment", nil, 0)},
+		{ID: "metrics", Get: get(nil, fmt.Errorf("metrics failed"), 0)},
+	}
+
+	results, err := batchGet(context.TODO(), requests, 0)
+	if err == nil || err.Error() != "[events: events failed, metrics: metrics failed]" {
+		t.Errorf("Expected errors aggregated in order of requests, got %v", err)
+	}
+
+	if len(results) != len(requests) {
+		t.Fatalf("Expected %d results, got %d", len(requests), len(results))
+	}
+
+	if results["pod"].Object != "pod" || results["owner"].Object != "deployment" {
+		t.Errorf("Expected results of successful requests, got %v", results)
+	}
+
+	if results["events"].Error == nil || results["metrics"].Error == nil {
+		t.Errorf("Expected errors of failed requests, got %v", results)
+	}
+}
+
+func TestBatchGetConcurrency(t *testing.T) {
+	var running, maxRunning int32
+	requests := make([]clientapi.BatchGetRequest, 10)
+	for i := range requests {
+		requests[i] = clientapi.BatchGetRequest{ID: fmt.Sprint(i), Get: func(context.Context) (interface{}, error) {
+			current := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			return nil, nil
+		}}
+	}
+
+	if _, err := batchGet(context.TODO(), requests, 3); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if maxRunning > 3 {
+		t.Errorf("Expected at most 3 concurrent requests, got %d", maxRunning)
+	}
+}
+
+func TestBatchGetCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	called := false
+	results, err := batchGet(ctx, []clientapi.BatchGetRequest{{ID: "pod", Get: func(context.Context) (interface{}, error) {
+		called = true
+		return nil, nil
+	}}}, 1)
+	if err == nil || results["pod"].Error != context.Canceled {
+		t.Errorf("Expected cancelled error, got %v", err)
+	}
+
+	if called {
+		t.Error("Expected get not to be called when context is cancelled")
+	}
+}
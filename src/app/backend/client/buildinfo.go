@@ -0,0 +1,59 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"net/url"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+// BuildInfo returns version of the dashboard and effective configuration of the insecure client. Only non-sensitive
+// values are copied, tokens, keys and file contents are never included.
+func (self *clientManager) BuildInfo() clientapi.BuildInfo {
+	info := clientapi.BuildInfo{
+		Version:              Version,
+		InCluster:            self.isRunningInCluster() && len(self.apiserverHost) == 0 && len(self.kubeConfigPath) == 0,
+		KubeConfigProvided:   len(self.kubeConfigPath) > 0,
+		AuthenticationModes:  args.Holder.GetAuthenticationMode(),
+		EnableSkipLogin:      args.Holder.GetEnableSkipLogin(),
+		EnableInsecureLogin:  args.Holder.GetEnableInsecureLogin(),
+		EgressProxyMutualTLS: self.egressProxy != nil,
+	}
+
+	if cfg := self.InsecureConfig(); cfg != nil {
+		info.APIServerHost = redactURL(cfg.Host)
+		info.QPS = cfg.QPS
+		info.Burst = cfg.Burst
+		info.ContentType = cfg.ContentType
+		info.UserAgent = cfg.UserAgent
+	}
+
+	return info
+}
+
+// Removes user info and query from the URL as they can contain credentials.
+func redactURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || len(parsed.Host) == 0 {
+		return ""
+	}
+
+	parsed.User = nil
+	parsed.RawQuery = ""
+	parsed.Fragment = ""
+	return parsed.String()
+}
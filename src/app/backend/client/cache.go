@@ -0,0 +1,116 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// ttlCache is a thread-safe cache with a bounded number of entries that expire after configured TTL.
+type ttlCache struct {
+	mux     sync.Mutex
+	ttl     time.Duration
+	maxSize int
+	entries map[string]cacheEntry
+	// Used to get current time. Can be overridden in tests.
+	now func() time.Time
+}
+
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// Get returns value stored under given key if it exists and has not expired yet.
+func (self *ttlCache) Get(key string) (interface{}, bool) {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	entry, exists := self.entries[key]
+	if !exists {
+		return nil, false
+	}
+
+	if !self.now().Before(entry.expires) {
+		delete(self.entries, key)
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+// Set stores value under given key. In case cache is full expired entries are removed first and then entry that
+// is closest to expiration is evicted.
+func (self *ttlCache) Set(key string, value interface{}) {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	if _, exists := self.entries[key]; !exists && len(self.entries) >= self.maxSize {
+		self.evict()
+	}
+
+	self.entries[key] = cacheEntry{value: value, expires: self.now().Add(self.ttl)}
+}
+
+// Delete removes entry stored under given key.
+func (self *ttlCache) Delete(key string) {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	delete(self.entries, key)
+}
+
+// Len returns number of entries currently stored in the cache, including the expired ones that were not removed yet.
+func (self *ttlCache) Len() int {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	return len(self.entries)
+}
+
+func (self *ttlCache) evict() {
+	now := self.now()
+	oldestKey := ""
+	var oldest time.Time
+	for key, entry := range self.entries {
+		if !now.Before(entry.expires) {
+			delete(self.entries, key)
+			continue
+		}
+
+		if len(oldestKey) == 0 || entry.expires.Before(oldest) {
+			oldestKey = key
+			oldest = entry.expires
+		}
+	}
+
+	if len(self.entries) >= self.maxSize && len(oldestKey) > 0 {
+		delete(self.entries, oldestKey)
+	}
+}
+
+func newTTLCache(ttl time.Duration, maxSize int) *ttlCache {
+	if maxSize < 1 {
+		maxSize = 1
+	}
+
+	return &ttlCache{
+		ttl:     ttl,
+		maxSize: maxSize,
+		entries: make(map[string]cacheEntry),
+		now:     time.Now,
+	}
+}
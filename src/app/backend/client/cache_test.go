@@ -0,0 +1,61 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTTLCache(t *testing.T) {
+	now := time.Now()
+	cache := newTTLCache(time.Minute, 2)
+	cache.now = func() time.Time { return now }
+
+	cache.Set("a", 1)
+	if value, ok := cache.Get("a"); !ok || value.(int) != 1 {
+		t.Fatalf("Get(a) == %v, %t, expected 1, true", value, ok)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := cache.Get("a"); ok {
+		t.Fatal("Get(a): expected entry to expire")
+	}
+}
+
+func TestTTLCacheEviction(t *testing.T) {
+	now := time.Now()
+	cache := newTTLCache(time.Minute, 2)
+	cache.now = func() time.Time { return now }
+
+	cache.Set("a", 1)
+	now = now.Add(time.Second)
+	cache.Set("b", 2)
+	cache.Set("c", 3)
+
+	if cache.Len() != 2 {
+		t.Fatalf("Len() == %d, expected 2", cache.Len())
+	}
+
+	if _, ok := cache.Get("a"); ok {
+		t.Fatal("Get(a): expected oldest entry to be evicted")
+	}
+
+	for _, key := range []string{"b", "c"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Fatalf("Get(%s): expected entry to be cached", key)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"sort"
+	"strings"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+// ResourcesInCategory returns built-in and custom resources tagged with given category, i.e. "all". It can be used to
+// build a view equivalent to "kubectl get all".
+func (self *clientManager) ResourcesInCategory(req *restful.Request, category string) ([]schema.GroupVersionResource, error) {
+	client, err := self.Client(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resourcesInCategory(client.Discovery(), category)
+}
+
+// resourcesInCategory finds resources tagged with given category. Categories may be present only on some versions of
+// a resource, so preferred version of a group is checked first and other versions are used as a fallback. Every
+// resource is returned once, in the first version that has the category.
+func resourcesInCategory(client discovery.DiscoveryInterface, category string) ([]schema.GroupVersionResource, error) {
+	if len(category) == 0 {
+		return nil, errors.NewBadRequest("category is required")
+	}
+
+	groups, lists, err := client.ServerGroupsAndResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, err
+	}
+
+	byGroupVersion := make(map[string]*metaV1.APIResourceList, len(lists))
+	for _, list := range lists {
+		if list != nil {
+			byGroupVersion[list.GroupVersion] = list
+		}
+	}
+
+	seen := make(map[schema.GroupResource]bool)
+	result := make([]schema.GroupVersionResource, 0)
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+
+		for _, version := range orderedGroupVersions(group) {
+			list, ok := byGroupVersion[version.GroupVersion]
+			if !ok {
+				continue
+			}
+
+			for _, resource := range list.APIResources {
+				if strings.Contains(resource.Name, "/") || !hasCategory(resource, category) {
+					continue
+				}
+
+				gr := schema.GroupResource{Group: group.Name, Resource: resource.Name}
+				if seen[gr] {
+					continue
+				}
+
+				seen[gr] = true
+				result = append(result, gr.WithVersion(version.Version))
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Group != result[j].Group {
+			return result[i].Group < result[j].Group
+		}
+		return result[i].Resource < result[j].Resource
+	})
+
+	return result, nil
+}
+
+// orderedGroupVersions returns versions of a group with preferred version first.
+func orderedGroupVersions(group *metaV1.APIGroup) []metaV1.GroupVersionForDiscovery {
+	versions := make([]metaV1.GroupVersionForDiscovery, 0, len(group.Versions)+1)
+	preferred := group.PreferredVersion.GroupVersion
+	if len(preferred) > 0 {
+		versions = append(versions, group.PreferredVersion)
+	}
+
+	for _, version := range group.Versions {
+		if version.GroupVersion != preferred {
+			versions = append(versions, version)
+		}
+	}
+
+	return versions
+}
+
+func hasCategory(resource metaV1.APIResource, category string) bool {
+	for _, c := range resource.Categories {
+		if c == category {
+			return true
+		}
+	}
+
+	return false
+}
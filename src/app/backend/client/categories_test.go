@@ -0,0 +1,110 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+func newCategoriesDiscovery() *fakediscovery.FakeDiscovery {
+	discovery := fake.NewSimpleClientset().Discovery().(*fakediscovery.FakeDiscovery)
+	discovery.Resources = []*metaV1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metaV1.APIResource{
+				{Name: "pods", Kind: "Pod", Namespaced: true, Categories: []string{"all"}},
+				{Name: "pods/log", Kind: "Pod", Namespaced: true, Categories: []string{"all"}},
+				{Name: "services", Kind: "Service", Namespaced: true, Categories: []string{"all"}},
+				{Name: "configmaps", Kind: "ConfigMap", Namespaced: true},
+			},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metaV1.APIResource{
+				{Name: "deployments", Kind: "Deployment", Namespaced: true, Categories: []string{"all"}},
+			},
+		},
+		{
+			// Preferred version of the group, category was added only in the older version.
+			GroupVersion: "example.com/v2",
+			APIResources: []metaV1.APIResource{
+				{Name: "widgets", Kind: "Widget", Namespaced: true, Categories: []string{"all", "example"}},
+				{Name: "gadgets", Kind: "Gadget", Namespaced: true},
+			},
+		},
+		{
+			GroupVersion: "example.com/v1",
+			APIResources: []metaV1.APIResource{
+				{Name: "widgets", Kind: "Widget", Namespaced: true, Categories: []string{"all"}},
+				{Name: "gadgets", Kind: "Gadget", Namespaced: true, Categories: []string{"all"}},
+			},
+		},
+	}
+	return discovery
+}
+
+func TestResourcesInCategory(t *testing.T) {
+	cases := []struct {
+		category string
+		expected []schema.GroupVersionResource
+	}{
+		{
+			"all",
+			[]schema.GroupVersionResource{
+				{Version: "v1", Resource: "pods"},
+				{Version: "v1", Resource: "services"},
+				{Group: "apps", Version: "v1", Resource: "deployments"},
+				{Group: "example.com", Version: "v1", Resource: "gadgets"},
+				{Group: "example.com", Version: "v2", Resource: "widgets"},
+			},
+		},
+		{
+			"example",
+			[]schema.GroupVersionResource{
+				{Group: "example.com", Version: "v2", Resource: "widgets"},
+			},
+		},
+		{
+			"unknown",
+			[]schema.GroupVersionResource{},
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := resourcesInCategory(newCategoriesDiscovery(), c.category)
+		if err != nil {
+			t.Fatalf("resourcesInCategory(%q) returned error: %v", c.category, err)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("resourcesInCategory(%q) == %v, expected %v", c.category, actual, c.expected)
+		}
+	}
+}
+
+func TestResourcesInCategoryRequiresCategory(t *testing.T) {
+	_, err := resourcesInCategory(newCategoriesDiscovery(), "")
+	if !errors.IsBadRequest(err) {
+		t.Errorf("expected bad request error, got %v", err)
+	}
+}
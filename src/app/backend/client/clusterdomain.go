@@ -0,0 +1,118 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"log"
+	"regexp"
+	"sync"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+)
+
+const (
+	// DefaultClusterDomain is used when cluster domain could not be detected and no fallback was configured.
+	DefaultClusterDomain = "cluster.local"
+	// ClusterDomainRetryInterval is time after which detection of the cluster domain is retried if it failed.
+	ClusterDomainRetryInterval = time.Minute
+)
+
+var (
+	// Matches 'kubernetes <domain> ...' line of the CoreDNS Corefile.
+	coreDNSDomainRegexp = regexp.MustCompile(`(?m)^\s*kubernetes\s+([\w.-]+)`)
+	// Matches 'dnsDomain: <domain>' line of the kubeadm ClusterConfiguration.
+	kubeadmDomainRegexp = regexp.MustCompile(`(?m)^\s*dnsDomain:\s*"?([\w.-]+)"?`)
+)
+
+// clusterDomainSource describes config map that can contain the cluster domain and how to extract it.
+type clusterDomainSource struct {
+	namespace string
+	name      string
+	key       string
+	regexp    *regexp.Regexp
+}
+
+var clusterDomainSources = []clusterDomainSource{
+	{metaV1.NamespaceSystem, "coredns", "Corefile", coreDNSDomainRegexp},
+	{metaV1.NamespaceSystem, "kubeadm-config", "ClusterConfiguration", kubeadmDomainRegexp},
+}
+
+// clusterDomainCache keeps detected cluster domain for the lifetime of the dashboard. Fallback used when detection
+// failed is kept only for ClusterDomainRetryInterval, so that transient errors do not pin it.
+type clusterDomainCache struct {
+	mux      sync.Mutex
+	domain   string
+	detected bool
+	expires  time.Time
+	now      func() time.Time
+}
+
+// Get returns cached cluster domain or detects it using provided client.
+func (self *clusterDomainCache) Get(client kubernetes.Interface, fallback string) string {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	now := time.Now
+	if self.now != nil {
+		now = self.now
+	}
+
+	if self.detected || (len(self.domain) > 0 && now().Before(self.expires)) {
+		return self.domain
+	}
+
+	self.domain, self.detected = detectClusterDomain(client, fallback)
+	self.expires = now().Add(ClusterDomainRetryInterval)
+	return self.domain
+}
+
+// Reads cluster domain from the well-known config maps. Fallback is returned if none of them exists or contains the
+// domain, in which case the second value is false.
+func detectClusterDomain(client kubernetes.Interface, fallback string) (string, bool) {
+	if len(fallback) == 0 {
+		fallback = DefaultClusterDomain
+	}
+
+	for _, source := range clusterDomainSources {
+		configMap, err := client.CoreV1().ConfigMaps(source.namespace).Get(context.TODO(), source.name,
+			metaV1.GetOptions{})
+		if err != nil {
+			if !k8serrors.IsNotFound(err) {
+				log.Printf("Could not read cluster domain from %s/%s config map: %s", source.namespace,
+					source.name, err.Error())
+			}
+			continue
+		}
+
+		if match := source.regexp.FindStringSubmatch(configMap.Data[source.key]); len(match) > 1 {
+			return match[1], true
+		}
+	}
+
+	log.Printf("Could not detect cluster domain, using %s", fallback)
+	return fallback, false
+}
+
+// ClusterDomain returns DNS domain of the cluster, i.e. 'cluster.local'. It is read once using the dashboard service
+// account from CoreDNS or kubeadm config maps and falls back to the 'cluster-domain' argument until it is detected.
+func (self *clientManager) ClusterDomain() string {
+	return self.clusterDomainCache.Get(self.InsecureClient(), args.Holder.GetClusterDomain())
+}
@@ -0,0 +1,121 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientTesting "k8s.io/client-go/testing"
+)
+
+func TestDetectClusterDomain(t *testing.T) {
+	cases := []struct {
+		info       string
+		configMaps []runtime.Object
+		fallback   string
+		expected   string
+		detected   bool
+	}{
+		{
+			"coredns config map",
+			[]runtime.Object{&v1.ConfigMap{
+				ObjectMeta: metaV1.ObjectMeta{Name: "coredns", Namespace: "kube-system"},
+				Data: map[string]string{"Corefile": ".:53 {\n    errors\n    kubernetes my.domain in-addr.arpa ip6.arpa {\n" +
+					"       pods insecure\n    }\n}"},
+			}},
+			"",
+			"my.domain",
+			true,
+		},
+		{
+			"kubeadm config map",
+			[]runtime.Object{&v1.ConfigMap{
+				ObjectMeta: metaV1.ObjectMeta{Name: "kubeadm-config", Namespace: "kube-system"},
+				Data: map[string]string{"ClusterConfiguration": "networking:\n  dnsDomain: kubeadm.local\n" +
+					"  serviceSubnet: 10.96.0.0/12\n"},
+			}},
+			"",
+			"kubeadm.local",
+			true,
+		},
+		{"no config maps", nil, "", DefaultClusterDomain, false},
+		{"configured fallback", nil, "fallback.local", "fallback.local", false},
+	}
+
+	for _, c := range cases {
+		client := fake.NewSimpleClientset(c.configMaps...)
+		if actual, detected := detectClusterDomain(client, c.fallback); actual != c.expected || detected != c.detected {
+			t.Errorf("%s: detectClusterDomain() == %s (%t), expected %s (%t)", c.info, actual, detected, c.expected,
+				c.detected)
+		}
+	}
+}
+
+func TestClusterDomainCache(t *testing.T) {
+	cache := &clusterDomainCache{}
+	client := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{Name: "coredns", Namespace: "kube-system"},
+		Data:       map[string]string{"Corefile": "kubernetes cached.local"},
+	})
+
+	if actual := cache.Get(client, ""); actual != "cached.local" {
+		t.Fatalf("Get() == %s, expected cached.local", actual)
+	}
+
+	if actual := cache.Get(fake.NewSimpleClientset(), ""); actual != "cached.local" {
+		t.Fatalf("Get() == %s, expected cached domain to be returned", actual)
+	}
+}
+
+func TestClusterDomainCacheRetriesFailedDetection(t *testing.T) {
+	now := time.Now()
+	cache := &clusterDomainCache{now: func() time.Time { return now }}
+	client := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{Name: "coredns", Namespace: "kube-system"},
+		Data:       map[string]string{"Corefile": "kubernetes detected.local"},
+	})
+	failures := 1
+	client.PrependReactor("get", "configmaps", func(action clientTesting.Action) (bool, runtime.Object, error) {
+		if failures > 0 {
+			return true, nil, k8serrors.NewServiceUnavailable("apiserver is starting")
+		}
+		return false, nil, nil
+	})
+
+	if actual := cache.Get(client, "fallback.local"); actual != "fallback.local" {
+		t.Fatalf("Get() == %s, expected fallback.local", actual)
+	}
+
+	failures = 0
+	if actual := cache.Get(client, "fallback.local"); actual != "fallback.local" {
+		t.Fatalf("Get() == %s, expected fallback to be kept until retry interval passes", actual)
+	}
+
+	now = now.Add(ClusterDomainRetryInterval)
+	if actual := cache.Get(client, "fallback.local"); actual != "detected.local" {
+		t.Fatalf("Get() == %s, expected detected.local after retry", actual)
+	}
+
+	now = now.Add(ClusterDomainRetryInterval)
+	if actual := cache.Get(fake.NewSimpleClientset(), "fallback.local"); actual != "detected.local" {
+		t.Fatalf("Get() == %s, expected detected domain to be cached", actual)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	batch "k8s.io/api/batch/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/emicklei/go-restful/v3"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+// CronJobSchedules returns summaries of the cron jobs in the namespace with their next scheduled time and status of
+// their last run, sorted by name, using credentials of the user. Empty namespace means all namespaces.
+func (self *clientManager) CronJobSchedules(req *restful.Request, namespace string) ([]clientapi.CronJobSummary,
+	error) {
+	client, err := self.Client(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return cronJobSchedules(client, namespace, time.Now())
+}
+
+func cronJobSchedules(client kubernetes.Interface, namespace string, now time.Time) ([]clientapi.CronJobSummary,
+	error) {
+	cronJobs, err := client.BatchV1().CronJobs(namespace).List(context.TODO(), metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := client.BatchV1().Jobs(namespace).List(context.TODO(), metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	lastJobs := make(map[types.UID]*batch.Job)
+	for i := range jobs.Items {
+		j := &jobs.Items[i]
+		owner := metaV1.GetControllerOf(j)
+		if owner == nil || owner.Kind != "CronJob" {
+			continue
+		}
+
+		if last, ok := lastJobs[owner.UID]; !ok || last.CreationTimestamp.Before(&j.CreationTimestamp) {
+			lastJobs[owner.UID] = j
+		}
+	}
+
+	result := make([]clientapi.CronJobSummary, 0, len(cronJobs.Items))
+	for i := range cronJobs.Items {
+		cronJob := &cronJobs.Items[i]
+		result = append(result, toCronJobSummary(cronJob, lastJobs[cronJob.UID], now))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+func toCronJobSummary(cronJob *batch.CronJob, lastJob *batch.Job, now time.Time) clientapi.CronJobSummary {
+	summary := clientapi.CronJobSummary{
+		Name:               cronJob.Name,
+		Namespace:          cronJob.Namespace,
+		Schedule:           cronJob.Spec.Schedule,
+		Suspended:          cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend,
+		LastSchedule:       cronJob.Status.LastScheduleTime,
+		LastSuccessfulTime: cronJob.Status.LastSuccessfulTime,
+		Active:             len(cronJob.Status.Active),
+	}
+
+	if lastJob != nil {
+		summary.LastJob = lastJob.Name
+		summary.LastRunStatus = toJobStatus(lastJob).Status
+	}
+
+	location := time.UTC
+	if cronJob.Spec.TimeZone != nil && len(*cronJob.Spec.TimeZone) > 0 {
+		summary.TimeZone = *cronJob.Spec.TimeZone
+		loaded, err := time.LoadLocation(summary.TimeZone)
+		if err != nil {
+			summary.Error = fmt.Sprintf("unknown time zone %q", summary.TimeZone)
+			return summary
+		}
+		location = loaded
+	}
+
+	schedule, err := parseCronSchedule(cronJob.Spec.Schedule, location)
+	if err != nil {
+		summary.Error = err.Error()
+		return summary
+	}
+
+	if summary.Suspended {
+		return summary
+	}
+
+	next := schedule.next(now)
+	if next.IsZero() {
+		summary.Error = fmt.Sprintf("schedule %q does not match any time", cronJob.Spec.Schedule)
+		return summary
+	}
+
+	nextSchedule := metaV1.NewTime(next)
+	summary.NextSchedule = &nextSchedule
+	return summary
+}
@@ -0,0 +1,115 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	batch "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+func TestCronJobSchedules(t *testing.T) {
+	now := time.Date(2022, time.June, 1, 12, 30, 0, 0, time.UTC)
+	lastSchedule := metaV1.NewTime(time.Date(2022, time.June, 1, 2, 0, 0, 0, time.UTC))
+	suspend, isController := true, true
+	berlin, unknown := "Europe/Berlin", "Mars/Olympus"
+	owner := func(uid types.UID) []metaV1.OwnerReference {
+		return []metaV1.OwnerReference{{Kind: "CronJob", Name: string(uid), UID: uid, Controller: &isController}}
+	}
+
+	client := fake.NewSimpleClientset(
+		&batch.CronJob{
+			ObjectMeta: metaV1.ObjectMeta{Name: "backup", Namespace: "default", UID: "backup"},
+			Spec:       batch.CronJobSpec{Schedule: "0 2 * * *"},
+			Status:     batch.CronJobStatus{LastScheduleTime: &lastSchedule, LastSuccessfulTime: &lastSchedule},
+		},
+		&batch.CronJob{
+			ObjectMeta: metaV1.ObjectMeta{Name: "report", Namespace: "default", UID: "report"},
+			Spec:       batch.CronJobSpec{Schedule: "0 9 * * *", TimeZone: &berlin},
+			Status:     batch.CronJobStatus{Active: []v1.ObjectReference{{Name: "report-2"}}},
+		},
+		&batch.CronJob{
+			ObjectMeta: metaV1.ObjectMeta{Name: "cleanup", Namespace: "default", UID: "cleanup"},
+			Spec:       batch.CronJobSpec{Schedule: "*/5 * * * *", Suspend: &suspend},
+		},
+		&batch.CronJob{
+			ObjectMeta: metaV1.ObjectMeta{Name: "broken", Namespace: "default", UID: "broken"},
+			Spec:       batch.CronJobSpec{Schedule: "0 25 * * *"},
+		},
+		&batch.CronJob{
+			ObjectMeta: metaV1.ObjectMeta{Name: "remote", Namespace: "default", UID: "remote"},
+			Spec:       batch.CronJobSpec{Schedule: "0 0 * * *", TimeZone: &unknown},
+		},
+		&batch.Job{
+			ObjectMeta: metaV1.ObjectMeta{Name: "backup-1", Namespace: "default", OwnerReferences: owner("backup"),
+				CreationTimestamp: metaV1.NewTime(now.Add(-48 * time.Hour))},
+			Status: batch.JobStatus{Conditions: []batch.JobCondition{
+				{Type: batch.JobFailed, Status: v1.ConditionTrue},
+			}},
+		},
+		&batch.Job{
+			ObjectMeta: metaV1.ObjectMeta{Name: "backup-2", Namespace: "default", OwnerReferences: owner("backup"),
+				CreationTimestamp: lastSchedule},
+			Status: batch.JobStatus{Succeeded: 1, Conditions: []batch.JobCondition{
+				{Type: batch.JobComplete, Status: v1.ConditionTrue},
+			}},
+		},
+		&batch.Job{
+			ObjectMeta: metaV1.ObjectMeta{Name: "report-2", Namespace: "default", OwnerReferences: owner("report"),
+				CreationTimestamp: metaV1.NewTime(now.Add(-time.Hour))},
+			Status: batch.JobStatus{Active: 1},
+		},
+	)
+
+	actual, err := cronJobSchedules(client, "default", now)
+	if err != nil {
+		t.Fatalf("Expected cron job schedules, but got %v", err)
+	}
+
+	backupNext := metaV1.NewTime(time.Date(2022, time.June, 2, 2, 0, 0, 0, time.UTC))
+	// 09:00 in Berlin is 07:00 UTC during summer time.
+	reportNext := metaV1.NewTime(time.Date(2022, time.June, 2, 7, 0, 0, 0, time.UTC))
+	expected := []clientapi.CronJobSummary{
+		{Name: "backup", Namespace: "default", Schedule: "0 2 * * *", LastSchedule: &lastSchedule,
+			LastSuccessfulTime: &lastSchedule, NextSchedule: &backupNext, LastJob: "backup-2",
+			LastRunStatus: "Complete"},
+		{Name: "broken", Namespace: "default", Schedule: "0 25 * * *",
+			Error: `invalid value "25" in hour field, expected 0-23`},
+		{Name: "cleanup", Namespace: "default", Schedule: "*/5 * * * *", Suspended: true},
+		{Name: "remote", Namespace: "default", Schedule: "0 0 * * *", TimeZone: unknown,
+			Error: `unknown time zone "Mars/Olympus"`},
+		{Name: "report", Namespace: "default", Schedule: "0 9 * * *", TimeZone: berlin, NextSchedule: &reportNext,
+			Active: 1, LastJob: "report-2", LastRunStatus: "Running"},
+	}
+
+	for i := range actual {
+		if next := actual[i].NextSchedule; next != nil {
+			utc := metaV1.NewTime(next.UTC())
+			actual[i].NextSchedule = &utc
+		}
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected cron job schedules \n%#v, but got \n%#v", expected, actual)
+	}
+}
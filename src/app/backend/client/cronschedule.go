@@ -0,0 +1,225 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule is a parsed standard cron schedule in the format used by cron jobs: minute, hour, day of month, month
+// and day of week fields, or one of the predefined descriptors like '@daily'.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// When both day fields are restricted, the day matches if either of them matches. Otherwise both have to match.
+	domStar, dowStar bool
+	// every is set for '@every <duration>' schedules.
+	every    time.Duration
+	location *time.Location
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Day of week allows 7 for Sunday as well, it is folded into 0 after parsing.
+	cronDow = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronSearchYears limits the search for the next run of schedules that never match, i.e. '0 0 30 2 *'.
+const cronSearchYears = 5
+
+// parseCronSchedule parses the schedule evaluated in the given location. 'TZ=' and 'CRON_TZ=' prefixes of the schedule
+// override the location.
+func parseCronSchedule(spec string, location *time.Location) (*cronSchedule, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ=") {
+		i := strings.Index(spec, " ")
+		if i < 0 {
+			return nil, fmt.Errorf("missing schedule after time zone in %q", spec)
+		}
+
+		name := spec[strings.Index(spec, "=")+1 : i]
+		loaded, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("unknown time zone %q", name)
+		}
+		location, spec = loaded, strings.TrimSpace(spec[i:])
+	}
+
+	schedule := &cronSchedule{location: location}
+	if strings.HasPrefix(spec, "@every ") {
+		every, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil || every < time.Second {
+			return nil, fmt.Errorf("invalid duration in %q", spec)
+		}
+		schedule.every = every
+		return schedule, nil
+	}
+
+	if descriptor, ok := cronDescriptors[strings.ToLower(spec)]; ok {
+		spec = descriptor
+	} else if strings.HasPrefix(spec, "@") {
+		return nil, fmt.Errorf("unknown descriptor %q", spec)
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in %q, found %d", spec, len(fields))
+	}
+
+	var err error
+	targets := []*uint64{&schedule.minute, &schedule.hour, &schedule.dom, &schedule.month, &schedule.dow}
+	for i, field := range []cronField{cronMinute, cronHour, cronDom, cronMonth, cronDow} {
+		if *targets[i], err = field.parse(fields[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow = schedule.dow&^(1<<7) | 1
+	}
+	schedule.domStar = isCronStar(fields[2])
+	schedule.dowStar = isCronStar(fields[4])
+	return schedule, nil
+}
+
+func isCronStar(field string) bool {
+	return field == "*" || field == "?" || field == "*/1"
+}
+
+// Parses comma separated list of '*', '?', 'a', 'a-b', '*/n', 'a/n' and 'a-b/n' into a bit set of allowed values.
+func (self cronField) parse(field string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			parsed, err := strconv.Atoi(part[i+1:])
+			if err != nil || parsed <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field %q", self.name, part)
+			}
+			rangePart, step = part[:i], parsed
+		}
+
+		start, end := self.min, self.max
+		switch {
+		case rangePart == "*" || rangePart == "?":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if start, err = self.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			if end, err = self.value(bounds[1]); err != nil {
+				return 0, err
+			}
+		default:
+			var err error
+			if start, err = self.value(rangePart); err != nil {
+				return 0, err
+			}
+			// Single value without step matches just the value, 'a/n' means from a to the maximum.
+			if step == 1 && !strings.Contains(part, "/") {
+				end = start
+			}
+		}
+
+		if start > end {
+			return 0, fmt.Errorf("invalid range in %s field %q", self.name, part)
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+
+	return bits, nil
+}
+
+func (self cronField) value(text string) (int, error) {
+	if value, ok := self.names[strings.ToLower(text)]; ok {
+		return value, nil
+	}
+
+	value, err := strconv.Atoi(text)
+	if err != nil || value < self.min || value > self.max {
+		return 0, fmt.Errorf("invalid value %q in %s field, expected %d-%d", text, self.name, self.min, self.max)
+	}
+
+	return value, nil
+}
+
+// next returns the first time after the given time matched by the schedule, or zero time if there is none.
+func (self *cronSchedule) next(after time.Time) time.Time {
+	if self.every > 0 {
+		return after.Truncate(time.Second).Add(self.every)
+	}
+
+	t := after.In(self.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + cronSearchYears
+	for t.Year() <= limit {
+		switch {
+		case !hasBit(self.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, self.location)
+		case !self.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, self.location)
+		case !hasBit(self.hour, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, self.location)
+		case !hasBit(self.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (self *cronSchedule) dayMatches(t time.Time) bool {
+	dom, dow := hasBit(self.dom, t.Day()), hasBit(self.dow, int(t.Weekday()))
+	if self.domStar || self.dowStar {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+func hasBit(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
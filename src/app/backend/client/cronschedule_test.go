@@ -0,0 +1,111 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	// Wednesday.
+	after := time.Date(2022, time.June, 1, 12, 30, 15, 0, time.UTC)
+	cases := []struct {
+		schedule string
+		expected time.Time
+	}{
+		{"*/15 * * * *", time.Date(2022, time.June, 1, 12, 45, 0, 0, time.UTC)},
+		{"0 * * * *", time.Date(2022, time.June, 1, 13, 0, 0, 0, time.UTC)},
+		{"30 12 * * *", time.Date(2022, time.June, 2, 12, 30, 0, 0, time.UTC)},
+		{"10-20/5 13 * * *", time.Date(2022, time.June, 1, 13, 10, 0, 0, time.UTC)},
+		{"0,45 12,18 * * ?", time.Date(2022, time.June, 1, 12, 45, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2022, time.June, 2, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * MON", time.Date(2022, time.June, 6, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2022, time.June, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2022, time.July, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 jul-aug *", time.Date(2022, time.July, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 */10 * *", time.Date(2022, time.June, 11, 0, 0, 0, 0, time.UTC)},
+		// Both day fields are restricted, so either of them has to match.
+		{"0 0 13 * 5", time.Date(2022, time.June, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2022, time.June, 1, 13, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2022, time.June, 2, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2022, time.June, 5, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2022, time.July, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"@every 90m", time.Date(2022, time.June, 1, 14, 0, 15, 0, time.UTC)},
+		{"CRON_TZ=Europe/Berlin 0 9 * * *", time.Date(2022, time.June, 2, 7, 0, 0, 0, time.UTC)},
+		{"TZ=America/New_York 0 9 * * *", time.Date(2022, time.June, 1, 13, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+
+	for _, c := range cases {
+		schedule, err := parseCronSchedule(c.schedule, time.UTC)
+		if err != nil {
+			t.Errorf("parseCronSchedule(%q) returned error: %v", c.schedule, err)
+			continue
+		}
+
+		if actual := schedule.next(after); !actual.Equal(c.expected) {
+			t.Errorf("next(%q) == %v, expected %v", c.schedule, actual, c.expected)
+		}
+	}
+}
+
+func TestCronScheduleNextInLocation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone database is not available: %v", err)
+	}
+
+	schedule, err := parseCronSchedule("30 2 * * *", berlin)
+	if err != nil {
+		t.Fatalf("parseCronSchedule() returned error: %v", err)
+	}
+
+	// 02:30 does not exist on the day daylight saving time starts, so the run is skipped.
+	after := time.Date(2022, time.March, 27, 0, 0, 0, 0, berlin)
+	expected := time.Date(2022, time.March, 28, 2, 30, 0, 0, berlin)
+	if actual := schedule.next(after); !actual.Equal(expected) {
+		t.Errorf("next() == %v, expected %v", actual, expected)
+	}
+}
+
+func TestParseCronScheduleErrors(t *testing.T) {
+	cases := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"* * * foo *",
+		"@fortnightly",
+		"@every 0s",
+		"TZ=Mars/Olympus 0 0 * * *",
+		"CRON_TZ=UTC",
+	}
+
+	for _, c := range cases {
+		if _, err := parseCronSchedule(c, time.UTC); err == nil {
+			t.Errorf("parseCronSchedule(%q) expected error", c)
+		}
+	}
+}
@@ -18,6 +18,8 @@ import (
 	"context"
 	"log"
 
+	coreV1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 
@@ -37,6 +39,10 @@ func (self *csrfTokenManager) init() {
 		Secrets(args.Holder.GetNamespace()).
 		Get(context.TODO(), api.CsrfTokenSecretName, v1.GetOptions{})
 
+	if k8serrors.IsNotFound(err) {
+		tokenSecret, err = self.createSecret()
+	}
+
 	if err != nil {
 		panic(err)
 	}
@@ -55,6 +61,28 @@ func (self *csrfTokenManager) init() {
 	self.token = token
 }
 
+// Creates secret holding newly generated token. It is done on the first start of the dashboard. In case other replica
+// has created the secret in the meantime, its secret is used instead.
+func (self *csrfTokenManager) createSecret() (*coreV1.Secret, error) {
+	log.Printf("Secret %s not found. Generating csrf token and storing it in a new secret", api.CsrfTokenSecretName)
+	secret := &coreV1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      api.CsrfTokenSecretName,
+			Namespace: args.Holder.GetNamespace(),
+		},
+		Data: map[string][]byte{api.CsrfTokenSecretData: []byte(api.GenerateCSRFKey())},
+	}
+
+	created, err := self.client.CoreV1().Secrets(args.Holder.GetNamespace()).Create(context.TODO(), secret,
+		v1.CreateOptions{})
+	if k8serrors.IsAlreadyExists(err) {
+		return self.client.CoreV1().Secrets(args.Holder.GetNamespace()).
+			Get(context.TODO(), api.CsrfTokenSecretName, v1.GetOptions{})
+	}
+
+	return created, err
+}
+
 // Token implements CsrfTokenManager interface.
 func (self *csrfTokenManager) Token() string {
 	return self.token
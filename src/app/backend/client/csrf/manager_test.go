@@ -15,6 +15,7 @@
 package csrf_test
 
 import (
+	"context"
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
@@ -29,34 +30,59 @@ func TestCsrfTokenManager_Token(t *testing.T) {
 	cases := []struct {
 		info       string
 		csrfSecret *v1.Secret
-		wantPanic  bool
-		wantToken  bool
+		wantToken  string
 	}{
-		{"should panic when secret does not exist", nil, true, false},
+		{"should generate token and create secret when secret does not exist", nil, ""},
 		{"should generate token when secret exists",
 			&v1.Secret{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: api.CsrfTokenSecretName,
 				},
-			}, false, true},
+			}, ""},
+		{"should use token stored in secret",
+			&v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: api.CsrfTokenSecretName,
+				},
+				Data: map[string][]byte{api.CsrfTokenSecretData: []byte("stored-token")},
+			}, "stored-token"},
 	}
 
 	for _, c := range cases {
 		t.Run(c.info, func(t *testing.T) {
-			defer func() {
-				r := recover()
-				if (r != nil) != c.wantPanic {
-					t.Errorf("Recover = %v, wantPanic = %v", r, c.wantPanic)
-				}
-			}()
-
-			client := fake.NewSimpleClientset(c.csrfSecret)
+			client := fake.NewSimpleClientset()
+			if c.csrfSecret != nil {
+				client = fake.NewSimpleClientset(c.csrfSecret)
+			}
+
 			manager := csrf.NewCsrfTokenManager(client)
+			if len(manager.Token()) == 0 {
+				t.Fatal("Expected token to exist")
+			}
 
-			if (len(manager.Token()) == 0) == c.wantToken {
-				t.Errorf("Expected token to exist: %v", c.wantToken)
+			if len(c.wantToken) > 0 && manager.Token() != c.wantToken {
+				t.Errorf("Expected token %s, got %s", c.wantToken, manager.Token())
+			}
+
+			secret, err := client.CoreV1().Secrets("").Get(context.TODO(), api.CsrfTokenSecretName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected secret to exist: %s", err.Error())
+			}
+
+			if string(secret.Data[api.CsrfTokenSecretData]) != manager.Token() &&
+				secret.StringData[api.CsrfTokenSecretData] != manager.Token() {
+				t.Error("Expected secret to hold token used for csrf signing")
 			}
 		})
+	}
+}
+
+func TestCsrfTokenManager_TokenSharedBetweenReplicas(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	first := csrf.NewCsrfTokenManager(client)
+	second := csrf.NewCsrfTokenManager(client)
 
+	if first.Token() != second.Token() {
+		t.Error("Expected replicas to use the same token stored in secret")
 	}
 }
@@ -0,0 +1,100 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/common"
+)
+
+// ListCustomResources lists instances of the given resource using credentials of the user. Label and field
+// selectors as well as limit and continue token of the options are passed to the apiserver, limit and timeout are
+// subject to the page size and list timeout arguments. Namespace is ignored for cluster-scoped resources. Items of
+// the fetched page are sorted according to the list sort, if provided.
+func (self *clientManager) ListCustomResources(req *restful.Request, gvr schema.GroupVersionResource,
+	namespace string, opts metaV1.ListOptions, listSort *common.ListSort) (*unstructured.UnstructuredList, error) {
+	cfg, err := self.Config(req)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err = common.WithPageLimit(opts, opts.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err = common.WithListTimeout(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return listCustomResources(client, self.restMapper(), gvr, namespace, opts, listSort)
+}
+
+func listCustomResources(client dynamic.Interface, mapper meta.ResettableRESTMapper, gvr schema.GroupVersionResource,
+	namespace string, opts metaV1.ListOptions, listSort *common.ListSort) (*unstructured.UnstructuredList, error) {
+	namespaced, err := isNamespaced(mapper, gvr)
+	if err != nil {
+		return nil, err
+	}
+
+	resource := client.Resource(gvr)
+	var list *unstructured.UnstructuredList
+	if namespaced {
+		list, err = resource.Namespace(namespace).List(context.TODO(), opts)
+	} else {
+		list, err = resource.List(context.TODO(), opts)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return list, common.SortList(list, listSort)
+}
+
+// Returns true if the given resource is namespaced. Mapper is reset once if resource is not known.
+func isNamespaced(mapper meta.ResettableRESTMapper, gvr schema.GroupVersionResource) (bool, error) {
+	gvk, err := mapper.KindFor(gvr)
+	if meta.IsNoMatchError(err) {
+		mapper.Reset()
+		gvk, err = mapper.KindFor(gvr)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	mapping, err := restMapping(mapper, gvk)
+	if err != nil {
+		return false, err
+	}
+
+	return mapping.Scope.Name() == meta.RESTScopeNameNamespace, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/common"
+)
+
+var (
+	widgetsGVR  = schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	clustersGVR = schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "clusters"}
+)
+
+func newCustomResource(kind, namespace, name string, labels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("example.com/v1")
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	return obj
+}
+
+func TestListCustomResources(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{widgetsGVR: "WidgetList", clustersGVR: "ClusterList"},
+		newCustomResource("Widget", "default", "first", map[string]string{"app": "a"}),
+		newCustomResource("Widget", "default", "second", map[string]string{"app": "b"}),
+		newCustomResource("Widget", "other", "third", map[string]string{"app": "a"}),
+		newCustomResource("Cluster", "", "cluster", nil),
+	)
+	mapper := newTestRESTMapper(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"})
+	mapper.Add(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Cluster"}, meta.RESTScopeRoot)
+
+	cases := []struct {
+		info      string
+		gvr       schema.GroupVersionResource
+		namespace string
+		opts      metaV1.ListOptions
+		listSort  *common.ListSort
+		expected  []string
+	}{
+		{"should list namespaced resources", widgetsGVR, "default", metaV1.ListOptions{}, nil,
+			[]string{"first", "second"}},
+		{"should list namespaced resources in all namespaces", widgetsGVR, "", metaV1.ListOptions{}, nil,
+			[]string{"first", "second", "third"}},
+		{"should filter by label selector", widgetsGVR, "", metaV1.ListOptions{LabelSelector: "app=a"}, nil,
+			[]string{"first", "third"}},
+		{"should ignore namespace of cluster-scoped resources", clustersGVR, "default", metaV1.ListOptions{}, nil,
+			[]string{"cluster"}},
+		{"should sort fetched items", widgetsGVR, "", metaV1.ListOptions{},
+			&common.ListSort{By: common.SortByLabelPrefix, Label: "app", Descending: true},
+			[]string{"second", "first", "third"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.info, func(t *testing.T) {
+			list, err := listCustomResources(client, mapper, c.gvr, c.namespace, c.opts, c.listSort)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			names := make([]string, 0, len(list.Items))
+			for _, item := range list.Items {
+				names = append(names, item.GetName())
+			}
+
+			if len(names) != len(c.expected) {
+				t.Fatalf("Expected %v, got %v", c.expected, names)
+			}
+
+			for i := range names {
+				if names[i] != c.expected[i] {
+					t.Errorf("Expected %v, got %v", c.expected, names)
+				}
+			}
+		})
+	}
+
+	if mapper.resets != 1 {
+		t.Errorf("Expected mapper to be reset once to discover new resource, got %d resets", mapper.resets)
+	}
+
+	if _, err := listCustomResources(client, mapper, schema.GroupVersionResource{Group: "unknown", Version: "v1",
+		Resource: "things"}, "", metaV1.ListOptions{}, nil); !meta.IsNoMatchError(err) {
+		t.Errorf("Expected no match error for unknown resource, got %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"k8s.io/client-go/rest"
+)
+
+// MaxInFlightDeduplicatedRequests limits the number of distinct requests tracked by the request deduplicator.
+// Requests over the limit are sent directly to the apiserver.
+const MaxInFlightDeduplicatedRequests = 1024
+
+// requestDeduplicator merges concurrent identical GET requests, so that only one of them is sent to the apiserver and
+// its response is shared with the others. Requests are identical when they are made by the same user for the same
+// URL, including resource and selectors, accept the same content type and carry the same propagated headers.
+type requestDeduplicator struct {
+	mux         sync.Mutex
+	calls       map[string]*inFlightCall
+	maxInFlight int
+	// Names of the headers propagated to the apiserver requests, see 'propagated-request-headers' argument. They are
+	// set before requests reach the deduplicator and may change the response, i.e. X-Tenant-ID.
+	propagatedHeaders []string
+}
+
+// inFlightCall is a request sent to the apiserver. Response is available once done is closed.
+type inFlightCall struct {
+	done chan struct{}
+	// Context of the request that is sent to the apiserver.
+	ctx  context.Context
+	resp *http.Response
+	body []byte
+	err  error
+	// Number of other requests waiting for the response.
+	shared int
+}
+
+func newRequestDeduplicator(maxInFlight int, propagatedHeaders []string) *requestDeduplicator {
+	return &requestDeduplicator{calls: make(map[string]*inFlightCall), maxInFlight: maxInFlight,
+		propagatedHeaders: propagatedHeaders}
+}
+
+// Adds request deduplication to the transport of the given config.
+func (self *requestDeduplicator) configure(cfg *rest.Config) {
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &deduplicatingRoundTripper{delegate: rt, deduplicator: self}
+	})
+}
+
+// Returns existing call for the key or registers a new one. Returns nil if the request should not be deduplicated
+// because too many requests are in flight.
+func (self *requestDeduplicator) join(key string) (call *inFlightCall, leader bool) {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	if call, ok := self.calls[key]; ok {
+		call.shared++
+		return call, false
+	}
+
+	if len(self.calls) >= self.maxInFlight {
+		return nil, false
+	}
+
+	call = &inFlightCall{done: make(chan struct{})}
+	self.calls[key] = call
+	return call, true
+}
+
+func (self *requestDeduplicator) finish(key string, call *inFlightCall) {
+	self.mux.Lock()
+	delete(self.calls, key)
+	self.mux.Unlock()
+	close(call.done)
+}
+
+// deduplicatingRoundTripper sends identical concurrent requests through the request deduplicator.
+type deduplicatingRoundTripper struct {
+	delegate     http.RoundTripper
+	deduplicator *requestDeduplicator
+}
+
+// RoundTrip implements http.RoundTripper.
+func (self *deduplicatingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || isStreamingRequest(req) {
+		return self.delegate.RoundTrip(req)
+	}
+
+	key := deduplicationKey(req, self.deduplicator.propagatedHeaders)
+	call, leader := self.deduplicator.join(key)
+	if call == nil {
+		return self.delegate.RoundTrip(req)
+	}
+
+	if leader {
+		call.ctx = req.Context()
+		call.resp, call.err = self.delegate.RoundTrip(req)
+		if call.err == nil && call.resp.Body != nil {
+			call.body, call.err = io.ReadAll(call.resp.Body)
+			call.resp.Body.Close()
+		}
+		self.deduplicator.finish(key, call)
+		return call.copyResponse(req)
+	}
+
+	select {
+	case <-call.done:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	// Request of the leader might have been cancelled by its caller, which should not affect the others.
+	if call.err != nil && call.ctx.Err() != nil && req.Context().Err() == nil {
+		return self.delegate.RoundTrip(req)
+	}
+
+	return call.copyResponse(req)
+}
+
+// WrappedRoundTripper allows client-go to reach the underlying transport, i.e. to close idle connections.
+func (self *deduplicatingRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return self.delegate
+}
+
+// Returns a copy of the shared response with its own body, so that every caller can read and close it.
+func (self *inFlightCall) copyResponse(req *http.Request) (*http.Response, error) {
+	if self.err != nil {
+		return nil, self.err
+	}
+
+	resp := *self.resp
+	resp.Header = self.resp.Header.Clone()
+	resp.Request = req
+	resp.Body = io.NopCloser(bytes.NewReader(self.body))
+	return &resp, nil
+}
+
+// Returns key identifying the user and the requested data. User credentials and values of the propagated headers are
+// hashed, so the tokens are not kept in memory longer than needed.
+func deduplicationKey(req *http.Request, propagatedHeaders []string) string {
+	user := sha256.New()
+	headers := append([]string{"Authorization", "Impersonate-User", "Impersonate-Group", "Impersonate-Uid"},
+		propagatedHeaders...)
+	for _, header := range headers {
+		header = http.CanonicalHeaderKey(strings.TrimSpace(header))
+		user.Write([]byte(header + ":" + strings.Join(req.Header.Values(header), ",") + "\n"))
+	}
+
+	return hex.EncodeToString(user.Sum(nil)) + " " + req.Header.Get("Accept") + " " + req.URL.String()
+}
@@ -0,0 +1,171 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Returns round tripper that counts requests and blocks them until release is closed.
+func newBlockingRoundTripper(calls *int32, release chan struct{}) roundTripperFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(calls, 1)
+		<-release
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"kind":"PodList"}`)),
+			Request:    req,
+		}, nil
+	}
+}
+
+func newDeduplicatedRequest(t *testing.T, token, url string) *http.Request {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+// Waits until given number of requests wait for the in-flight call.
+func waitForSharedCall(t *testing.T, deduplicator *requestDeduplicator, shared int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		deduplicator.mux.Lock()
+		total := 0
+		for _, call := range deduplicator.calls {
+			total += call.shared
+		}
+		deduplicator.mux.Unlock()
+
+		if total >= shared {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatalf("Timed out waiting for %d shared requests", shared)
+}
+
+func TestDeduplicatingRoundTripper(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	deduplicator := newRequestDeduplicator(MaxInFlightDeduplicatedRequests, nil)
+	rt := &deduplicatingRoundTripper{delegate: newBlockingRoundTripper(&calls, release), deduplicator: deduplicator}
+
+	const url = "https://localhost/api/v1/pods?labelSelector=app%3Dweb"
+	bodies := make([]string, 2)
+	wg := sync.WaitGroup{}
+	for i := range bodies {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := rt.RoundTrip(newDeduplicatedRequest(t, "token", url))
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			bodies[i] = string(body)
+		}(i)
+	}
+
+	waitForSharedCall(t, deduplicator, 1)
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatalf("Expected identical concurrent requests to result in one call, but got %d", calls)
+	}
+
+	for _, body := range bodies {
+		if body != `{"kind":"PodList"}` {
+			t.Errorf("Expected response to be shared, but got %s", body)
+		}
+	}
+
+	if len(deduplicator.calls) != 0 {
+		t.Errorf("Expected finished calls to be removed, but got %d", len(deduplicator.calls))
+	}
+}
+
+// Sets the tenant header the way header propagation does before the request reaches the deduplicator.
+func withTenant(req *http.Request, tenant string) *http.Request {
+	req.Header.Set("X-Tenant-ID", tenant)
+	return req
+}
+
+func TestDeduplicatingRoundTripperDistinctRequests(t *testing.T) {
+	cases := []struct {
+		info        string
+		first       *http.Request
+		second      *http.Request
+		maxInFlight int
+	}{
+		{"different users", newDeduplicatedRequest(t, "a", "https://localhost/api/v1/pods"),
+			newDeduplicatedRequest(t, "b", "https://localhost/api/v1/pods"), MaxInFlightDeduplicatedRequests},
+		{"different selectors", newDeduplicatedRequest(t, "a", "https://localhost/api/v1/pods?labelSelector=a"),
+			newDeduplicatedRequest(t, "a", "https://localhost/api/v1/pods?labelSelector=b"),
+			MaxInFlightDeduplicatedRequests},
+		{"watch", newDeduplicatedRequest(t, "a", "https://localhost/api/v1/pods?watch=true"),
+			newDeduplicatedRequest(t, "a", "https://localhost/api/v1/pods?watch=true"), MaxInFlightDeduplicatedRequests},
+		{"in-flight limit", newDeduplicatedRequest(t, "a", "https://localhost/api/v1/pods"),
+			newDeduplicatedRequest(t, "a", "https://localhost/api/v1/pods"), 0},
+		{"different propagated headers",
+			withTenant(newDeduplicatedRequest(t, "a", "https://localhost/api/v1/pods"), "a"),
+			withTenant(newDeduplicatedRequest(t, "a", "https://localhost/api/v1/pods"), "b"),
+			MaxInFlightDeduplicatedRequests},
+	}
+
+	for _, c := range cases {
+		var calls int32
+		release := make(chan struct{})
+		rt := &deduplicatingRoundTripper{delegate: newBlockingRoundTripper(&calls, release),
+			deduplicator: newRequestDeduplicator(c.maxInFlight, []string{"x-tenant-id"})}
+
+		wg := sync.WaitGroup{}
+		for _, req := range []*http.Request{c.first, c.second} {
+			wg.Add(1)
+			go func(req *http.Request) {
+				defer wg.Done()
+				if resp, err := rt.RoundTrip(req); err == nil {
+					resp.Body.Close()
+				}
+			}(req)
+		}
+
+		deadline := time.Now().Add(5 * time.Second)
+		for atomic.LoadInt32(&calls) < 2 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		close(release)
+		wg.Wait()
+
+		if calls != 2 {
+			t.Errorf("%s: expected requests not to be merged, but got %d calls", c.info, calls)
+		}
+	}
+}
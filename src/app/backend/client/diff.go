@@ -0,0 +1,227 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+// DiffContextLines is the number of unchanged lines shown around every change of the diff.
+const DiffContextLines = 3
+
+// DiffResource returns unified diff between the current object and the object that would be stored if proposed
+// configuration was applied, using credentials of the user. Result is computed by the apiserver with server-side
+// apply in dry-run mode, so defaulting, admission and conflicts with other field managers are taken into account.
+// Diff of the object that does not exist yet shows all its lines as added. Empty diff means no changes.
+func (self *clientManager) DiffResource(req *restful.Request, gvr schema.GroupVersionResource, namespace string,
+	proposed *unstructured.Unstructured) (string, error) {
+	cfg, err := self.Config(req)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	return diffResource(client, gvr, namespace, proposed)
+}
+
+func diffResource(client dynamic.Interface, gvr schema.GroupVersionResource, namespace string,
+	proposed *unstructured.Unstructured) (string, error) {
+	if proposed == nil || len(proposed.GetName()) == 0 {
+		return "", errors.NewBadRequest("name of the proposed object is required")
+	}
+
+	resource := client.Resource(gvr).Namespace(namespace)
+	current, err := resource.Get(context.TODO(), proposed.GetName(), metaV1.GetOptions{})
+	if errors.IsNotFoundError(err) {
+		current = nil
+	} else if err != nil {
+		return "", err
+	}
+
+	body, err := proposed.MarshalJSON()
+	if err != nil {
+		return "", err
+	}
+
+	result, err := resource.Patch(context.TODO(), proposed.GetName(), types.ApplyPatchType, body,
+		metaV1.PatchOptions{FieldManager: ApplyFieldManager, DryRun: []string{metaV1.DryRunAll}})
+	if err != nil {
+		return "", err
+	}
+
+	from, err := toDiffLines(current)
+	if err != nil {
+		return "", err
+	}
+
+	to, err := toDiffLines(result)
+	if err != nil {
+		return "", err
+	}
+
+	name := gvr.Resource + "/" + proposed.GetName()
+	return unifiedDiff("current/"+name, "proposed/"+name, from, to), nil
+}
+
+// Serializes object to YAML lines. Managed fields are left out as they change with every request.
+func toDiffLines(obj *unstructured.Unstructured) ([]string, error) {
+	if obj == nil {
+		return nil, nil
+	}
+
+	obj = obj.DeepCopy()
+	obj.SetManagedFields(nil)
+	content, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+	if last := len(lines) - 1; len(lines[last]) == 0 {
+		lines = lines[:last]
+	}
+
+	return lines, nil
+}
+
+type diffOp struct {
+	kind byte
+	line string
+	// Indexes of the line in the old and new version before this operation.
+	from, to int
+}
+
+// Returns diff of the lines in the unified format using longest common subsequence of the lines.
+func unifiedDiff(fromName, toName string, from, to []string) string {
+	ops := diffOps(from, to)
+
+	var result strings.Builder
+	for start := 0; start < len(ops); {
+		if ops[start].kind == ' ' {
+			start++
+			continue
+		}
+
+		hunkStart := start - DiffContextLines
+		if hunkStart < 0 {
+			hunkStart = 0
+		}
+
+		// Extend the hunk as long as next change is close enough for the context lines to overlap.
+		end, unchanged := start, 0
+		for i := start; i < len(ops) && unchanged <= 2*DiffContextLines; i++ {
+			if ops[i].kind == ' ' {
+				unchanged++
+				continue
+			}
+			unchanged, end = 0, i+1
+		}
+
+		hunkEnd := end + DiffContextLines
+		if hunkEnd > len(ops) {
+			hunkEnd = len(ops)
+		}
+
+		if result.Len() == 0 {
+			fmt.Fprintf(&result, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		writeHunk(&result, ops[hunkStart:hunkEnd])
+		start = hunkEnd
+	}
+
+	return result.String()
+}
+
+func diffOps(from, to []string) []diffOp {
+	// lengths[i][j] is the length of the longest common subsequence of from[i:] and to[j:].
+	lengths := make([][]int, len(from)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(to)+1)
+	}
+
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, len(from)+len(to))
+	i, j := 0, 0
+	for i < len(from) || j < len(to) {
+		switch {
+		case i < len(from) && j < len(to) && from[i] == to[j]:
+			ops = append(ops, diffOp{kind: ' ', line: from[i], from: i, to: j})
+			i++
+			j++
+		case j == len(to) || (i < len(from) && lengths[i+1][j] >= lengths[i][j+1]):
+			ops = append(ops, diffOp{kind: '-', line: from[i], from: i, to: j})
+			i++
+		default:
+			ops = append(ops, diffOp{kind: '+', line: to[j], from: i, to: j})
+			j++
+		}
+	}
+
+	return ops
+}
+
+func writeHunk(result *strings.Builder, ops []diffOp) {
+	fromLen, toLen := 0, 0
+	for _, op := range ops {
+		if op.kind != '+' {
+			fromLen++
+		}
+		if op.kind != '-' {
+			toLen++
+		}
+	}
+
+	fmt.Fprintf(result, "@@ -%s +%s @@\n", hunkRange(ops[0].from, fromLen), hunkRange(ops[0].to, toLen))
+	for _, op := range ops {
+		result.WriteByte(op.kind)
+		result.WriteString(op.line)
+	}
+}
+
+// Formats range of the hunk. Empty range starts at the line preceding the hunk.
+func hunkRange(start, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
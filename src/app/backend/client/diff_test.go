@@ -0,0 +1,164 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+const testConfigMap = `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings","namespace":"default",` +
+	`"managedFields":[{"manager":"dashboard"}]},"data":{"level":"%s","mode":"fast"}}`
+
+func newDiffTestClient(t *testing.T, current string, patched *string, query *string) dynamic.Interface {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			if len(current) == 0 {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+				return
+			}
+			w.Write([]byte(current))
+		case http.MethodPatch:
+			if r.Header.Get("Content-Type") != string(types.ApplyPatchType) {
+				t.Errorf("Expected apply patch, got %s", r.Header.Get("Content-Type"))
+			}
+			*query = r.URL.RawQuery
+			w.Write([]byte(*patched))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := dynamic.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+func newProposedConfigMap(level string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "settings", "namespace": "default"},
+		"data":       map[string]interface{}{"level": level},
+	}}
+}
+
+func TestDiffResourceUpdate(t *testing.T) {
+	var query string
+	result := fmt.Sprintf(testConfigMap, "debug")
+	client := newDiffTestClient(t, fmt.Sprintf(testConfigMap, "info"), &result, &query)
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+	diff, err := diffResource(client, gvr, "default", newProposedConfigMap("debug"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `--- current/configmaps/settings
++++ proposed/configmaps/settings
+@@ -1,6 +1,6 @@
+ apiVersion: v1
+ data:
+-  level: info
++  level: debug
+   mode: fast
+ kind: ConfigMap
+ metadata:
+`
+	if diff != expected {
+		t.Errorf("Expected diff:\n%s\ngot:\n%s", expected, diff)
+	}
+
+	if query != "dryRun=All&fieldManager=dashboard" {
+		t.Errorf("Expected dry-run apply by dashboard field manager, got query %s", query)
+	}
+}
+
+func TestDiffResourceCreate(t *testing.T) {
+	var query string
+	result := `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings"}}`
+	client := newDiffTestClient(t, "", &result, &query)
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+	diff, err := diffResource(client, gvr, "default", newProposedConfigMap("debug"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `--- current/configmaps/settings
++++ proposed/configmaps/settings
+@@ -0,0 +1,4 @@
++apiVersion: v1
++kind: ConfigMap
++metadata:
++  name: settings
+`
+	if diff != expected {
+		t.Errorf("Expected diff:\n%s\ngot:\n%s", expected, diff)
+	}
+}
+
+func TestDiffResourceWithoutName(t *testing.T) {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	proposed := &unstructured.Unstructured{Object: map[string]interface{}{"kind": "ConfigMap"}}
+
+	if _, err := diffResource(nil, gvr, "default", proposed); !errors.IsBadRequest(err) {
+		t.Errorf("Expected bad request error, got %v", err)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	from := []string{"a\n", "b\n", "c\n", "d\n", "e\n", "f\n", "g\n", "h\n", "i\n", "j\n", "k\n", "l\n", "m\n"}
+	to := []string{"a\n", "B\n", "c\n", "d\n", "e\n", "f\n", "g\n", "h\n", "i\n", "j\n", "k\n", "l\n", "m\n",
+		"n\n"}
+
+	expected := `--- from
++++ to
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -11,3 +11,4 @@
+ k
+ l
+ m
++n
+`
+	if actual := unifiedDiff("from", "to", from, to); actual != expected {
+		t.Errorf("Expected diff:\n%s\ngot:\n%s", expected, actual)
+	}
+
+	if actual := unifiedDiff("from", "to", from, from); len(actual) != 0 {
+		t.Errorf("Expected no diff for equal lines, got:\n%s", actual)
+	}
+}
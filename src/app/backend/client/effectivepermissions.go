@@ -0,0 +1,274 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	rbac "k8s.io/api/rbac/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/emicklei/go-restful/v3"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+// EffectivePermissions resolves rules granted to the subject by all role bindings and cluster role bindings that
+// reference it, directly or through one of its groups, using credentials of the user. Rules are merged, so every
+// set of resources in a namespace is listed once with all granted verbs.
+func (self *clientManager) EffectivePermissions(req *restful.Request,
+	subject clientapi.Subject) ([]clientapi.PolicyRule, error) {
+	client, err := self.Client(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return effectivePermissions(client, subject)
+}
+
+func effectivePermissions(client kubernetes.Interface, subject clientapi.Subject) ([]clientapi.PolicyRule, error) {
+	if err := validateSubject(subject); err != nil {
+		return nil, err
+	}
+
+	resolver := &ruleResolver{client: client, clusterRoles: make(map[string][]rbac.PolicyRule)}
+	groups := subjectGroups(subject)
+	rules := newRuleSet()
+
+	clusterRoleBindings, err := client.RbacV1().ClusterRoleBindings().List(context.TODO(), metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, binding := range clusterRoleBindings.Items {
+		if !bindsSubject(binding.Subjects, "", subject, groups) {
+			continue
+		}
+
+		granted, err := resolver.rules(binding.RoleRef, "")
+		if err != nil {
+			return nil, err
+		}
+		rules.add("", granted)
+	}
+
+	roleBindings, err := client.RbacV1().RoleBindings(metaV1.NamespaceAll).List(context.TODO(), metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, binding := range roleBindings.Items {
+		if !bindsSubject(binding.Subjects, binding.Namespace, subject, groups) {
+			continue
+		}
+
+		granted, err := resolver.rules(binding.RoleRef, binding.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		rules.add(binding.Namespace, granted)
+	}
+
+	return rules.list(), nil
+}
+
+func validateSubject(subject clientapi.Subject) error {
+	if len(subject.Name) == 0 {
+		return errors.NewBadRequest("subject name is required")
+	}
+
+	switch subject.Kind {
+	case rbac.UserKind, rbac.GroupKind:
+		return nil
+	case rbac.ServiceAccountKind:
+		if len(subject.Namespace) == 0 {
+			return errors.NewBadRequest("service account subject requires a namespace")
+		}
+		return nil
+	default:
+		return errors.NewBadRequest("unsupported subject kind: " + subject.Kind)
+	}
+}
+
+// Returns groups the subject belongs to, including the ones assigned implicitly by the API server.
+func subjectGroups(subject clientapi.Subject) map[string]bool {
+	groups := make(map[string]bool)
+	for _, group := range subject.Groups {
+		groups[group] = true
+	}
+
+	switch subject.Kind {
+	case rbac.GroupKind:
+		groups[subject.Name] = true
+	case rbac.ServiceAccountKind:
+		groups["system:serviceaccounts"] = true
+		groups["system:serviceaccounts:"+subject.Namespace] = true
+		groups["system:authenticated"] = true
+	case rbac.UserKind:
+		groups["system:authenticated"] = true
+	}
+
+	return groups
+}
+
+// Checks if any of the binding subjects refers to the subject. Namespace of the binding is used for service
+// accounts that do not specify one.
+func bindsSubject(subjects []rbac.Subject, namespace string, subject clientapi.Subject, groups map[string]bool) bool {
+	for _, s := range subjects {
+		switch s.Kind {
+		case rbac.GroupKind:
+			if groups[s.Name] {
+				return true
+			}
+		case rbac.UserKind:
+			if subject.Kind == rbac.UserKind && s.Name == subject.Name {
+				return true
+			}
+			if subject.Kind == rbac.ServiceAccountKind &&
+				s.Name == "system:serviceaccount:"+subject.Namespace+":"+subject.Name {
+				return true
+			}
+		case rbac.ServiceAccountKind:
+			saNamespace := s.Namespace
+			if len(saNamespace) == 0 {
+				saNamespace = namespace
+			}
+			if subject.Kind == rbac.ServiceAccountKind && s.Name == subject.Name && saNamespace == subject.Namespace {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ruleResolver gets rules of the roles referenced by bindings. Cluster roles are cached, as they are usually
+// referenced by many bindings.
+type ruleResolver struct {
+	client       kubernetes.Interface
+	clusterRoles map[string][]rbac.PolicyRule
+}
+
+// Returns rules of the referenced role. Bindings that reference missing roles do not grant anything.
+func (self *ruleResolver) rules(ref rbac.RoleRef, namespace string) ([]rbac.PolicyRule, error) {
+	switch ref.Kind {
+	case "ClusterRole":
+		if rules, ok := self.clusterRoles[ref.Name]; ok {
+			return rules, nil
+		}
+
+		role, err := self.client.RbacV1().ClusterRoles().Get(context.TODO(), ref.Name, metaV1.GetOptions{})
+		if err != nil && !errors.IsNotFoundError(err) {
+			return nil, err
+		}
+
+		var rules []rbac.PolicyRule
+		if err == nil {
+			rules = role.Rules
+		}
+		self.clusterRoles[ref.Name] = rules
+		return rules, nil
+	case "Role":
+		role, err := self.client.RbacV1().Roles(namespace).Get(context.TODO(), ref.Name, metaV1.GetOptions{})
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return role.Rules, nil
+	default:
+		return nil, nil
+	}
+}
+
+// ruleSet merges rules that apply to the same resources in the same namespace by joining their verbs.
+type ruleSet struct {
+	rules map[string]*clientapi.PolicyRule
+}
+
+func newRuleSet() *ruleSet {
+	return &ruleSet{rules: make(map[string]*clientapi.PolicyRule)}
+}
+
+func (self *ruleSet) add(namespace string, rules []rbac.PolicyRule) {
+	for _, rule := range rules {
+		normalized := rbac.PolicyRule{
+			APIGroups:       sortedUnique(rule.APIGroups),
+			Resources:       sortedUnique(rule.Resources),
+			ResourceNames:   sortedUnique(rule.ResourceNames),
+			NonResourceURLs: sortedUnique(rule.NonResourceURLs),
+		}
+
+		key := ruleKey(namespace, normalized)
+		existing, ok := self.rules[key]
+		if !ok {
+			existing = &clientapi.PolicyRule{PolicyRule: normalized, Namespace: namespace}
+			self.rules[key] = existing
+		}
+		existing.Verbs = sortedUnique(append(existing.Verbs, rule.Verbs...))
+	}
+}
+
+// Returns merged rules. Cluster-wide rules go first, followed by the namespaced ones.
+func (self *ruleSet) list() []clientapi.PolicyRule {
+	keys := make([]string, 0, len(self.rules))
+	for key := range self.rules {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := self.rules[keys[i]], self.rules[keys[j]]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return keys[i] < keys[j]
+	})
+
+	result := make([]clientapi.PolicyRule, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, *self.rules[key])
+	}
+
+	return result
+}
+
+func ruleKey(namespace string, rule rbac.PolicyRule) string {
+	return strings.Join([]string{namespace, strings.Join(rule.APIGroups, ","), strings.Join(rule.Resources, ","),
+		strings.Join(rule.ResourceNames, ","), strings.Join(rule.NonResourceURLs, ",")}, "|")
+}
+
+func sortedUnique(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,140 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	rbac "k8s.io/api/rbac/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+func newPermissionsClient() *fake.Clientset {
+	return fake.NewSimpleClientset(
+		&rbac.ClusterRole{
+			ObjectMeta: metaV1.ObjectMeta{Name: "view"},
+			Rules: []rbac.PolicyRule{
+				{Verbs: []string{"get", "list"}, APIGroups: []string{""}, Resources: []string{"pods"}},
+			},
+		},
+		&rbac.ClusterRole{
+			ObjectMeta: metaV1.ObjectMeta{Name: "pod-deleter"},
+			Rules: []rbac.PolicyRule{
+				{Verbs: []string{"delete", "get"}, APIGroups: []string{""}, Resources: []string{"pods"}},
+			},
+		},
+		&rbac.Role{
+			ObjectMeta: metaV1.ObjectMeta{Name: "config", Namespace: "team"},
+			Rules: []rbac.PolicyRule{
+				{Verbs: []string{"update"}, APIGroups: []string{""}, Resources: []string{"configmaps"}},
+			},
+		},
+		&rbac.ClusterRoleBinding{
+			ObjectMeta: metaV1.ObjectMeta{Name: "developers-view"},
+			Subjects:   []rbac.Subject{{Kind: rbac.GroupKind, Name: "developers"}},
+			RoleRef:    rbac.RoleRef{Kind: "ClusterRole", Name: "view"},
+		},
+		&rbac.ClusterRoleBinding{
+			ObjectMeta: metaV1.ObjectMeta{Name: "alice-delete"},
+			Subjects:   []rbac.Subject{{Kind: rbac.UserKind, Name: "alice"}},
+			RoleRef:    rbac.RoleRef{Kind: "ClusterRole", Name: "pod-deleter"},
+		},
+		&rbac.ClusterRoleBinding{
+			ObjectMeta: metaV1.ObjectMeta{Name: "dangling"},
+			Subjects:   []rbac.Subject{{Kind: rbac.UserKind, Name: "alice"}},
+			RoleRef:    rbac.RoleRef{Kind: "ClusterRole", Name: "missing"},
+		},
+		&rbac.RoleBinding{
+			ObjectMeta: metaV1.ObjectMeta{Name: "config", Namespace: "team"},
+			Subjects: []rbac.Subject{
+				{Kind: rbac.UserKind, Name: "alice"},
+				{Kind: rbac.ServiceAccountKind, Name: "builder"},
+			},
+			RoleRef: rbac.RoleRef{Kind: "Role", Name: "config"},
+		},
+		&rbac.RoleBinding{
+			ObjectMeta: metaV1.ObjectMeta{Name: "view", Namespace: "team"},
+			Subjects:   []rbac.Subject{{Kind: rbac.GroupKind, Name: "system:serviceaccounts:team"}},
+			RoleRef:    rbac.RoleRef{Kind: "ClusterRole", Name: "view"},
+		},
+	)
+}
+
+func TestEffectivePermissions(t *testing.T) {
+	cases := []struct {
+		subject  clientapi.Subject
+		expected []clientapi.PolicyRule
+	}{
+		{
+			clientapi.Subject{Kind: rbac.UserKind, Name: "alice", Groups: []string{"developers"}},
+			[]clientapi.PolicyRule{
+				{PolicyRule: rbac.PolicyRule{Verbs: []string{"delete", "get", "list"}, APIGroups: []string{""},
+					Resources: []string{"pods"}}},
+				{PolicyRule: rbac.PolicyRule{Verbs: []string{"update"}, APIGroups: []string{""},
+					Resources: []string{"configmaps"}}, Namespace: "team"},
+			},
+		},
+		{
+			clientapi.Subject{Kind: rbac.GroupKind, Name: "developers"},
+			[]clientapi.PolicyRule{
+				{PolicyRule: rbac.PolicyRule{Verbs: []string{"get", "list"}, APIGroups: []string{""},
+					Resources: []string{"pods"}}},
+			},
+		},
+		{
+			clientapi.Subject{Kind: rbac.ServiceAccountKind, Name: "builder", Namespace: "team"},
+			[]clientapi.PolicyRule{
+				{PolicyRule: rbac.PolicyRule{Verbs: []string{"update"}, APIGroups: []string{""},
+					Resources: []string{"configmaps"}}, Namespace: "team"},
+				{PolicyRule: rbac.PolicyRule{Verbs: []string{"get", "list"}, APIGroups: []string{""},
+					Resources: []string{"pods"}}, Namespace: "team"},
+			},
+		},
+		{
+			clientapi.Subject{Kind: rbac.UserKind, Name: "bob"},
+			[]clientapi.PolicyRule{},
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := effectivePermissions(newPermissionsClient(), c.subject)
+		if err != nil {
+			t.Fatalf("Expected permissions of %s to be resolved, but got %v", c.subject.Name, err)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Expected permissions of %s to be %#v, but got %#v", c.subject.Name, c.expected, actual)
+		}
+	}
+}
+
+func TestEffectivePermissionsInvalidSubject(t *testing.T) {
+	subjects := []clientapi.Subject{
+		{Kind: rbac.UserKind},
+		{Kind: "Robot", Name: "r2"},
+		{Kind: rbac.ServiceAccountKind, Name: "builder"},
+	}
+
+	for _, subject := range subjects {
+		if _, err := effectivePermissions(newPermissionsClient(), subject); !errors.IsBadRequest(err) {
+			t.Errorf("Expected bad request for %#v, but got %v", subject, err)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+// Loads TLS configuration used to connect to the egress proxy that requires mutual TLS. Client certificate used for
+// the proxy connection is independent of the credentials used to authenticate with the apiserver. Returns nil config
+// if neither certificate nor CA were provided.
+func loadEgressProxyTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	if len(certFile) == 0 && len(keyFile) == 0 && len(caFile) == 0 {
+		return nil, nil
+	}
+
+	if (len(certFile) == 0) != (len(keyFile) == 0) {
+		return nil, fmt.Errorf("both egress proxy client certificate and key have to be provided")
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(certFile) > 0 {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load egress proxy client certificate: %s", err.Error())
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if len(caFile) > 0 {
+		ca, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read egress proxy CA: %s", err.Error())
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("could not parse egress proxy CA from %s", caFile)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+// Maximum number of proxied transports cached by the egress proxy. Once the limit is reached, the least recently used
+// transport is evicted and its idle connections are closed.
+const maxEgressProxyTransports = 64
+
+// egressProxy connects the transports to the HTTPS egress proxy using provided TLS config. HTTP transport would use
+// apiserver TLS config for the proxy connection, so the proxy is presented to the transport as a plain HTTP proxy
+// and the TLS connection to it is established by the dialer instead.
+//
+// Proxy and dial functions are not set on the rest configs, because client-go does not cache transports of such
+// configs. Instead, the transports cached by client-go are wrapped and their proxied copies are cached as well, so
+// that all clients created with the same TLS options share one connection pool.
+type egressProxy struct {
+	tlsConfig *tls.Config
+	mux       sync.Mutex
+	// Maps transports created by client-go to their proxied copies.
+	transports map[*http.Transport]*egressProxyTransport
+	// Incremented on every use of the transport to find the least recently used one.
+	uses uint64
+	// Maps addresses of the HTTPS proxies to their host names used for TLS server name verification.
+	proxyHosts sync.Map
+}
+
+// Returns nil egress proxy if TLS config is nil.
+func newEgressProxy(tlsConfig *tls.Config) *egressProxy {
+	if tlsConfig == nil {
+		return nil
+	}
+
+	return &egressProxy{tlsConfig: tlsConfig, transports: make(map[*http.Transport]*egressProxyTransport)}
+}
+
+type egressProxyTransport struct {
+	transport *http.Transport
+	lastUsed  uint64
+}
+
+// Configures rest config to connect to the HTTPS egress proxy. Does nothing if egress proxy is nil.
+func (self *egressProxy) configure(cfg *rest.Config) {
+	if self == nil {
+		return
+	}
+
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		transport, ok := rt.(*http.Transport)
+		if !ok {
+			return rt
+		}
+
+		return self.transportFor(transport)
+	})
+}
+
+// Returns proxied copy of the transport, creating it on first use.
+func (self *egressProxy) transportFor(base *http.Transport) *http.Transport {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	self.uses++
+	if entry, ok := self.transports[base]; ok {
+		entry.lastUsed = self.uses
+		return entry.transport
+	}
+
+	if len(self.transports) >= maxEgressProxyTransports {
+		self.evict()
+	}
+
+	transport := base.Clone()
+	transport.Proxy = self.proxy(base.Proxy)
+	transport.DialContext = self.dial(base.DialContext)
+	self.transports[base] = &egressProxyTransport{transport: transport, lastUsed: self.uses}
+	return transport
+}
+
+// Removes the least recently used transport and closes its idle connections. Clients that still use it keep working,
+// but their connections are no longer pooled with the new clients.
+func (self *egressProxy) evict() {
+	var oldestBase *http.Transport
+	var oldest *egressProxyTransport
+	for base, entry := range self.transports {
+		if oldest == nil || entry.lastUsed < oldest.lastUsed {
+			oldestBase, oldest = base, entry
+		}
+	}
+
+	if oldest != nil {
+		delete(self.transports, oldestBase)
+		oldest.transport.CloseIdleConnections()
+	}
+}
+
+func (self *egressProxy) proxy(proxy func(*http.Request) (*url.URL, error)) func(*http.Request) (*url.URL, error) {
+	if proxy == nil {
+		proxy = http.ProxyFromEnvironment
+	}
+
+	return func(req *http.Request) (*url.URL, error) {
+		proxyURL, err := proxy(req)
+		if err != nil || proxyURL == nil || proxyURL.Scheme != "https" {
+			return proxyURL, err
+		}
+
+		port := proxyURL.Port()
+		if len(port) == 0 {
+			port = "443"
+		}
+
+		result := *proxyURL
+		result.Scheme = "http"
+		result.Host = net.JoinHostPort(proxyURL.Hostname(), port)
+		self.proxyHosts.Store(result.Host, proxyURL.Hostname())
+		return &result, nil
+	}
+}
+
+func (self *egressProxy) dial(dial func(ctx context.Context, network, address string) (net.Conn, error)) func(
+	ctx context.Context, network, address string) (net.Conn, error) {
+	if dial == nil {
+		dial = (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}).DialContext
+	}
+
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		conn, err := dial(ctx, network, address)
+		if err != nil {
+			return nil, err
+		}
+
+		serverName, isProxy := self.proxyHosts.Load(address)
+		if !isProxy {
+			return conn, nil
+		}
+
+		proxyTLSConfig := self.tlsConfig.Clone()
+		if len(proxyTLSConfig.ServerName) == 0 {
+			proxyTLSConfig.ServerName = serverName.(string)
+		}
+
+		tlsConn := tls.Client(conn, proxyTLSConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		return tlsConn, nil
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/emicklei/go-restful/v3"
+	"k8s.io/client-go/rest"
+	certutil "k8s.io/client-go/util/cert"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+)
+
+func writeTestCertificate(t *testing.T) (certFile, keyFile string) {
+	cert, key, err := certutil.GenerateSelfSignedCertKey("127.0.0.1", []net.IP{net.ParseIP("127.0.0.1")}, nil)
+	if err != nil {
+		t.Fatalf("Could not generate certificate: %s", err.Error())
+	}
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "proxy.crt")
+	keyFile = filepath.Join(dir, "proxy.key")
+	if err = ioutil.WriteFile(certFile, cert, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = ioutil.WriteFile(keyFile, key, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
+
+func TestLoadEgressProxyTLSConfig(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t)
+
+	tlsConfig, err := loadEgressProxyTLSConfig(certFile, keyFile, certFile)
+	if err != nil {
+		t.Fatalf("loadEgressProxyTLSConfig(): unexpected error: %s", err.Error())
+	}
+
+	if len(tlsConfig.Certificates) != 1 || tlsConfig.RootCAs == nil {
+		t.Fatalf("loadEgressProxyTLSConfig(): expected client certificate and CA to be loaded, got %#v", tlsConfig)
+	}
+
+	if tlsConfig, err = loadEgressProxyTLSConfig("", "", ""); tlsConfig != nil || err != nil {
+		t.Fatalf("loadEgressProxyTLSConfig(): expected no config when files are not provided, got %v, %v",
+			tlsConfig, err)
+	}
+
+	if _, err = loadEgressProxyTLSConfig(certFile, "", ""); err == nil {
+		t.Fatal("loadEgressProxyTLSConfig(): expected error when key is missing")
+	}
+}
+
+func TestConfigureEgressProxy(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t)
+	tlsConfig, err := loadEgressProxyTLSConfig(certFile, keyFile, certFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serverCert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAnyClientCert,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	peerCertificates := make(chan int, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tlsConn := conn.(*tls.Conn)
+		if err := tlsConn.Handshake(); err != nil {
+			peerCertificates <- 0
+			return
+		}
+		peerCertificates <- len(tlsConn.ConnectionState().PeerCertificates)
+	}()
+
+	proxy := newEgressProxy(tlsConfig)
+	transport := proxy.transportFor(&http.Transport{
+		Proxy: func(*http.Request) (*url.URL, error) {
+			return &url.URL{Scheme: "https", Host: listener.Addr().String()}, nil
+		},
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "https://apiserver:6443/api", nil)
+	proxyURL, err := transport.Proxy(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if proxyURL.Scheme != "http" || proxyURL.Host != listener.Addr().String() {
+		t.Fatalf("Proxy(): expected proxy to be presented as plain HTTP proxy, got %s", proxyURL)
+	}
+
+	conn, err := transport.DialContext(context.Background(), "tcp", proxyURL.Host)
+	if err != nil {
+		t.Fatalf("Dial(): expected TLS connection to the proxy but got error: %s", err.Error())
+	}
+	defer conn.Close()
+
+	if _, ok := conn.(*tls.Conn); !ok {
+		t.Fatal("Dial(): expected TLS connection to the proxy")
+	}
+
+	if count := <-peerCertificates; count == 0 {
+		t.Fatal("Dial(): expected proxy client certificate to be presented to the proxy")
+	}
+}
+
+func TestEgressProxySharesTransport(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t)
+	args.GetHolderBuilder().SetEgressProxyClientCert(certFile).SetEgressProxyClientKey(keyFile)
+	defer func() { args.GetHolderBuilder().SetEgressProxyClientCert("").SetEgressProxyClientKey("") }()
+
+	manager := NewClientManager("", "http://localhost:8080").(*clientManager)
+	for _, token := range []string{"first-token", "second-token"} {
+		req := &restful.Request{Request: &http.Request{
+			Header: http.Header{"Authorization": {"Bearer " + token}},
+			TLS:    &tls.ConnectionState{},
+		}}
+
+		cfg, err := manager.Config(req)
+		if err != nil {
+			t.Fatalf("Config(): unexpected error: %s", err.Error())
+		}
+
+		if cfg.Proxy != nil || cfg.Dial != nil {
+			t.Fatal("Config(): expected proxy and dial functions not to be set, so that transport can be cached")
+		}
+
+		if _, err = rest.TransportFor(cfg); err != nil {
+			t.Fatalf("TransportFor(): unexpected error: %s", err.Error())
+		}
+	}
+
+	if count := len(manager.egressProxy.transports); count != 1 {
+		t.Fatalf("Expected clients to share one proxied transport, got %d", count)
+	}
+}
+
+func TestEgressProxyEvictsLeastRecentlyUsedTransport(t *testing.T) {
+	proxy := newEgressProxy(&tls.Config{})
+	bases := make([]*http.Transport, maxEgressProxyTransports+1)
+	for i := range bases {
+		bases[i] = &http.Transport{}
+	}
+
+	first := proxy.transportFor(bases[0])
+	for _, base := range bases[1:maxEgressProxyTransports] {
+		proxy.transportFor(base)
+	}
+
+	if proxy.transportFor(bases[0]) != first {
+		t.Fatal("Expected cached proxied transport to be reused")
+	}
+
+	proxy.transportFor(bases[maxEgressProxyTransports])
+	if count := len(proxy.transports); count != maxEgressProxyTransports {
+		t.Fatalf("Expected %d cached transports, got %d", maxEgressProxyTransports, count)
+	}
+
+	if _, ok := proxy.transports[bases[1]]; ok {
+		t.Error("Expected least recently used transport to be evicted")
+	}
+
+	if proxy.transportFor(bases[0]) != first {
+		t.Error("Expected recently used transport to stay cached")
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/event"
+)
+
+// ResourceEvents returns events of the object with given kind and name using credentials of the user. See
+// event.GetInvolvedObjectEvents for more information.
+func (self *clientManager) ResourceEvents(req *restful.Request, namespace, kind, name string) (*v1.EventList, error) {
+	client, err := self.Client(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return event.GetInvolvedObjectEvents(client, namespace, kind, name)
+}
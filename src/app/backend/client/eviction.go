@@ -0,0 +1,54 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+// EvictPod evicts the pod through the Eviction API using credentials of the user, so that its pod disruption budget
+// is respected. Nil grace period means that the default grace period of the pod is used. Evictions rejected by the
+// disruption budget are reported with the eviction blocked error.
+func (self *clientManager) EvictPod(req *restful.Request, namespace, name string, gracePeriod *int64) error {
+	client, err := self.Client(req)
+	if err != nil {
+		return err
+	}
+
+	return evictPod(client, namespace, name, gracePeriod)
+}
+
+func evictPod(client kubernetes.Interface, namespace, name string, gracePeriod *int64) error {
+	eviction := &policyv1.Eviction{
+		ObjectMeta:    metaV1.ObjectMeta{Namespace: namespace, Name: name},
+		DeleteOptions: &metaV1.DeleteOptions{GracePeriodSeconds: gracePeriod},
+	}
+
+	err := client.PolicyV1().Evictions(namespace).Evict(context.TODO(), eviction)
+	if k8serrors.IsTooManyRequests(err) {
+		return errors.NewEvictionBlocked(err.Error())
+	}
+
+	return err
+}
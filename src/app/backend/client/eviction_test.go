@@ -0,0 +1,72 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientTesting "k8s.io/client-go/testing"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+func TestEvictPod(t *testing.T) {
+	gracePeriod := int64(30)
+	cases := []struct {
+		info        string
+		err         error
+		wantBlocked bool
+		wantErr     bool
+	}{
+		{"should evict pod", nil, false, false},
+		{"should report eviction blocked by disruption budget",
+			k8serrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 10),
+			true, true},
+		{"should propagate other errors", k8serrors.NewNotFound(policyv1.Resource("pods"), "pod"), false, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.info, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			var eviction *policyv1.Eviction
+			client.PrependReactor("create", "pods", func(action clientTesting.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "eviction" {
+					return false, nil, nil
+				}
+
+				eviction = action.(clientTesting.CreateAction).GetObject().(*policyv1.Eviction)
+				return true, nil, c.err
+			})
+
+			err := evictPod(client, "default", "pod", &gracePeriod)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("Expected error: %v, got %v", c.wantErr, err)
+			}
+
+			if errors.IsEvictionBlocked(err) != c.wantBlocked || errors.IsThrottled(err) {
+				t.Errorf("Expected eviction blocked error: %v, got %v", c.wantBlocked, err)
+			}
+
+			if eviction == nil || eviction.Name != "pod" || eviction.Namespace != "default" ||
+				*eviction.DeleteOptions.GracePeriodSeconds != gracePeriod {
+				t.Errorf("Expected eviction of default/pod with grace period, got %+v", eviction)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// LastAppliedConfigAnnotation is set by 'kubectl apply' and contains the previously applied configuration.
+const LastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+var (
+	// exportedMetadataFields are metadata fields set by the apiserver that are removed from exported objects.
+	exportedMetadataFields = []string{"managedFields", "resourceVersion", "uid", "creationTimestamp", "generation",
+		"selfLink", "deletionTimestamp", "deletionGracePeriodSeconds"}
+
+	// exportedDefaultedFields are fields allocated or defaulted by the apiserver for specific resources. They are
+	// removed, so that exported object can be applied in another namespace or cluster.
+	exportedDefaultedFields = map[schema.GroupResource][][]string{
+		{Resource: "services"}: {
+			{"spec", "clusterIP"},
+			{"spec", "clusterIPs"},
+		},
+		{Resource: "persistentvolumeclaims"}: {
+			{"spec", "volumeName"},
+		},
+		{Group: "batch", Resource: "jobs"}: {
+			{"spec", "selector"},
+			{"spec", "template", "metadata", "labels", "controller-uid"},
+			{"spec", "template", "metadata", "labels", "batch.kubernetes.io/controller-uid"},
+		},
+	}
+)
+
+// ExportResourceYAML returns YAML of the object without status and fields managed by the apiserver, so that it can
+// be applied again, using credentials of the user.
+func (self *clientManager) ExportResourceYAML(req *restful.Request, gvr schema.GroupVersionResource, namespace,
+	name string) ([]byte, error) {
+	cfg, err := self.Config(req)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return exportResourceYAML(client, gvr, namespace, name)
+}
+
+func exportResourceYAML(client dynamic.Interface, gvr schema.GroupVersionResource, namespace,
+	name string) ([]byte, error) {
+	obj, err := client.Resource(gvr).Namespace(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	stripServerFields(obj, gvr.GroupResource())
+	return yaml.Marshal(obj.Object)
+}
+
+// Removes status, server-managed metadata and fields defaulted by the apiserver from the object.
+func stripServerFields(obj *unstructured.Unstructured, resource schema.GroupResource) {
+	unstructured.RemoveNestedField(obj.Object, "status")
+	for _, field := range exportedMetadataFields {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations[LastAppliedConfigAnnotation]; ok {
+		delete(annotations, LastAppliedConfigAnnotation)
+		if len(annotations) == 0 {
+			annotations = nil
+		}
+		obj.SetAnnotations(annotations)
+	}
+
+	for _, field := range exportedDefaultedFields[resource] {
+		unstructured.RemoveNestedField(obj.Object, field...)
+	}
+}
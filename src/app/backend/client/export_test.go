@@ -0,0 +1,85 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestExportResourceYAML(t *testing.T) {
+	service := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata": map[string]interface{}{
+			"name":              "web",
+			"namespace":         "default",
+			"uid":               "1234",
+			"resourceVersion":   "42",
+			"creationTimestamp": "2022-01-01T00:00:00Z",
+			"labels":            map[string]interface{}{"app": "web"},
+			"annotations": map[string]interface{}{
+				LastAppliedConfigAnnotation: `{"kind":"Service"}`,
+			},
+			"managedFields": []interface{}{map[string]interface{}{"manager": "kubectl"}},
+		},
+		"spec": map[string]interface{}{
+			"clusterIP":  "10.0.0.1",
+			"clusterIPs": []interface{}{"10.0.0.1"},
+			"selector":   map[string]interface{}{"app": "web"},
+			"ports":      []interface{}{map[string]interface{}{"port": int64(80)}},
+		},
+		"status": map[string]interface{}{"loadBalancer": map[string]interface{}{}},
+	}}
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "services"}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "ServiceList"}, service)
+
+	actual, err := exportResourceYAML(client, gvr, "default", "web")
+	if err != nil {
+		t.Fatalf("Expected object to be exported, but got %v", err)
+	}
+
+	expected := `apiVersion: v1
+kind: Service
+metadata:
+  labels:
+    app: web
+  name: web
+  namespace: default
+spec:
+  ports:
+  - port: 80
+  selector:
+    app: web
+`
+	if string(actual) != expected {
+		t.Errorf("Expected exported YAML:\n%s\nbut got:\n%s", expected, actual)
+	}
+}
+
+func TestExportResourceYAMLNotFound(t *testing.T) {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "services"}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "ServiceList"})
+
+	if _, err := exportResourceYAML(client, gvr, "default", "missing"); err == nil {
+		t.Fatal("Expected error for missing object")
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+const (
+	// FilterPresetsConfigMapName is a name of the config map in the dashboard namespace that holds label selector
+	// presets. Every key is a name of the preset and its value is the label selector, i.e. 'app=frontend,tier!=db'.
+	FilterPresetsConfigMapName = "kubernetes-dashboard-filter-presets"
+)
+
+// FilterPresets returns named label selector presets configured by the administrator. Presets are part of the
+// dashboard configuration, so they are read using the dashboard service account once the user is authenticated.
+func (self *clientManager) FilterPresets(req *restful.Request) ([]clientapi.FilterPreset, error) {
+	if _, err := self.Client(req); err != nil {
+		return nil, err
+	}
+
+	return filterPresets(self.InsecureClient(), args.Holder.GetNamespace())
+}
+
+// Reads presets from the config map in given namespace. Presets with empty or invalid label selector are skipped, so
+// that a single typo does not hide all of them. Missing config map means that no presets are configured.
+func filterPresets(client kubernetes.Interface, namespace string) ([]clientapi.FilterPreset, error) {
+	configMap, err := client.CoreV1().ConfigMaps(namespace).Get(context.TODO(), FilterPresetsConfigMapName,
+		metaV1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return []clientapi.FilterPreset{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	presets := make([]clientapi.FilterPreset, 0, len(configMap.Data))
+	for name, value := range configMap.Data {
+		selector, err := labels.Parse(value)
+		if err != nil {
+			log.Printf("Skipping filter preset %s with invalid label selector: %s", name, err.Error())
+			continue
+		}
+
+		if selector.Empty() {
+			log.Printf("Skipping filter preset %s with empty label selector", name)
+			continue
+		}
+
+		presets = append(presets, clientapi.FilterPreset{Name: name, LabelSelector: selector.String()})
+	}
+
+	sort.Slice(presets, func(i, j int) bool { return presets[i].Name < presets[j].Name })
+	return presets, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+func TestFilterPresets(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{Name: FilterPresetsConfigMapName, Namespace: "kubernetes-dashboard"},
+		Data: map[string]string{
+			"frontend":  "app=frontend",
+			"backend":   "tier in (api, db),env!=dev",
+			"has-owner": "owner",
+			"invalid":   "app in (a",
+			"bad-value": "app=not a value",
+			"empty":     "",
+		},
+	})
+
+	presets, err := filterPresets(client, "kubernetes-dashboard")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []clientapi.FilterPreset{
+		{Name: "backend", LabelSelector: "env!=dev,tier in (api,db)"},
+		{Name: "frontend", LabelSelector: "app=frontend"},
+		{Name: "has-owner", LabelSelector: "owner"},
+	}
+	if !reflect.DeepEqual(presets, expected) {
+		t.Errorf("filterPresets() == %+v, expected %+v", presets, expected)
+	}
+}
+
+func TestFilterPresetsMissingConfigMap(t *testing.T) {
+	presets, err := filterPresets(fake.NewSimpleClientset(), "kubernetes-dashboard")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if presets == nil || len(presets) != 0 {
+		t.Errorf("Expected empty list of presets, got %+v", presets)
+	}
+}
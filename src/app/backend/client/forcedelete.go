@@ -0,0 +1,122 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	v1 "k8s.io/api/authorization/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+// Removes all finalizers of the object.
+var removeFinalizersPatch = []byte(`{"metadata":{"finalizers":null}}`)
+
+// ForceDelete removes finalizers of the object and deletes it with zero grace period using credentials of the user.
+// Controllers owning the finalizers do not get a chance to clean up, so it is allowed only when the
+// 'enable-force-delete' argument is set and user is allowed to both patch and delete the object. Every attempt is
+// logged together with the user identifier and its outcome.
+func (self *clientManager) ForceDelete(req *restful.Request, gvr schema.GroupVersionResource, namespace,
+	name string) error {
+	err := self.forceDelete(req, gvr, namespace, name)
+
+	outcome := "succeeded"
+	if err != nil {
+		outcome = "failed: " + err.Error()
+	}
+
+	log.Printf("AUDIT: Force delete of %s %s/%s requested by user %s (remote address %q) %s",
+		gvr.GroupResource().String(), namespace, name, auditUser(self.userCacheKey(req)), req.Request.RemoteAddr,
+		outcome)
+	return err
+}
+
+func (self *clientManager) forceDelete(req *restful.Request, gvr schema.GroupVersionResource, namespace,
+	name string) error {
+	if !args.Holder.GetEnableForceDelete() {
+		return errors.NewForbidden("force delete is disabled, it can be enabled with 'enable-force-delete' argument")
+	}
+
+	client, err := self.Client(req)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := self.Config(req)
+	if err != nil {
+		return err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	return forceDelete(client, dynamicClient, gvr, namespace, name)
+}
+
+// Finalizers are removed first, as deleting with zero grace period does not skip them. Object removed right after
+// finalizers were patched away is treated as deleted.
+func forceDelete(client kubernetes.Interface, dynamicClient dynamic.Interface, gvr schema.GroupVersionResource,
+	namespace, name string) error {
+	permissions := []v1.ResourceAttributes{
+		{Verb: "patch", Group: gvr.Group, Resource: gvr.Resource, Namespace: namespace, Name: name},
+		{Verb: "delete", Group: gvr.Group, Resource: gvr.Resource, Namespace: namespace, Name: name},
+	}
+	if missing := MissingPermissions(client, permissions); len(missing) > 0 {
+		return errors.NewForbidden(fmt.Sprintf("not allowed to %s %s %s/%s", missing[0].Verb, gvr.Resource,
+			namespace, name))
+	}
+
+	resource := dynamicClient.Resource(gvr).Namespace(namespace)
+	if _, err := resource.Patch(context.TODO(), name, types.MergePatchType, removeFinalizersPatch,
+		metaV1.PatchOptions{}); err != nil {
+		return err
+	}
+
+	gracePeriod := int64(0)
+	err := resource.Delete(context.TODO(), name, metaV1.DeleteOptions{GracePeriodSeconds: &gracePeriod})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+// Returns a short, stable identifier of the user that can be correlated between audit records without logging
+// credentials.
+func auditUser(cacheKey string) string {
+	if len(cacheKey) == 0 {
+		return "<dashboard service account>"
+	}
+
+	if len(cacheKey) > 12 {
+		return cacheKey[:12]
+	}
+
+	return cacheKey
+}
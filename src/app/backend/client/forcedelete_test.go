@@ -0,0 +1,95 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	restful "github.com/emicklei/go-restful/v3"
+	v1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/rest"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+func TestForceDelete(t *testing.T) {
+	requests := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, fmt.Sprintf("%s %s %s %s", r.Method, r.URL.Path, r.Header.Get("Content-Type"),
+			strings.TrimSpace(string(body))))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"apiVersion":"example.com/v1","kind":"Widget","metadata":{"name":"stuck","namespace":"default"}}`))
+	}))
+	defer server.Close()
+
+	dynamicClient, err := dynamic.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := forceDelete(newAccessReviewClient(), dynamicClient, widgetsGVR, "default", "stuck"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	path := "/apis/example.com/v1/namespaces/default/widgets/stuck"
+	expected := []string{
+		"PATCH " + path + " application/merge-patch+json " + `{"metadata":{"finalizers":null}}`,
+		"DELETE " + path + " application/json " + `{"kind":"DeleteOptions","apiVersion":"v1","gracePeriodSeconds":0}`,
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Expected finalizers to be patched before deletion %v, got %v", expected, requests)
+	}
+}
+
+func TestForceDeleteForbidden(t *testing.T) {
+	widget := newCustomResource("Widget", "default", "stuck", nil)
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), widget)
+	client := newAccessReviewClient(v1.ResourceAttributes{Verb: "delete", Group: "example.com", Resource: "widgets",
+		Namespace: "default", Name: "stuck"})
+
+	err := forceDelete(client, dynamicClient, widgetsGVR, "default", "stuck")
+	if !errors.IsForbiddenError(err) {
+		t.Fatalf("Expected forbidden error, got %v", err)
+	}
+
+	if len(dynamicClient.Actions()) != 0 {
+		t.Errorf("Expected object not to be touched, got %v", dynamicClient.Actions())
+	}
+}
+
+func TestForceDeleteDisabled(t *testing.T) {
+	manager := NewClientManager("", "http://localhost:8080")
+	req := &restful.Request{Request: &http.Request{
+		Header: http.Header{"Authorization": {"Bearer test-token"}},
+		TLS:    &tls.ConnectionState{},
+	}}
+
+	args.GetHolderBuilder().SetEnableForceDelete(false)
+	if err := manager.ForceDelete(req, widgetsGVR, "default", "stuck"); !errors.IsForbiddenError(err) {
+		t.Errorf("Expected force delete to be rejected when disabled, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"net/http"
+	"strings"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// Headers that carry credentials or identity and are never propagated to the apiserver even if allowed.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"Impersonate-User",
+	"Impersonate-Group",
+	"Impersonate-Uid",
+	ImpersonateUserExtraHeader,
+	"X-Remote-User",
+	"X-Remote-Group",
+	"X-Remote-Extra-",
+	ImpersonationAssertionHeader,
+}
+
+// Configures transport of the given config to copy allowed headers of the incoming request onto every apiserver
+// request made with it.
+func configureHeaderPropagation(req *restful.Request, cfg *rest.Config, allowed []string) {
+	headers := propagatedHeaders(req.Request.Header, allowed)
+	if len(headers) == 0 {
+		return
+	}
+
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &headerPropagatingRoundTripper{delegate: rt, headers: headers}
+	})
+}
+
+// Returns values of the allowed headers that are not sensitive.
+func propagatedHeaders(header http.Header, allowed []string) http.Header {
+	result := http.Header{}
+	for _, name := range allowed {
+		name = http.CanonicalHeaderKey(strings.TrimSpace(name))
+		if len(name) == 0 || isSensitiveHeader(name) {
+			continue
+		}
+
+		if values := header.Values(name); len(values) > 0 {
+			result[name] = append([]string{}, values...)
+		}
+	}
+
+	return result
+}
+
+func isSensitiveHeader(name string) bool {
+	if strings.EqualFold(name, GetJWETokenHeader()) {
+		return true
+	}
+
+	for _, sensitive := range sensitiveHeaders {
+		sensitive = http.CanonicalHeaderKey(sensitive)
+		if name == sensitive || (strings.HasSuffix(sensitive, "-") && strings.HasPrefix(name, sensitive)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// headerPropagatingRoundTripper sets propagated headers on the outgoing requests.
+type headerPropagatingRoundTripper struct {
+	delegate http.RoundTripper
+	headers  http.Header
+}
+
+// RoundTrip implements http.RoundTripper.
+func (self *headerPropagatingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Requests must not be modified by the round trippers, clone it before setting headers.
+	req = req.Clone(req.Context())
+	for name, values := range self.headers {
+		req.Header[name] = values
+	}
+
+	return self.delegate.RoundTrip(req)
+}
+
+// WrappedRoundTripper allows client-go to reach the underlying transport, i.e. to close idle connections.
+func (self *headerPropagatingRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return self.delegate
+}
@@ -0,0 +1,89 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/emicklei/go-restful/v3"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+)
+
+func TestHeaderPropagation(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"major":"1","minor":"24"}`))
+	}))
+	defer server.Close()
+
+	args.GetHolderBuilder().SetPropagatedRequestHeaders([]string{"X-Tenant-ID", "traceparent", "Cookie",
+		"Impersonate-Extra-Scopes", "X-Missing"})
+	defer args.GetHolderBuilder().SetPropagatedRequestHeaders([]string{})
+
+	manager := NewClientManager("", server.URL)
+	request := &restful.Request{Request: &http.Request{Header: http.Header{
+		"X-Tenant-Id":              {"tenant-a"},
+		"Traceparent":              {"00-trace-span-01"},
+		"Cookie":                   {"session=secret"},
+		"Impersonate-Extra-Scopes": {"admin"},
+		"X-Other":                  {"value"},
+	}}}
+
+	cfg, err := manager.Config(request)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := client.Discovery().ServerVersion(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for name, expected := range map[string]string{"X-Tenant-Id": "tenant-a", "Traceparent": "00-trace-span-01"} {
+		if actual := received.Get(name); actual != expected {
+			t.Errorf("Expected header %s to be propagated with value %s, but got %s", name, expected, actual)
+		}
+	}
+
+	for _, name := range []string{"Cookie", "Impersonate-Extra-Scopes", "X-Other", "X-Missing"} {
+		if _, ok := received[name]; ok {
+			t.Errorf("Expected header %s not to be propagated", name)
+		}
+	}
+
+	rt := manager.(*clientManager).InsecureConfig().WrapTransport(http.DefaultTransport)
+	for rt != nil {
+		if _, ok := rt.(*headerPropagatingRoundTripper); ok {
+			t.Fatal("Expected shared insecure config not to propagate headers")
+		}
+
+		wrapper, ok := rt.(utilnet.RoundTripperWrapper)
+		if !ok {
+			break
+		}
+		rt = wrapper.WrappedRoundTripper()
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+const (
+	// Header that contains impersonation assertion signed by the trusted proxy.
+	ImpersonationAssertionHeader = "X-Impersonation-Assertion"
+	// Maximum age of the impersonation assertion. Older assertions are rejected to prevent replay.
+	ImpersonationAssertionMaxAge = 5 * time.Minute
+)
+
+// ImpersonationAssertion contains user that should be impersonated. It is sent in ImpersonationAssertionHeader as
+// '<base64url encoded JSON>.<base64url encoded HMAC-SHA256 of the encoded JSON>'.
+type ImpersonationAssertion struct {
+	User   string   `json:"user"`
+	Groups []string `json:"groups,omitempty"`
+	// Unix time in seconds when the assertion was created.
+	Timestamp int64 `json:"timestamp"`
+}
+
+// SignImpersonationAssertion returns value of ImpersonationAssertionHeader signed with the given secret.
+func SignImpersonationAssertion(secret []byte, assertion ImpersonationAssertion) (string, error) {
+	payload, err := json.Marshal(assertion)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(signAssertion(secret, encoded)), nil
+}
+
+// Verifies signature and age of the assertion and returns it.
+func verifyImpersonationAssertion(secret []byte, value string, now time.Time) (*ImpersonationAssertion, error) {
+	parts := strings.Split(value, ".")
+	if len(parts) != 2 {
+		return nil, errors.NewUnauthorized("malformed impersonation assertion")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, signAssertion(secret, parts[0])) {
+		return nil, errors.NewUnauthorized("invalid impersonation assertion signature")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, errors.NewUnauthorized("malformed impersonation assertion")
+	}
+
+	assertion := &ImpersonationAssertion{}
+	if err := json.Unmarshal(payload, assertion); err != nil || len(assertion.User) == 0 {
+		return nil, errors.NewUnauthorized("malformed impersonation assertion")
+	}
+
+	// Small clock skew between the proxy and the dashboard is tolerated for assertions created in the future.
+	age := now.Sub(time.Unix(assertion.Timestamp, 0))
+	if age > ImpersonationAssertionMaxAge || age < -ImpersonationAssertionMaxAge {
+		return nil, errors.NewUnauthorized("impersonation assertion has expired")
+	}
+
+	return assertion, nil
+}
+
+func signAssertion(secret []byte, payload string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,106 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+func TestImpersonationAssertion(t *testing.T) {
+	secret := "shared-secret"
+	args.GetHolderBuilder().SetImpersonationAssertionSecret(secret)
+	defer args.GetHolderBuilder().SetImpersonationAssertionSecret("")
+
+	sign := func(secret string, assertion ImpersonationAssertion) string {
+		value, err := SignImpersonationAssertion([]byte(secret), assertion)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return value
+	}
+
+	now := time.Now()
+	valid := sign(secret, ImpersonationAssertion{User: "alice", Groups: []string{"dev"}, Timestamp: now.Unix()})
+	expired := sign(secret, ImpersonationAssertion{User: "alice",
+		Timestamp: now.Add(-2 * ImpersonationAssertionMaxAge).Unix()})
+	wrongSecret := sign("other-secret", ImpersonationAssertion{User: "alice", Timestamp: now.Unix()})
+	forged := sign(secret, ImpersonationAssertion{User: "admin", Timestamp: now.Unix()})
+	tampered := strings.Split(forged, ".")[0] + "." + strings.Split(valid, ".")[1]
+
+	cases := []struct {
+		info           string
+		headers        map[string]string
+		expectedUser   string
+		expectedGroups []string
+		check          func(error) bool
+	}{
+		{"valid assertion", map[string]string{ImpersonationAssertionHeader: valid}, "alice", []string{"dev"},
+			func(err error) bool { return err == nil }},
+		{"expired assertion", map[string]string{ImpersonationAssertionHeader: expired}, "", nil,
+			errors.IsUnauthorized},
+		{"assertion signed with other secret", map[string]string{ImpersonationAssertionHeader: wrongSecret}, "",
+			nil, errors.IsUnauthorized},
+		{"tampered assertion", map[string]string{ImpersonationAssertionHeader: tampered}, "", nil,
+			errors.IsUnauthorized},
+		{"malformed assertion", map[string]string{ImpersonationAssertionHeader: "garbage"}, "", nil,
+			errors.IsUnauthorized},
+		{"raw impersonation header", map[string]string{"Impersonate-User": "admin"}, "", nil,
+			errors.IsBadRequest},
+		{"no impersonation", map[string]string{}, "", nil, func(err error) bool { return err == nil }},
+	}
+
+	for _, c := range cases {
+		header := http.Header{"Authorization": {"Bearer proxy-token"}}
+		for name, value := range c.headers {
+			header.Set(name, value)
+		}
+
+		manager := &clientManager{}
+		authInfo, err := manager.extractAuthInfo(&restful.Request{Request: &http.Request{Header: header}})
+		if !c.check(err) {
+			t.Errorf("%s: unexpected error %v", c.info, err)
+			continue
+		}
+
+		if err != nil {
+			continue
+		}
+
+		if authInfo.Token != "proxy-token" || authInfo.Impersonate != c.expectedUser ||
+			!reflect.DeepEqual(authInfo.ImpersonateGroups, c.expectedGroups) {
+			t.Errorf("%s: unexpected auth info %#v", c.info, authInfo)
+		}
+	}
+}
+
+func TestImpersonationAssertionDisabled(t *testing.T) {
+	value, _ := SignImpersonationAssertion([]byte("secret"), ImpersonationAssertion{User: "alice",
+		Timestamp: time.Now().Unix()})
+	header := http.Header{"Authorization": {"Bearer proxy-token"}, ImpersonationAssertionHeader: {value}}
+
+	manager := &clientManager{}
+	if _, err := manager.extractAuthInfo(&restful.Request{Request: &http.Request{Header: header}}); !errors.IsBadRequest(err) {
+		t.Fatalf("Expected bad request error when assertions are not enabled, but got %v", err)
+	}
+}
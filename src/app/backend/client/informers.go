@@ -0,0 +1,132 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"sync"
+
+	"github.com/emicklei/go-restful/v3"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+// InformerFactory returns shared informer factory scoped to the given namespace that uses credentials of the user, or
+// insecure client if request does not contain auth info. Factories are shared between requests of the same user and
+// their informers are stopped once released by all callers. Number of active factories can be limited with
+// 'max-informer-factories' argument. Overrides passed in request headers, i.e. timeout, are not applied to the
+// factory, as later requests would inherit them.
+func (self *clientManager) InformerFactory(req *restful.Request, namespace string) (clientapi.InformerFactory, error) {
+	key := self.userCacheKey(req) + "/" + namespace
+	return self.informerFactories.acquire(key, args.Holder.GetMaxInformerFactories(), func() (
+		informers.SharedInformerFactory, error) {
+		client, err := self.authClient(req)
+		if err != nil {
+			return nil, err
+		}
+
+		return newNamespacedInformerFactory(client, namespace), nil
+	})
+}
+
+func newNamespacedInformerFactory(client kubernetes.Interface, namespace string) informers.SharedInformerFactory {
+	return informers.NewSharedInformerFactoryWithOptions(client, 0, informers.WithNamespace(namespace))
+}
+
+// informerFactoryCache keeps track of the active informer factories and the number of their users.
+type informerFactoryCache struct {
+	mux     sync.Mutex
+	entries map[string]*informerFactoryEntry
+}
+
+type informerFactoryEntry struct {
+	factory informers.SharedInformerFactory
+	stopCh  chan struct{}
+	refs    int
+}
+
+func newInformerFactoryCache() *informerFactoryCache {
+	return &informerFactoryCache{entries: make(map[string]*informerFactoryEntry)}
+}
+
+// Returns factory stored under the given key or creates new one. Limit lower than 1 means that the number of active
+// factories is not limited.
+func (self *informerFactoryCache) acquire(key string, limit int,
+	create func() (informers.SharedInformerFactory, error)) (clientapi.InformerFactory, error) {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	entry, exists := self.entries[key]
+	if !exists {
+		if limit > 0 && len(self.entries) >= limit {
+			return nil, errors.NewTooManyRequests("too many active informer factories")
+		}
+
+		factory, err := create()
+		if err != nil {
+			return nil, err
+		}
+
+		entry = &informerFactoryEntry{factory: factory, stopCh: make(chan struct{})}
+		self.entries[key] = entry
+	}
+
+	entry.refs++
+	return &informerFactoryHandle{cache: self, key: key, entry: entry}, nil
+}
+
+// Stops informers of the factory when it is no longer used by anyone.
+func (self *informerFactoryCache) release(key string, entry *informerFactoryEntry) {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	entry.refs--
+	if entry.refs > 0 {
+		return
+	}
+
+	close(entry.stopCh)
+	if self.entries[key] == entry {
+		delete(self.entries, key)
+	}
+}
+
+// informerFactoryHandle implements InformerFactory interface.
+type informerFactoryHandle struct {
+	cache       *informerFactoryCache
+	key         string
+	entry       *informerFactoryEntry
+	releaseOnce sync.Once
+}
+
+// Factory implements InformerFactory interface.
+func (self *informerFactoryHandle) Factory() informers.SharedInformerFactory {
+	return self.entry.factory
+}
+
+// Start implements InformerFactory interface.
+func (self *informerFactoryHandle) Start() {
+	self.entry.factory.Start(self.entry.stopCh)
+}
+
+// Release implements InformerFactory interface.
+func (self *informerFactoryHandle) Release() {
+	self.releaseOnce.Do(func() {
+		self.cache.release(self.key, self.entry)
+	})
+}
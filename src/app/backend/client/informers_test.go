@@ -0,0 +1,95 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestInformerFactoryCache(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "pod-1", Namespace: "default"}},
+		&v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "pod-2", Namespace: "kube-system"}},
+	)
+	created := 0
+	create := func() (informers.SharedInformerFactory, error) {
+		created++
+		return newNamespacedInformerFactory(client, "default"), nil
+	}
+
+	factoryCache := newInformerFactoryCache()
+	first, err := factoryCache.acquire("user/default", 1, create)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	second, err := factoryCache.acquire("user/default", 1, create)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if created != 1 || first.Factory() != second.Factory() {
+		t.Errorf("Expected factory to be shared, created %d factories", created)
+	}
+
+	if _, err := factoryCache.acquire("user/kube-system", 1, create); !k8serrors.IsTooManyRequests(err) {
+		t.Errorf("Expected too many requests error when limit is reached, got %v", err)
+	}
+
+	informer := first.Factory().Core().V1().Pods().Informer()
+	first.Start()
+	timeout := make(chan struct{})
+	timer := time.AfterFunc(5*time.Second, func() { close(timeout) })
+	defer timer.Stop()
+	if !cache.WaitForCacheSync(timeout, informer.HasSynced) {
+		t.Fatal("Expected informer to sync")
+	}
+
+	if pods := informer.GetStore().List(); len(pods) != 1 {
+		t.Errorf("Expected informer to list pods from a single namespace, got %d pods", len(pods))
+	}
+
+	entry := factoryCache.entries["user/default"]
+	first.Release()
+	first.Release()
+	select {
+	case <-entry.stopCh:
+		t.Fatal("Expected informers to run while factory is used")
+	default:
+	}
+
+	second.Release()
+	select {
+	case <-entry.stopCh:
+	default:
+		t.Fatal("Expected informers to be stopped when factory is released")
+	}
+
+	if len(factoryCache.entries) != 0 {
+		t.Errorf("Expected released factory to be removed, got %d factories", len(factoryCache.entries))
+	}
+
+	if _, err := factoryCache.acquire("user/kube-system", 1, create); err != nil {
+		t.Errorf("Expected factory to be created after release, got %v", err)
+	}
+}
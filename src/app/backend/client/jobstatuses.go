@@ -0,0 +1,112 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"sort"
+
+	batch "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/emicklei/go-restful/v3"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/job"
+)
+
+// defaultJobBackoffLimit is used by the job controller when backoff limit is not set.
+const defaultJobBackoffLimit = 6
+
+// JobStatuses returns summaries of the jobs in the namespace sorted by name using credentials of the user. Empty
+// namespace means all namespaces.
+func (self *clientManager) JobStatuses(req *restful.Request, namespace string) ([]clientapi.JobStatus, error) {
+	client, err := self.Client(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobStatuses(client, namespace)
+}
+
+func jobStatuses(client kubernetes.Interface, namespace string) ([]clientapi.JobStatus, error) {
+	list, err := client.BatchV1().Jobs(namespace).List(context.TODO(), metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]clientapi.JobStatus, 0, len(list.Items))
+	for i := range list.Items {
+		result = append(result, toJobStatus(&list.Items[i]))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+func toJobStatus(j *batch.Job) clientapi.JobStatus {
+	status := clientapi.JobStatus{
+		Name:           j.Name,
+		Namespace:      j.Namespace,
+		Status:         string(job.JobStatusRunning),
+		Active:         j.Status.Active,
+		Succeeded:      j.Status.Succeeded,
+		Failed:         j.Status.Failed,
+		Completions:    j.Spec.Completions,
+		CompletionMode: string(batch.NonIndexedCompletion),
+		BackoffLimit:   defaultJobBackoffLimit,
+	}
+
+	if j.Spec.CompletionMode != nil {
+		status.CompletionMode = string(*j.Spec.CompletionMode)
+	}
+
+	if j.Spec.BackoffLimit != nil {
+		status.BackoffLimit = *j.Spec.BackoffLimit
+	}
+
+	for _, condition := range j.Status.Conditions {
+		if condition.Status != v1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batch.JobComplete:
+			status.Status = string(job.JobStatusComplete)
+		case batch.JobFailed:
+			status.Status = string(job.JobStatusFailed)
+			status.BackoffLimitExceeded = condition.Reason == "BackoffLimitExceeded"
+		}
+	}
+
+	// Job controller sets the condition only after the last pod is gone, so failed pods are checked as well.
+	if status.Failed > status.BackoffLimit {
+		status.BackoffLimitExceeded = true
+	}
+
+	if owner := metaV1.GetControllerOf(j); owner != nil && owner.Kind == "CronJob" {
+		status.CronJob = owner.Name
+	}
+
+	return status
+}
@@ -0,0 +1,75 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	batch "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+func TestJobStatuses(t *testing.T) {
+	isController := true
+	indexed := batch.IndexedCompletion
+	client := fake.NewSimpleClientset(
+		&batch.Job{
+			ObjectMeta: metaV1.ObjectMeta{Name: "backup-123", Namespace: "default",
+				OwnerReferences: []metaV1.OwnerReference{
+					{Kind: "CronJob", Name: "backup", Controller: &isController},
+				}},
+			Spec: batch.JobSpec{Completions: int32Ptr(1)},
+			Status: batch.JobStatus{Succeeded: 1, Conditions: []batch.JobCondition{
+				{Type: batch.JobComplete, Status: v1.ConditionTrue},
+			}},
+		},
+		&batch.Job{
+			ObjectMeta: metaV1.ObjectMeta{Name: "migrate", Namespace: "default"},
+			Spec:       batch.JobSpec{BackoffLimit: int32Ptr(2)},
+			Status: batch.JobStatus{Failed: 3, Conditions: []batch.JobCondition{
+				{Type: batch.JobFailed, Status: v1.ConditionTrue, Reason: "BackoffLimitExceeded"},
+			}},
+		},
+		&batch.Job{
+			ObjectMeta: metaV1.ObjectMeta{Name: "render", Namespace: "default"},
+			Spec:       batch.JobSpec{Completions: int32Ptr(5), CompletionMode: &indexed},
+			Status:     batch.JobStatus{Active: 2, Succeeded: 1, Failed: 1},
+		},
+		&batch.Job{ObjectMeta: metaV1.ObjectMeta{Name: "other", Namespace: "kube-system"}},
+	)
+
+	actual, err := jobStatuses(client, "default")
+	if err != nil {
+		t.Fatalf("Expected job statuses, but got %v", err)
+	}
+
+	expected := []clientapi.JobStatus{
+		{Name: "backup-123", Namespace: "default", Status: "Complete", Succeeded: 1, Completions: int32Ptr(1),
+			CompletionMode: "NonIndexed", BackoffLimit: 6, CronJob: "backup"},
+		{Name: "migrate", Namespace: "default", Status: "Failed", Failed: 3, CompletionMode: "NonIndexed",
+			BackoffLimit: 2, BackoffLimitExceeded: true},
+		{Name: "render", Namespace: "default", Status: "Running", Active: 2, Succeeded: 1, Failed: 1,
+			Completions: int32Ptr(5), CompletionMode: "Indexed", BackoffLimit: 6},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected job statuses %#v, but got %#v", expected, actual)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"os"
+
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+// ExportKubeConfig returns kubeconfig file of the user that made the request. Export has to be enabled with
+// 'enable-kubeconfig-export' argument. Token of the user is included only if 'kubeconfig-export-include-token'
+// argument is set, other credentials and paths to files local to the dashboard are never exported.
+func (self *clientManager) ExportKubeConfig(req *restful.Request) ([]byte, error) {
+	if !args.Holder.GetEnableKubeConfigExport() {
+		return nil, errors.NewForbidden("kubeconfig export is disabled")
+	}
+
+	cmdCfg, err := self.ClientCmdConfig(req)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := cmdCfg.RawConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return exportKubeConfig(config, args.Holder.GetKubeConfigExportIncludeToken())
+}
+
+func exportKubeConfig(config api.Config, includeToken bool) ([]byte, error) {
+	exported := config.DeepCopy()
+	for _, cluster := range exported.Clusters {
+		// Certificate authority file is stored on the dashboard host, so its content is inlined instead.
+		if len(cluster.CertificateAuthority) > 0 && len(cluster.CertificateAuthorityData) == 0 {
+			if data, err := os.ReadFile(cluster.CertificateAuthority); err == nil {
+				cluster.CertificateAuthorityData = data
+			}
+		}
+		cluster.CertificateAuthority = ""
+	}
+
+	for name, authInfo := range exported.AuthInfos {
+		result := api.NewAuthInfo()
+		if authInfo != nil {
+			result.Impersonate = authInfo.Impersonate
+			result.ImpersonateGroups = authInfo.ImpersonateGroups
+			result.ImpersonateUserExtra = authInfo.ImpersonateUserExtra
+			if includeToken {
+				result.Token = authInfo.Token
+			}
+		}
+		exported.AuthInfos[name] = result
+	}
+
+	return clientcmd.Write(*exported)
+}
@@ -0,0 +1,106 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"crypto/tls"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	restful "github.com/emicklei/go-restful/v3"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+func TestExportKubeConfig(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	if err := os.WriteFile(caFile, []byte("ca-data"), 0600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	config := api.NewConfig()
+	config.Clusters["kubernetes"] = &api.Cluster{Server: "https://10.0.0.1", CertificateAuthority: caFile}
+	config.AuthInfos["kubernetes"] = &api.AuthInfo{Token: "user-token", Username: "admin", Password: "secret",
+		ClientKeyData: []byte("key"), TokenFile: "/var/run/token", Impersonate: "alice"}
+	config.Contexts["kubernetes"] = &api.Context{Cluster: "kubernetes", AuthInfo: "kubernetes"}
+	config.CurrentContext = "kubernetes"
+
+	for _, includeToken := range []bool{true, false} {
+		data, err := exportKubeConfig(*config, includeToken)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		exported, err := clientcmd.Load(data)
+		if err != nil {
+			t.Fatalf("Expected exported kubeconfig to be valid: %v", err)
+		}
+
+		cluster := exported.Clusters["kubernetes"]
+		if cluster.Server != "https://10.0.0.1" || string(cluster.CertificateAuthorityData) != "ca-data" ||
+			len(cluster.CertificateAuthority) > 0 {
+			t.Errorf("Expected cluster with inlined certificate authority, got %+v", cluster)
+		}
+
+		authInfo := exported.AuthInfos["kubernetes"]
+		if authInfo.Password != "" || authInfo.Username != "" || len(authInfo.ClientKeyData) > 0 ||
+			authInfo.TokenFile != "" || authInfo.Impersonate != "alice" {
+			t.Errorf("Expected only token and impersonation to be exported, got %+v", authInfo)
+		}
+
+		if (authInfo.Token == "user-token") != includeToken {
+			t.Errorf("Expected token to be included: %v, got %q", includeToken, authInfo.Token)
+		}
+
+		if exported.CurrentContext != "kubernetes" {
+			t.Errorf("Expected current context to be exported, got %q", exported.CurrentContext)
+		}
+	}
+
+	if config.AuthInfos["kubernetes"].Password != "secret" {
+		t.Error("Expected source config not to be modified")
+	}
+}
+
+func TestExportKubeConfigDisabled(t *testing.T) {
+	args.GetHolderBuilder().SetEnableKubeConfigExport(false)
+	manager := NewClientManager("", "http://localhost:8080")
+	req := &restful.Request{Request: &http.Request{
+		Header: http.Header{"Authorization": {"Bearer test-token"}},
+		TLS:    &tls.ConnectionState{},
+	}}
+
+	if _, err := manager.ExportKubeConfig(req); !errors.IsForbiddenError(err) {
+		t.Errorf("Expected forbidden error when export is disabled, got %v", err)
+	}
+
+	args.GetHolderBuilder().SetEnableKubeConfigExport(true)
+	defer args.GetHolderBuilder().SetEnableKubeConfigExport(false)
+	data, err := manager.ExportKubeConfig(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	exported, err := clientcmd.Load(data)
+	if err != nil || exported.Clusters[DefaultCmdConfigName].Server != "http://localhost:8080" ||
+		len(exported.AuthInfos[DefaultCmdConfigName].Token) > 0 {
+		t.Errorf("Expected kubeconfig of the cluster without token, got %s", data)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+
+	"k8s.io/client-go/rest"
+)
+
+// IncludeManagedFieldsHeader allows client to receive managed fields of the objects, which are stripped from the
+// apiserver responses by default.
+const IncludeManagedFieldsHeader = "X-Include-Managed-Fields"
+
+// Removes managed fields from the objects returned through the transport of the given config.
+func configureManagedFieldsStripping(cfg *rest.Config) {
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &managedFieldsStripper{delegate: rt}
+	})
+}
+
+// managedFieldsStripper removes metadata.managedFields from JSON objects and lists returned by the apiserver.
+// Streaming responses and responses in other formats are returned unchanged.
+type managedFieldsStripper struct {
+	delegate http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (self *managedFieldsStripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := self.delegate.RoundTrip(req)
+	if err != nil || resp.Body == nil || isStreamingRequest(req) || !isJSONResponse(resp) {
+		return resp, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	data = stripManagedFields(data)
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	resp.ContentLength = int64(len(data))
+	resp.Header.Del("Content-Length")
+	return resp, nil
+}
+
+// WrappedRoundTripper allows client-go to reach the underlying transport, i.e. to close idle connections.
+func (self *managedFieldsStripper) WrappedRoundTripper() http.RoundTripper {
+	return self.delegate
+}
+
+func isJSONResponse(resp *http.Response) bool {
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return err == nil && mediaType == "application/json"
+}
+
+// Returns JSON object or list without managed fields. Data is returned unchanged if it does not contain managed
+// fields or can not be decoded.
+func stripManagedFields(data []byte) []byte {
+	if !bytes.Contains(data, []byte(`"managedFields"`)) {
+		return data
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Keeps large integers, i.e. resource quantities, intact.
+	decoder.UseNumber()
+
+	var obj map[string]interface{}
+	if err := decoder.Decode(&obj); err != nil {
+		return data
+	}
+
+	removeManagedFields(obj)
+	if items, ok := obj["items"].([]interface{}); ok {
+		for _, item := range items {
+			if itemObj, ok := item.(map[string]interface{}); ok {
+				removeManagedFields(itemObj)
+			}
+		}
+	}
+
+	result, err := json.Marshal(obj)
+	if err != nil {
+		return data
+	}
+
+	return result
+}
+
+func removeManagedFields(obj map[string]interface{}) {
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "managedFields")
+	}
+}
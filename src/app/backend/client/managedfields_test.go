@@ -0,0 +1,122 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	restful "github.com/emicklei/go-restful/v3"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+func TestStripManagedFields(t *testing.T) {
+	cases := []struct {
+		info     string
+		data     string
+		expected string
+	}{
+		{
+			"should strip managed fields of the object",
+			`{"kind":"Pod","metadata":{"name":"pod","managedFields":[{"manager":"kubectl"}]},"spec":{"priority":9007199254740993}}`,
+			`{"kind":"Pod","metadata":{"name":"pod"},"spec":{"priority":9007199254740993}}`,
+		},
+		{
+			"should strip managed fields of the list items",
+			`{"kind":"PodList","metadata":{},"items":[{"metadata":{"name":"a","managedFields":[]}},{"metadata":{"name":"b"}}]}`,
+			`{"items":[{"metadata":{"name":"a"}},{"metadata":{"name":"b"}}],"kind":"PodList","metadata":{}}`,
+		},
+		{
+			"should not change objects without managed fields",
+			`{"kind":"Pod","metadata":{"name":"pod"}}`,
+			`{"kind":"Pod","metadata":{"name":"pod"}}`,
+		},
+		{
+			"should not change invalid objects",
+			`{"managedFields":`,
+			`{"managedFields":`,
+		},
+	}
+
+	for _, c := range cases {
+		if actual := string(stripManagedFields([]byte(c.data))); actual != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.info, c.expected, actual)
+		}
+	}
+}
+
+func TestIncludeManagedFieldsHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"kind":"Pod","apiVersion":"v1","metadata":{"name":"pod","namespace":"default",` +
+			`"managedFields":[{"manager":"kubectl","operation":"Apply"}]}}`))
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		header     string
+		wantFields bool
+		wantErr    bool
+	}{
+		{"", false, false},
+		{"true", true, false},
+		{"false", false, false},
+		{"invalid", false, true},
+	}
+
+	manager := NewClientManager("", server.URL)
+	for _, c := range cases {
+		req := &restful.Request{Request: &http.Request{
+			Header: http.Header{"Authorization": {"Bearer test-token"}},
+			TLS:    &tls.ConnectionState{},
+		}}
+		if len(c.header) > 0 {
+			req.Request.Header.Set(IncludeManagedFieldsHeader, c.header)
+		}
+
+		cfg, err := manager.Config(req)
+		if c.wantErr {
+			if !errors.IsBadRequest(err) {
+				t.Errorf("Expected bad request error for %q header, got %v", c.header, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		client, err := kubernetes.NewForConfig(cfg)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		pod, err := client.CoreV1().Pods("default").Get(context.TODO(), "pod", metaV1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if (len(pod.ManagedFields) > 0) != c.wantFields {
+			t.Errorf("Expected managed fields to be included: %v for %q header, got %v", c.wantFields, c.header,
+				pod.ManagedFields)
+		}
+	}
+}
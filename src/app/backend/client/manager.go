@@ -16,12 +16,21 @@ package client
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"log"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	v12 "k8s.io/api/authentication/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 
 	v1 "k8s.io/api/authorization/v1"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
@@ -55,17 +64,64 @@ const (
 	DefaultContentType = "application/vnd.kubernetes.protobuf"
 	// Default cluster/context/auth name to be set in clientcmd config
 	DefaultCmdConfigName = "kubernetes"
-	// Header name that contains token used for authorization. See TokenManager for more information.
+	// Default header name that contains token used for authorization. See TokenManager for more information.
 	JWETokenHeader = "jweToken"
 	// Default http header for user-agent
 	DefaultUserAgent = "dashboard"
 	//Impersonation Extra header
 	ImpersonateUserExtraHeader = "Impersonate-Extra-"
+	// Header that can be used to override timeout of apiserver requests made on behalf of the request
+	RequestTimeoutHeader = "X-Request-Timeout"
+	// Header that can be used to override content type of apiserver requests made on behalf of the request
+	ContentTypeOverrideHeader = "X-Content-Type-Override"
+	// Header that can be used to disable compression of apiserver responses made on behalf of the request
+	DisableCompressionHeader = "X-Disable-Compression"
+	// Issuer of the legacy service account tokens
+	ServiceAccountTokenIssuer = "kubernetes/serviceaccount"
+	// Prefix of the service account usernames, used as a subject of the bound service account tokens
+	ServiceAccountUsernamePrefix = "system:serviceaccount:"
+)
+
+const (
+	// DefaultUsernameExtractionRegex extracts name from the usernames in the
+	// 'system:serviceaccount:<namespace>:<name>' format.
+	DefaultUsernameExtractionRegex = `(?P<ignore>[\w-]+):(?P<type>[\w-]+):(?P<namespace>[\w-_]+):(?P<name>[\w-]+)`
+	// UsernameRegexGroup is a name of the capture group that contains user name.
+	UsernameRegexGroup = "name"
+)
+
+var defaultUsernameRegexp = regexp.MustCompile(DefaultUsernameExtractionRegex)
+
+// Content types that can be requested with ContentTypeOverrideHeader.
+var contentTypeOverrides = map[string]string{
+	"json":     "application/json",
+	"protobuf": DefaultContentType,
+}
+
+const (
+	// Time for which namespaces accessible by the user are cached.
+	AccessibleNamespacesCacheTTL = 10 * time.Second
+	// Maximum number of users whose accessible namespaces are cached at once.
+	AccessibleNamespacesCacheSize = 1000
+	// Time for which results of access reviews made by CanI are cached.
+	AccessReviewCacheTTL = 5 * time.Second
+	// Maximum number of access review results cached at once.
+	AccessReviewCacheSize = 10000
 )
 
 // VERSION of this binary
 var Version = "UNKNOWN"
 
+// GetJWETokenHeader returns name of the header that contains token used for authorization. It can be configured
+// with 'jwe-token-header' argument and falls back to JWETokenHeader.
+func GetJWETokenHeader() string {
+	if header := args.Holder.GetJWETokenHeader(); len(header) > 0 {
+		return header
+	}
+
+	return JWETokenHeader
+}
+
 // clientManager implements ClientManager interface
 type clientManager struct {
 	// Autogenerated key on backend start used to secure requests from csrf attacks
@@ -93,6 +149,40 @@ type clientManager struct {
 	// to service account used by dashboard or kubeconfig file if it was passed during dashboard
 	// init.
 	insecureConfig *rest.Config
+	// Per-user cache of namespaces that user is allowed to access.
+	namespaceCache *ttlCache
+	// Per-user cache of the access review results.
+	accessReviewCache *ttlCache
+	// Shared cache of the OpenAPI schema.
+	openAPISchemaCache *openAPISchemaCache
+	// Connects transports to the egress proxy. It is nil if egress proxy does not require mutual TLS.
+	egressProxy *egressProxy
+	// Used to extract user name from the username returned by the apiserver.
+	usernameRegexp *regexp.Regexp
+	// Matches namespaces hidden from users that are not cluster admins. Nil if all namespaces are visible.
+	namespaceDenylist *regexp.Regexp
+	// Maps extracted user names to the names displayed to the users.
+	usernameResolver clientapi.UsernameResolver
+	// Cluster domain detected on first use.
+	clusterDomainCache *clusterDomainCache
+	// Number of concurrent watches opened by every user.
+	watchLimiter *watchLimiter
+	// Number of concurrent port forwarding sessions opened by every user.
+	portForwardLimiter *watchLimiter
+	// Namespaced informer factories shared between requests of the same user.
+	informerFactories *informerFactoryCache
+	// Observes connections of the transports used by the clients.
+	connectionTracker *connectionTracker
+	// Merges concurrent identical requests of the same user.
+	deduplicator *requestDeduplicator
+	// Records requests sent with the credentials of the dashboard service account.
+	insecureMetrics *requestMetrics
+	// RESTMapper shared by all requests, created on first use.
+	mapper         meta.ResettableRESTMapper
+	restMapperOnce sync.Once
+	// Mechanism used by the insecure client to authenticate, computed once on first use.
+	authMode     clientapi.AuthMode
+	authModeOnce sync.Once
 }
 
 // Client returns a kubernetes client. In case dashboard login is enabled and option to skip
@@ -152,7 +242,7 @@ func (self *clientManager) Config(req *restful.Request) (*rest.Config, error) {
 		return self.secureConfig(req)
 	}
 
-	return self.InsecureConfig(), nil
+	return self.applyRequestOverrides(req, self.InsecureConfig())
 }
 
 // InsecureClient returns kubernetes client that was created without providing auth info. It uses
@@ -196,13 +286,37 @@ func (self *clientManager) CanI(req *restful.Request, ssar *v1.SelfSubjectAccess
 		return false
 	}
 
-	response, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(context.TODO(), ssar, metaV1.CreateOptions{})
+	allowed, err := self.cachedAccessReview(client, self.userCacheKey(req), ssar)
 	if err != nil {
 		log.Println(err)
 		return false
 	}
 
-	return response.Status.Allowed
+	return allowed
+}
+
+// Returns result of the access review from the cache or creates it using provided client. Results are cached per
+// user, identified by the key, and resource attributes of the review. Because the key is derived from the user
+// credentials, cached results are not reused after the token changes.
+func (self *clientManager) cachedAccessReview(client kubernetes.Interface, userKey string,
+	ssar *v1.SelfSubjectAccessReview) (bool, error) {
+	spec, err := json.Marshal(ssar.Spec)
+	if err != nil {
+		return false, err
+	}
+
+	key := userKey + "/" + string(spec)
+	if allowed, ok := self.accessReviewCache.Get(key); ok {
+		return allowed.(bool), nil
+	}
+
+	response, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(context.TODO(), ssar, metaV1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	self.accessReviewCache.Set(key, response.Status.Allowed)
+	return response.Status.Allowed, nil
 }
 
 // ClientCmdConfig creates ClientCmd Config based on authentication information extracted from request.
@@ -248,7 +362,7 @@ func (self *clientManager) HasAccess(authInfo api.AuthInfo) (string, error) {
 	_, err = client.ServerVersion()
 	if err != nil {
 		if k8serrors.IsForbidden(err) {
-			return self.getUsernameFromError(err), err
+			return self.resolveUsername(self.getUsernameFromError(err)), err
 		}
 
 		return "", err
@@ -262,13 +376,13 @@ func (self *clientManager) HasAccess(authInfo api.AuthInfo) (string, error) {
 
 	if err != nil {
 		if k8serrors.IsForbidden(err) {
-			return self.getUsernameFromError(err), nil
+			return self.resolveUsername(self.getUsernameFromError(err)), nil
 		}
 
 		return "", err
 	}
 
-	return self.getUsername(result.Status.User.Username), nil
+	return self.resolveUsername(self.getUsername(result.Status.User.Username)), nil
 }
 
 // VerberClient returns new verber client based on authentication information extracted from request
@@ -312,12 +426,46 @@ func (self *clientManager) SetTokenManager(manager authApi.TokenManager) {
 	self.tokenManager = manager
 }
 
+// SetUsernameResolver sets the resolver used to map user names to the display names. Names are returned unchanged
+// if resolver is not set.
+func (self *clientManager) SetUsernameResolver(resolver clientapi.UsernameResolver) {
+	self.usernameResolver = resolver
+}
+
 // Initializes config with default values
 func (self *clientManager) initConfig(cfg *rest.Config) {
-	cfg.QPS = DefaultQPS
-	cfg.Burst = DefaultBurst
+	cfg.QPS, cfg.Burst = self.rateLimits()
 	cfg.ContentType = DefaultContentType
 	cfg.UserAgent = DefaultUserAgent + "/" + Version
+	self.egressProxy.configure(cfg)
+	configureResponseSizeLimit(cfg, args.Holder.GetMaxResponseSize())
+	configureThrottleRetry(cfg)
+	self.connectionTracker.configure(cfg)
+	self.deduplicator.configure(cfg)
+}
+
+// Returns QPS and burst configured for the in-cluster or out-of-cluster config, depending on which one is used by
+// buildConfigFromFlags. Unset values fall back to DefaultQPS and DefaultBurst.
+func (self *clientManager) rateLimits() (float32, int) {
+	qps, burst := args.Holder.GetOutOfClusterQPS(), args.Holder.GetOutOfClusterBurst()
+	if self.usesInClusterConfig() {
+		qps, burst = args.Holder.GetInClusterQPS(), args.Holder.GetInClusterBurst()
+	}
+
+	if qps <= 0 {
+		qps = DefaultQPS
+	}
+
+	if burst <= 0 {
+		burst = DefaultBurst
+	}
+
+	return qps, burst
+}
+
+// Returns true if neither kubeconfig nor apiserver host is provided and buildConfigFromFlags uses in-cluster config.
+func (self *clientManager) usesInClusterConfig() bool {
+	return len(self.kubeConfigPath) == 0 && len(self.apiserverHost) == 0
 }
 
 // Returns rest Config based on provided apiserverHost and kubeConfigPath flags. If both are
@@ -363,7 +511,8 @@ func (self *clientManager) buildCmdConfig(authInfo *api.AuthInfo, cfg *rest.Conf
 func (self *clientManager) extractAuthInfo(req *restful.Request) (*api.AuthInfo, error) {
 	authHeader := req.HeaderParameter("Authorization")
 	impersonationHeader := req.HeaderParameter("Impersonate-User")
-	jweToken := req.HeaderParameter(JWETokenHeader)
+	assertionHeader := req.HeaderParameter(ImpersonationAssertionHeader)
+	jweToken := req.HeaderParameter(GetJWETokenHeader())
 
 	// Authorization header will be more important than our token
 	token := self.extractTokenFromHeader(authHeader)
@@ -371,6 +520,36 @@ func (self *clientManager) extractAuthInfo(req *restful.Request) (*api.AuthInfo,
 
 		authInfo := &api.AuthInfo{Token: token}
 
+		// Service accounts allowed to impersonate could be used to launder privileges of other users.
+		if (len(impersonationHeader) > 0 || len(assertionHeader) > 0) &&
+			args.Holder.GetBlockServiceAccountImpersonation() && isServiceAccountToken(token) {
+			return nil, errors.NewForbidden("impersonation is not allowed for service account tokens")
+		}
+
+		// Once assertion secret is configured, only signed impersonation assertions are honored.
+		if secret := args.Holder.GetImpersonationAssertionSecret(); len(secret) > 0 {
+			if len(impersonationHeader) > 0 {
+				return nil, errors.NewBadRequest(fmt.Sprintf("impersonation headers are not accepted, use %s header",
+					ImpersonationAssertionHeader))
+			}
+
+			if len(assertionHeader) > 0 {
+				assertion, err := verifyImpersonationAssertion([]byte(secret), assertionHeader, time.Now())
+				if err != nil {
+					return nil, err
+				}
+
+				authInfo.Impersonate = assertion.User
+				authInfo.ImpersonateGroups = assertion.Groups
+			}
+
+			return authInfo, nil
+		}
+
+		if len(assertionHeader) > 0 {
+			return nil, errors.NewBadRequest("impersonation assertions are not enabled")
+		}
+
 		if len(impersonationHeader) > 0 {
 			//there's an impersonation header, lets make sure to add it
 			authInfo.Impersonate = impersonationHeader
@@ -395,6 +574,11 @@ func (self *clientManager) extractAuthInfo(req *restful.Request) (*api.AuthInfo,
 		return authInfo, nil
 	}
 
+	// Impersonation headers are honored only together with bearer token, do not silently drop them.
+	if len(impersonationHeader) > 0 || len(assertionHeader) > 0 {
+		return nil, errors.NewBadRequest("impersonation requires authentication")
+	}
+
 	if self.tokenManager != nil && len(jweToken) > 0 {
 		return self.tokenManager.Decrypt(jweToken)
 	}
@@ -402,12 +586,15 @@ func (self *clientManager) extractAuthInfo(req *restful.Request) (*api.AuthInfo,
 	return nil, errors.NewUnauthorized(errors.MsgLoginUnauthorizedError)
 }
 
-// Checks if request headers contain any auth information without parsing.
+// Checks if request headers contain any auth information without parsing. Impersonation headers are also taken into
+// account so that impersonation without authentication is reported instead of being ignored.
 func (self *clientManager) containsAuthInfo(req *restful.Request) bool {
 	authHeader := req.HeaderParameter("Authorization")
-	jweToken := req.HeaderParameter(JWETokenHeader)
+	jweToken := req.HeaderParameter(GetJWETokenHeader())
+	impersonationHeader := req.HeaderParameter("Impersonate-User")
+	assertionHeader := req.HeaderParameter(ImpersonationAssertionHeader)
 
-	return len(authHeader) > 0 || len(jweToken) > 0
+	return len(authHeader) > 0 || len(jweToken) > 0 || len(impersonationHeader) > 0 || len(assertionHeader) > 0
 }
 
 func (self *clientManager) extractTokenFromHeader(authHeader string) string {
@@ -418,6 +605,52 @@ func (self *clientManager) extractTokenFromHeader(authHeader string) string {
 	return ""
 }
 
+// Checks if token is a service account JWT token. Token signature is not verified, it is only used to decide if
+// the request should be rejected, authentication is still done by the apiserver.
+func isServiceAccountToken(token string) bool {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return false
+	}
+
+	claims := struct {
+		Issuer  string `json:"iss"`
+		Subject string `json:"sub"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return false
+	}
+
+	return claims.Issuer == ServiceAccountTokenIssuer || strings.HasPrefix(claims.Subject, ServiceAccountUsernamePrefix)
+}
+
+// Returns key identifying user that made the request. It is used to store per-user data in caches. Credentials are
+// never used directly, only their hash is. Empty key is returned for requests that do not use their own credentials.
+func (self *clientManager) userCacheKey(req *restful.Request) string {
+	if !self.isSecureModeEnabled(req) {
+		return ""
+	}
+
+	authInfo, err := self.extractAuthInfo(req)
+	if err != nil || authInfo == nil {
+		return ""
+	}
+
+	hash := sha256.New()
+	for _, value := range append([]string{authInfo.Token, authInfo.Username, authInfo.Password,
+		string(authInfo.ClientCertificateData), authInfo.Impersonate}, authInfo.ImpersonateGroups...) {
+		hash.Write([]byte(value))
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
 func (self *clientManager) isLoginEnabled(req *restful.Request) bool {
 	return req.Request.TLS != nil || args.Holder.GetEnableInsecureLogin()
 }
@@ -475,6 +708,16 @@ func (self *clientManager) securePluginClient(req *restful.Request) (pluginclien
 }
 
 func (self *clientManager) secureConfig(req *restful.Request) (*rest.Config, error) {
+	cfg, err := self.authConfig(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.applyRequestOverrides(req, cfg)
+}
+
+// Returns config that uses credentials of the user, without the overrides passed in request headers.
+func (self *clientManager) authConfig(req *restful.Request) (*rest.Config, error) {
 	cmdConfig, err := self.ClientCmdConfig(req)
 	if err != nil {
 		return nil, err
@@ -489,9 +732,103 @@ func (self *clientManager) secureConfig(req *restful.Request) (*rest.Config, err
 	return cfg, nil
 }
 
+// Returns client that uses credentials of the user, or insecure client if secure mode is not enabled. Unlike Client,
+// overrides passed in request headers, i.e. timeout or propagated headers, are not applied, so that it can be shared
+// between requests of the same user.
+func (self *clientManager) authClient(req *restful.Request) (kubernetes.Interface, error) {
+	if req == nil {
+		return nil, errors.NewBadRequest("request can not be nil")
+	}
+
+	if !self.isSecureModeEnabled(req) {
+		return self.InsecureClient(), nil
+	}
+
+	cfg, err := self.authConfig(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return kubernetes.NewForConfig(cfg)
+}
+
+// Applies overrides passed in request headers to the copy of provided config.
+func (self *clientManager) applyRequestOverrides(req *restful.Request, cfg *rest.Config) (*rest.Config, error) {
+	result := rest.CopyConfig(cfg)
+
+	if timeout := req.HeaderParameter(RequestTimeoutHeader); len(timeout) > 0 {
+		maxTimeout := time.Duration(args.Holder.GetMaxRequestTimeout()) * time.Second
+		parsed, err := parseRequestTimeout(timeout, maxTimeout)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Timeout = parsed
+	}
+
+	// Unknown content types are ignored and the default one is used.
+	override := strings.ToLower(strings.TrimSpace(req.HeaderParameter(ContentTypeOverrideHeader)))
+	if contentType, ok := contentTypeOverrides[override]; ok {
+		result.ContentType = contentType
+	}
+
+	if disable := req.HeaderParameter(DisableCompressionHeader); len(disable) > 0 {
+		parsed, err := strconv.ParseBool(disable)
+		if err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid %s header value: %s", DisableCompressionHeader, disable))
+		}
+
+		result.DisableCompression = result.DisableCompression || parsed
+	}
+
+	includeManagedFields := false
+	if include := req.HeaderParameter(IncludeManagedFieldsHeader); len(include) > 0 {
+		parsed, err := strconv.ParseBool(include)
+		if err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid %s header value: %s", IncludeManagedFieldsHeader, include))
+		}
+
+		includeManagedFields = parsed
+	}
+
+	if !includeManagedFields {
+		configureManagedFieldsStripping(result)
+	}
+
+	configureHeaderPropagation(req, result, args.Holder.GetPropagatedRequestHeaders())
+	return result, nil
+}
+
+// Parses timeout provided either as a duration (i.e. '30s') or number of seconds. Timeout has to be positive and is
+// capped to the max timeout if it is set.
+func parseRequestTimeout(timeout string, maxTimeout time.Duration) (time.Duration, error) {
+	parsed, err := time.ParseDuration(timeout)
+	if err != nil {
+		seconds, convErr := strconv.Atoi(timeout)
+		if convErr != nil {
+			return 0, errors.NewBadRequest(fmt.Sprintf("invalid %s header value: %s", RequestTimeoutHeader, timeout))
+		}
+
+		parsed = time.Duration(seconds) * time.Second
+	}
+
+	if parsed <= 0 {
+		return 0, errors.NewBadRequest(fmt.Sprintf("%s header value has to be positive", RequestTimeoutHeader))
+	}
+
+	if maxTimeout > 0 && parsed > maxTimeout {
+		return maxTimeout, nil
+	}
+
+	return parsed, nil
+}
+
 // Initializes client manager
 func (self *clientManager) init() {
 	self.initInClusterConfig()
+	self.initEgressProxy()
+	self.initUsernameRegexp()
+	self.initNamespaceDenylist()
 	self.initInsecureClients()
 	self.initCSRFKey()
 }
@@ -504,7 +841,8 @@ func (self *clientManager) initInClusterConfig() {
 	}
 
 	log.Print("Using in-cluster config to connect to apiserver")
-	cfg, err := rest.InClusterConfig()
+	cfg, err := loadInClusterConfig(rest.InClusterConfig, args.Holder.GetInClusterConfigAttempts(),
+		time.Duration(args.Holder.GetInClusterConfigRetryInterval())*time.Second, time.Sleep)
 	if err != nil {
 		log.Printf("Could not init in cluster config: %s", err.Error())
 		return
@@ -513,8 +851,71 @@ func (self *clientManager) initInClusterConfig() {
 	self.inClusterConfig = cfg
 }
 
-// Initializes csrfKey. If in-cluster config is detected then csrf key is initialized with
-// service account token, otherwise it is generated
+// Loads in-cluster config retrying with exponential backoff, as projected service account token may not be
+// available right after the container starts. Loading is not retried if dashboard is not running in a cluster.
+func loadInClusterConfig(loader func() (*rest.Config, error), attempts int, interval time.Duration,
+	sleep func(time.Duration)) (*rest.Config, error) {
+	for attempt := 1; ; attempt++ {
+		cfg, err := loader()
+		if err == nil || err == rest.ErrNotInCluster || attempt >= attempts {
+			return cfg, err
+		}
+
+		log.Printf("Could not init in cluster config (attempt %d of %d), retrying in %s: %s", attempt, attempts,
+			interval, err.Error())
+		sleep(interval)
+		interval *= 2
+	}
+}
+
+// Initializes egress proxy if its client certificate or CA were provided.
+func (self *clientManager) initEgressProxy() {
+	tlsConfig, err := loadEgressProxyTLSConfig(args.Holder.GetEgressProxyClientCert(),
+		args.Holder.GetEgressProxyClientKey(), args.Holder.GetEgressProxyCA())
+	if err != nil {
+		panic(err)
+	}
+
+	if tlsConfig != nil {
+		log.Print("Using mutual TLS to connect to the egress proxy")
+	}
+
+	self.egressProxy = newEgressProxy(tlsConfig)
+}
+
+// Initializes regular expression used to extract user names. Fails if configured expression is invalid.
+func (self *clientManager) initUsernameRegexp() {
+	expr := args.Holder.GetUsernameExtractionRegex()
+	if len(expr) == 0 {
+		self.usernameRegexp = defaultUsernameRegexp
+		return
+	}
+
+	re, err := parseUsernameRegexp(expr)
+	if err != nil {
+		panic(err)
+	}
+
+	self.usernameRegexp = re
+}
+
+// Initializes regular expression matching namespaces hidden from users. Fails if configured expression is invalid.
+func (self *clientManager) initNamespaceDenylist() {
+	expr := args.Holder.GetNamespaceDenylist()
+	if len(expr) == 0 {
+		return
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		panic(fmt.Errorf("invalid namespace denylist: %s", err.Error()))
+	}
+
+	self.namespaceDenylist = re
+}
+
+// Initializes csrfKey. If in-cluster config is detected then csrf key is read from the dedicated secret managed by
+// the dashboard, so it does not depend on the rotating service account token. Otherwise it is generated.
 func (self *clientManager) initCSRFKey() {
 	if self.inClusterConfig == nil {
 		// Most likely running for a dev, so no replica issues, just generate a random key
@@ -524,7 +925,7 @@ func (self *clientManager) initCSRFKey() {
 	}
 
 	// We run in a cluster, so we should use a signing key that is the same for potential replications
-	log.Println("Using secret token for csrf signing")
+	log.Printf("Using key stored in %s secret for csrf signing", clientapi.CsrfTokenSecretName)
 	self.csrfKey = csrf.NewCsrfTokenManager(self.insecureClient).Token()
 }
 
@@ -558,6 +959,7 @@ func (self *clientManager) initInsecureConfig() {
 	}
 
 	self.initConfig(cfg)
+	self.insecureMetrics.configure(cfg)
 	self.insecureConfig = cfg
 }
 
@@ -571,25 +973,63 @@ func (self *clientManager) getUsernameFromError(err error) string {
 	return re.ReplaceAllString(err.Error(), "$1")
 }
 
+// Extracts user name from the username returned by the apiserver using configured regular expression. Username is
+// returned unchanged if it does not match.
 func (self *clientManager) getUsername(name string) string {
-	const groups = 5
-	const nameGroupIdx = 4
-	re := regexp.MustCompile(`(?P<ignore>[\w-]+):(?P<type>[\w-]+):(?P<namespace>[\w-_]+):(?P<name>[\w-]+)`)
+	re := self.usernameRegexp
+	if re == nil {
+		re = defaultUsernameRegexp
+	}
+
 	match := re.FindStringSubmatch(name)
+	if match == nil || len(match[re.SubexpIndex(UsernameRegexGroup)]) == 0 {
+		return name
+	}
+
+	return match[re.SubexpIndex(UsernameRegexGroup)]
+}
 
-	if match == nil || len(match) != groups {
+// Maps user name to the display name using configured resolver.
+func (self *clientManager) resolveUsername(name string) string {
+	if self.usernameResolver == nil || len(name) == 0 {
 		return name
 	}
 
-	return match[nameGroupIdx]
+	return self.usernameResolver.Resolve(name)
+}
+
+// Compiles regular expression used to extract user name and makes sure it contains UsernameRegexGroup.
+func parseUsernameRegexp(expr string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid username extraction regex: %s", err.Error())
+	}
+
+	if re.SubexpIndex(UsernameRegexGroup) < 0 {
+		return nil, fmt.Errorf("username extraction regex has to contain named capture group '%s'",
+			UsernameRegexGroup)
+	}
+
+	return re, nil
 }
 
 // NewClientManager creates client manager based on kubeConfigPath and apiserverHost parameters.
 // If both are empty then in-cluster config is used.
 func NewClientManager(kubeConfigPath, apiserverHost string) clientapi.ClientManager {
+	deduplicator := newRequestDeduplicator(MaxInFlightDeduplicatedRequests, args.Holder.GetPropagatedRequestHeaders())
 	result := &clientManager{
-		kubeConfigPath: kubeConfigPath,
-		apiserverHost:  apiserverHost,
+		kubeConfigPath:     kubeConfigPath,
+		apiserverHost:      apiserverHost,
+		namespaceCache:     newTTLCache(AccessibleNamespacesCacheTTL, AccessibleNamespacesCacheSize),
+		accessReviewCache:  newTTLCache(AccessReviewCacheTTL, AccessReviewCacheSize),
+		openAPISchemaCache: newOpenAPISchemaCache(OpenAPISchemaCacheTTL),
+		clusterDomainCache: &clusterDomainCache{},
+		watchLimiter:       newWatchLimiter(),
+		portForwardLimiter: newWatchLimiter(),
+		connectionTracker:  newConnectionTracker(),
+		deduplicator:       deduplicator,
+		insecureMetrics:    newRequestMetrics(),
+		informerFactories:  newInformerFactoryCache(),
 	}
 
 	result.init()
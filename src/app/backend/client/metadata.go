@@ -0,0 +1,61 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/metadata"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/common"
+)
+
+// ListMetadata lists only metadata of the given resources using credentials of the user. Objects are requested as
+// PartialObjectMetadataList, which makes responses much smaller for list views that do not need whole objects.
+// Empty namespace lists resources from all namespaces, limit and timeout of the options are subject to the page size
+// and list timeout arguments. Items of the fetched page are sorted according to the list sort, if provided.
+func (self *clientManager) ListMetadata(req *restful.Request, gvr schema.GroupVersionResource, namespace string,
+	opts metaV1.ListOptions, listSort *common.ListSort) (*metaV1.PartialObjectMetadataList, error) {
+	cfg, err := self.Config(req)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := metadata.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err = common.WithPageLimit(opts, opts.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err = common.WithListTimeout(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.Resource(gvr).Namespace(namespace).List(context.TODO(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, common.SortList(list, listSort)
+}
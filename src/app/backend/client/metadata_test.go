@@ -0,0 +1,64 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	restful "github.com/emicklei/go-restful/v3"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestListMetadata(t *testing.T) {
+	var accept, path, limit string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+		path = r.URL.Path
+		limit = r.URL.Query().Get("limit")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"kind":"PartialObjectMetadataList","apiVersion":"meta.k8s.io/v1","metadata":{},` +
+			`"items":[{"metadata":{"name":"web","namespace":"default","labels":{"app":"web"}}}]}`))
+	}))
+	defer server.Close()
+
+	manager := NewClientManager("", server.URL)
+	req := &restful.Request{Request: &http.Request{
+		Header: http.Header{"Authorization": {"Bearer test-token"}},
+		TLS:    &tls.ConnectionState{},
+	}}
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+	list, err := manager.ListMetadata(req, gvr, "default", metaV1.ListOptions{Limit: 10}, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.Contains(accept, "as=PartialObjectMetadataList;g=meta.k8s.io;v=v1") {
+		t.Errorf("Expected partial object metadata list to be requested, got Accept header %q", accept)
+	}
+
+	if path != "/apis/apps/v1/namespaces/default/deployments" || limit != "10" {
+		t.Errorf("Expected namespaced list request with limit, got %s?limit=%s", path, limit)
+	}
+
+	if len(list.Items) != 1 || list.Items[0].Name != "web" || list.Items[0].Labels["app"] != "web" {
+		t.Errorf("Expected metadata of the deployment, got %+v", list.Items)
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"regexp"
+	"sync"
+
+	v1 "k8s.io/api/authorization/v1"
+	coreV1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/namespace"
+)
+
+// AccessibleNamespaces returns names of the namespaces that user is allowed to list or to see any resources in,
+// except for the ones hidden by the namespace denylist. Results are cached per user for AccessibleNamespacesCacheTTL.
+func (self *clientManager) AccessibleNamespaces(req *restful.Request) ([]string, error) {
+	client, err := self.Client(req)
+	if err != nil {
+		return nil, err
+	}
+
+	key := self.userCacheKey(req)
+	if cached, ok := self.namespaceCache.Get(key); ok {
+		return cached.([]string), nil
+	}
+
+	namespaces, err := accessibleNamespaces(client, self.InsecureClient())
+	if err != nil {
+		return nil, err
+	}
+
+	denylist, err := namespaceDenylist(client, self.namespaceDenylist)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces = namespace.FilterDeniedNamespaces(namespaces, denylist)
+
+	self.namespaceCache.Set(key, namespaces)
+	return namespaces, nil
+}
+
+// Maximum number of SelfSubjectRulesReviews created at the same time when the accessible namespaces are checked
+// one by one.
+const maxConcurrentNamespaceRulesReviews = 10
+
+// Verbs that allow user to see resources within the namespace.
+var namespaceReadVerbs = []string{"get", "list"}
+
+// Returns names of the namespaces that can be accessed using provided client. In case user is allowed to list
+// namespaces the list is returned right away. Otherwise the fallback client, i.e. dashboard service account, is used
+// only to enumerate names of the namespaces, and a SelfSubjectRulesReview scoped to every namespace checks if user
+// is allowed to get or list any resource in it. At most maxConcurrentNamespaceRulesReviews reviews are created at
+// the same time and the result is cached per user by the caller.
+func accessibleNamespaces(client, fallbackClient kubernetes.Interface) ([]string, error) {
+	list, err := client.CoreV1().Namespaces().List(context.TODO(), metaV1.ListOptions{})
+	if err == nil {
+		return namespaceNames(list.Items), nil
+	}
+
+	if !k8serrors.IsForbidden(err) || fallbackClient == nil {
+		return nil, err
+	}
+
+	list, err = fallbackClient.CoreV1().Namespaces().List(context.TODO(), metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	names := namespaceNames(list.Items)
+	allowed := make([]bool, len(names))
+	errs := make([]error, len(names))
+	semaphore := make(chan struct{}, maxConcurrentNamespaceRulesReviews)
+	wg := sync.WaitGroup{}
+	for i := range names {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			allowed[i], errs[i] = canReadNamespace(client, names[i])
+		}(i)
+	}
+	wg.Wait()
+
+	result := make([]string, 0, len(names))
+	for i, name := range names {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+
+		if allowed[i] {
+			result = append(result, name)
+		}
+	}
+
+	return result, nil
+}
+
+// Returns true if user is allowed to get or list any resource within the namespace, including the namespace itself.
+func canReadNamespace(client kubernetes.Interface, namespace string) (bool, error) {
+	rules, err := client.AuthorizationV1().SelfSubjectRulesReviews().Create(context.TODO(),
+		&v1.SelfSubjectRulesReview{
+			Spec: v1.SelfSubjectRulesReviewSpec{Namespace: namespace},
+		}, metaV1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	for _, rule := range rules.Status.ResourceRules {
+		for _, verb := range namespaceReadVerbs {
+			if matches(rule.Verbs, verb) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func namespaceNames(namespaces []coreV1.Namespace) []string {
+	names := make([]string, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		names = append(names, namespace.Name)
+	}
+
+	return names
+}
+
+// NamespaceDenylist returns expression matching names of the namespaces hidden from the user, configured with
+// 'namespace-denylist' argument. Nil is returned if denylist is not configured or the user is a cluster admin, as
+// cluster admins can see all namespaces.
+func (self *clientManager) NamespaceDenylist(req *restful.Request) (*regexp.Regexp, error) {
+	if self.namespaceDenylist == nil {
+		return nil, nil
+	}
+
+	client, err := self.Client(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return namespaceDenylist(client, self.namespaceDenylist)
+}
+
+func namespaceDenylist(client kubernetes.Interface, denylist *regexp.Regexp) (*regexp.Regexp, error) {
+	if denylist == nil {
+		return nil, nil
+	}
+
+	review, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(context.TODO(),
+		&v1.SelfSubjectAccessReview{
+			Spec: v1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &v1.ResourceAttributes{Verb: "*", Group: "*", Resource: "*"},
+			},
+		}, metaV1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if review.Status.Allowed {
+		return nil, nil
+	}
+
+	return denylist, nil
+}
+
+// NamespaceAccessResources lists resources checked by the namespace access summary, keyed by the resource name.
+var NamespaceAccessResources = map[string]string{
+	"pods":        "",
+	"deployments": "apps",
+	"services":    "",
+	"configmaps":  "",
+	"secrets":     "",
+}
+
+// NamespaceAccessSummary returns information whether the user is allowed to list the common resources in the given
+// namespace, keyed by the resource name. It is based on a single SelfSubjectRulesReview scoped to the namespace.
+func (self *clientManager) NamespaceAccessSummary(req *restful.Request, namespace string) (map[string]bool, error) {
+	client, err := self.Client(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return namespaceAccessSummary(client, namespace)
+}
+
+func namespaceAccessSummary(client kubernetes.Interface, namespace string) (map[string]bool, error) {
+	rules, err := client.AuthorizationV1().SelfSubjectRulesReviews().Create(context.TODO(),
+		&v1.SelfSubjectRulesReview{
+			Spec: v1.SelfSubjectRulesReviewSpec{Namespace: namespace},
+		}, metaV1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]bool, len(NamespaceAccessResources))
+	for resource, group := range NamespaceAccessResources {
+		result[resource] = rulesAllow(rules.Status.ResourceRules, group, resource, "", "list")
+	}
+
+	return result, nil
+}
+
+// Returns true if any of the provided rules allows to perform given verb on the named resource.
+func rulesAllow(rules []v1.ResourceRule, group, resource, name, verb string) bool {
+	for _, rule := range rules {
+		if matches(rule.APIGroups, group) && matches(rule.Resources, resource) && matches(rule.Verbs, verb) &&
+			(len(rule.ResourceNames) == 0 || contains(rule.ResourceNames, name)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns true if values contain given value or a wildcard.
+func matches(values []string, value string) bool {
+	return contains(values, value) || contains(values, "*")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
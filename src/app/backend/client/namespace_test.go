@@ -0,0 +1,217 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"reflect"
+	"regexp"
+	"sync/atomic"
+	"testing"
+
+	v1 "k8s.io/api/authorization/v1"
+	coreV1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientTesting "k8s.io/client-go/testing"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/namespace"
+)
+
+func newNamespace(name string) *coreV1.Namespace {
+	return &coreV1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: name}}
+}
+
+func TestAccessibleNamespaces(t *testing.T) {
+	cases := []struct {
+		info            string
+		listAllowed     bool
+		rules           map[string][]v1.ResourceRule
+		expected        []string
+		expectedReviews int
+	}{
+		{
+			"namespaces listed by user",
+			true,
+			nil,
+			[]string{"default", "kube-system", "team-a"},
+			0,
+		},
+		{
+			"rules review access",
+			false,
+			map[string][]v1.ResourceRule{
+				"default": {{Verbs: []string{"get", "list"}, APIGroups: []string{""}, Resources: []string{"namespaces"}}},
+				"team-a":  {{Verbs: []string{"list"}, APIGroups: []string{"apps"}, Resources: []string{"deployments"}}},
+				"kube-system": {{Verbs: []string{"create"}, APIGroups: []string{"authorization.k8s.io"},
+					Resources: []string{"selfsubjectaccessreviews"}}},
+			},
+			[]string{"default", "team-a"},
+			3,
+		},
+		{
+			"no access",
+			false,
+			map[string][]v1.ResourceRule{
+				"default": {{Verbs: []string{"create", "delete"}, APIGroups: []string{""}, Resources: []string{"pods"}}},
+			},
+			[]string{},
+			3,
+		},
+	}
+
+	for _, c := range cases {
+		fallbackClient := fake.NewSimpleClientset(newNamespace("default"), newNamespace("kube-system"),
+			newNamespace("team-a"))
+		client := fake.NewSimpleClientset(newNamespace("default"), newNamespace("kube-system"), newNamespace("team-a"))
+		client.PrependReactor("list", "namespaces",
+			func(action clientTesting.Action) (bool, runtime.Object, error) {
+				if c.listAllowed {
+					return false, nil, nil
+				}
+				return true, nil, k8serrors.NewForbidden(coreV1.Resource("namespaces"), "", nil)
+			})
+		reviews := int32(0)
+		client.PrependReactor("create", "selfsubjectrulesreviews",
+			func(action clientTesting.Action) (bool, runtime.Object, error) {
+				atomic.AddInt32(&reviews, 1)
+				review := action.(clientTesting.CreateAction).GetObject().(*v1.SelfSubjectRulesReview)
+				review.Status.ResourceRules = c.rules[review.Spec.Namespace]
+				return true, review, nil
+			})
+
+		actual, err := accessibleNamespaces(client, fallbackClient)
+		if err != nil {
+			t.Fatalf("%s: accessibleNamespaces(): unexpected error: %s", c.info, err.Error())
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: accessibleNamespaces() == %v, expected %v", c.info, actual, c.expected)
+		}
+
+		if int(reviews) != c.expectedReviews {
+			t.Errorf("%s: expected %d rules reviews, got %d", c.info, c.expectedReviews, reviews)
+		}
+
+		if actions := fallbackClient.Actions(); c.listAllowed != (len(actions) == 0) {
+			t.Errorf("%s: unexpected fallback client actions: %v", c.info, actions)
+		}
+	}
+
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("list", "namespaces", func(action clientTesting.Action) (bool, runtime.Object, error) {
+		return true, nil, k8serrors.NewForbidden(coreV1.Resource("namespaces"), "", nil)
+	})
+	if _, err := accessibleNamespaces(client, nil); !k8serrors.IsForbidden(err) {
+		t.Errorf("Expected forbidden error without fallback client, got %v", err)
+	}
+}
+
+func TestRulesAllow(t *testing.T) {
+	rules := []v1.ResourceRule{
+		{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"namespaces"}, ResourceNames: []string{"a"}},
+		{Verbs: []string{"list"}, APIGroups: []string{"apps"}, Resources: []string{"*"}},
+	}
+
+	cases := []struct {
+		group, resource, name, verb string
+		expected                    bool
+	}{
+		{"", "namespaces", "a", "get", true},
+		{"", "namespaces", "b", "get", false},
+		{"apps", "deployments", "", "list", true},
+		{"apps", "deployments", "", "delete", false},
+		{"", "pods", "", "list", false},
+	}
+
+	for _, c := range cases {
+		if actual := rulesAllow(rules, c.group, c.resource, c.name, c.verb); actual != c.expected {
+			t.Errorf("rulesAllow(%s, %s, %s, %s) == %t, expected %t", c.group, c.resource, c.name, c.verb,
+				actual, c.expected)
+		}
+	}
+}
+
+func TestNamespaceAccessSummary(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "selfsubjectrulesreviews",
+		func(action clientTesting.Action) (bool, runtime.Object, error) {
+			review := action.(clientTesting.CreateAction).GetObject().(*v1.SelfSubjectRulesReview)
+			if review.Spec.Namespace != "team-a" {
+				return true, review, nil
+			}
+
+			review.Status.ResourceRules = []v1.ResourceRule{
+				{Verbs: []string{"get", "list", "watch"}, APIGroups: []string{""}, Resources: []string{"pods", "services"}},
+				{Verbs: []string{"*"}, APIGroups: []string{"apps"}, Resources: []string{"*"}},
+				{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"configmaps"}},
+				{Verbs: []string{"list"}, APIGroups: []string{""}, Resources: []string{"secrets"},
+					ResourceNames: []string{"token"}},
+			}
+			return true, review, nil
+		})
+
+	cases := []struct {
+		namespace string
+		expected  map[string]bool
+	}{
+		{"team-a", map[string]bool{
+			"pods": true, "deployments": true, "services": true, "configmaps": false, "secrets": false}},
+		{"other", map[string]bool{
+			"pods": false, "deployments": false, "services": false, "configmaps": false, "secrets": false}},
+	}
+
+	for _, c := range cases {
+		actual, err := namespaceAccessSummary(client, c.namespace)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Expected access to %s namespace to be %v, got %v", c.namespace, c.expected, actual)
+		}
+	}
+}
+
+func TestNamespaceDenylist(t *testing.T) {
+	denylist := regexp.MustCompile("^kube-")
+	names := []string{"default", "kube-system", "team-a"}
+	cases := []struct {
+		info     string
+		denied   []v1.ResourceAttributes
+		expected []string
+	}{
+		{"cluster admin sees all namespaces", nil, names},
+		{"non-admin does not see denied namespaces",
+			[]v1.ResourceAttributes{{Verb: "*", Group: "*", Resource: "*"}}, []string{"default", "team-a"}},
+	}
+
+	for _, c := range cases {
+		actual, err := namespaceDenylist(newAccessReviewClient(c.denied...), denylist)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.info, err)
+		}
+
+		if filtered := namespace.FilterDeniedNamespaces(names, actual); !reflect.DeepEqual(filtered, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.info, c.expected, filtered)
+		}
+	}
+
+	client := newAccessReviewClient(v1.ResourceAttributes{Verb: "*", Group: "*", Resource: "*"})
+	if actual, _ := namespaceDenylist(client, nil); actual != nil || len(client.Actions()) != 0 {
+		t.Error("Expected no access review without configured denylist")
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/node"
+)
+
+// SetNodeSchedulable cordons or uncordons the node using credentials of the user.
+func (self *clientManager) SetNodeSchedulable(req *restful.Request, nodeName string, schedulable bool) error {
+	client, err := self.Client(req)
+	if err != nil {
+		return err
+	}
+
+	return node.SetNodeSchedulable(client, nodeName, schedulable)
+}
+
+// NodeDrainReadiness returns pods that would block drain of the node using credentials of the user. See
+// node.GetNodeDrainReadiness for more information.
+func (self *clientManager) NodeDrainReadiness(req *restful.Request, nodeName string) (*node.DrainReadiness, error) {
+	client, err := self.Client(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return node.GetNodeDrainReadiness(client, nodeName)
+}
+
+// NodeStatsSummary returns stats summary of the kubelet of the node using credentials of the user. See
+// node.GetNodeStatsSummary for more information.
+func (self *clientManager) NodeStatsSummary(req *restful.Request, nodeName string) (*node.StatsSummary, error) {
+	client, err := self.Client(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return node.GetNodeStatsSummary(client, nodeName)
+}
+
+// NodeAllocationSummary returns requests and limits of the pods scheduled on the node compared to its allocatable
+// resources using credentials of the user. See node.GetNodeAllocationSummary for more information.
+func (self *clientManager) NodeAllocationSummary(req *restful.Request, nodeName string) (*node.AllocationSummary,
+	error) {
+	client, err := self.Client(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return node.GetNodeAllocationSummary(client, nodeName)
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"sync"
+	"time"
+
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+	"k8s.io/client-go/discovery"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+const (
+	// Time for which OpenAPI schema retrieved from the apiserver is cached. Schema changes only when APIs served by
+	// the apiserver change, i.e. when CRDs are added, so it can be cached for a long time.
+	OpenAPISchemaCacheTTL = 10 * time.Minute
+)
+
+// openAPISchemaCache holds a single, shared copy of the OpenAPI schema. Schema documents are large so only one copy of
+// it is kept in memory and concurrent requests wait for a single fetch instead of downloading it in parallel.
+type openAPISchemaCache struct {
+	mux     sync.Mutex
+	ttl     time.Duration
+	schema  *openapi_v2.Document
+	expires time.Time
+	// Used to get current time. Can be overridden in tests.
+	now func() time.Time
+}
+
+// Get returns cached schema or fetches it using provided discovery client if it is missing or has expired.
+func (self *openAPISchemaCache) Get(client discovery.OpenAPISchemaInterface) (*openapi_v2.Document, error) {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	if self.schema != nil && self.now().Before(self.expires) {
+		return self.schema, nil
+	}
+
+	schema, err := client.OpenAPISchema()
+	if err != nil {
+		return nil, err
+	}
+
+	self.schema = schema
+	self.expires = self.now().Add(self.ttl)
+	return schema, nil
+}
+
+func newOpenAPISchemaCache(ttl time.Duration) *openAPISchemaCache {
+	return &openAPISchemaCache{ttl: ttl, now: time.Now}
+}
+
+// OpenAPISchema returns OpenAPI v2 schema of the APIs served by the apiserver. It can be used to generate forms for
+// arbitrary resources. Schema is shared between users and cached for OpenAPISchemaCacheTTL.
+func (self *clientManager) OpenAPISchema(req *restful.Request) (*openapi_v2.Document, error) {
+	client, err := self.Client(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.openAPISchemaCache.Get(client.Discovery())
+}
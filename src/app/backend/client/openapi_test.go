@@ -0,0 +1,54 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+)
+
+type fakeOpenAPISchemaClient struct {
+	calls int
+}
+
+func (self *fakeOpenAPISchemaClient) OpenAPISchema() (*openapi_v2.Document, error) {
+	self.calls++
+	return &openapi_v2.Document{Swagger: "2.0"}, nil
+}
+
+func TestOpenAPISchemaCache(t *testing.T) {
+	now := time.Now()
+	client := &fakeOpenAPISchemaClient{}
+	cache := newOpenAPISchemaCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	first, err := cache.Get(client)
+	if err != nil {
+		t.Fatalf("Get(): unexpected error: %s", err.Error())
+	}
+
+	second, _ := cache.Get(client)
+	if first != second || client.calls != 1 {
+		t.Fatalf("Get(): expected cached schema to be reused, but schema was fetched %d times", client.calls)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err = cache.Get(client); err != nil || client.calls != 2 {
+		t.Fatalf("Get(): expected schema to be fetched again after expiration, but it was fetched %d times",
+			client.calls)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/emicklei/go-restful/v3"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+// MaxOwnerChainLength limits the number of owners resolved for a single object.
+const MaxOwnerChainLength = 10
+
+// ResolveOwners returns chain of the object owners using credentials of the user, i.e. ReplicaSet and Deployment
+// of the pod. Controller reference is followed if object has more than one owner. Chain ends at the owner that
+// has no owners itself, at the owner that does not exist, which is reported as missing, or when a cycle is detected.
+func (self *clientManager) ResolveOwners(req *restful.Request, obj metaV1.Object, namespace string) (
+	[]clientapi.OwnerRef, error) {
+	cfg, err := self.Config(req)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveOwners(client, self.restMapper(), obj, namespace)
+}
+
+func resolveOwners(client dynamic.Interface, mapper meta.ResettableRESTMapper, obj metaV1.Object,
+	namespace string) ([]clientapi.OwnerRef, error) {
+	result := make([]clientapi.OwnerRef, 0)
+	visited := map[types.UID]bool{obj.GetUID(): true}
+
+	for current := obj; len(result) < MaxOwnerChainLength; {
+		ref := ownerToFollow(current.GetOwnerReferences())
+		if ref == nil || visited[ref.UID] {
+			break
+		}
+		visited[ref.UID] = true
+
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			return nil, err
+		}
+
+		mapping, err := restMapping(mapper, gv.WithKind(ref.Kind))
+		if err != nil {
+			return nil, err
+		}
+
+		owner := clientapi.OwnerRef{APIVersion: ref.APIVersion, Kind: ref.Kind, Name: ref.Name, UID: ref.UID}
+		var resource dynamic.ResourceInterface = client.Resource(mapping.Resource)
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			owner.Namespace = namespace
+			resource = client.Resource(mapping.Resource).Namespace(namespace)
+		}
+
+		object, err := resource.Get(context.TODO(), ref.Name, metaV1.GetOptions{})
+		if k8serrors.IsNotFound(err) || (err == nil && object.GetUID() != ref.UID) {
+			owner.Missing = true
+			result = append(result, owner)
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, owner)
+		current = object
+	}
+
+	return result, nil
+}
+
+// Returns controller reference or the first owner reference if none of the owners is a controller.
+func ownerToFollow(refs []metaV1.OwnerReference) *metaV1.OwnerReference {
+	if ref := metaV1.GetControllerOfNoCopy(&metaV1.ObjectMeta{OwnerReferences: refs}); ref != nil {
+		return ref
+	}
+
+	if len(refs) > 0 {
+		return &refs[0]
+	}
+
+	return nil
+}
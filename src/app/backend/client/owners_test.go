@@ -0,0 +1,104 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+func newOwnedObject(apiVersion, kind, name string, uid types.UID, owners ...metaV1.OwnerReference) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	obj.SetUID(uid)
+	obj.SetOwnerReferences(owners)
+	return obj
+}
+
+func newOwnerReference(apiVersion, kind, name string, uid types.UID, controller bool) metaV1.OwnerReference {
+	return metaV1.OwnerReference{APIVersion: apiVersion, Kind: kind, Name: name, UID: uid, Controller: &controller}
+}
+
+func TestResolveOwners(t *testing.T) {
+	deploymentRef := newOwnerReference("apps/v1", "Deployment", "deploy", "deploy-uid", true)
+	replicaSetRef := newOwnerReference("apps/v1", "ReplicaSet", "rs", "rs-uid", true)
+	missingRef := newOwnerReference("apps/v1", "ReplicaSet", "missing", "missing-uid", true)
+	cycleRef := newOwnerReference("apps/v1", "ReplicaSet", "cycle", "cycle-uid", true)
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(),
+		newOwnedObject("apps/v1", "Deployment", "deploy", "deploy-uid"),
+		newOwnedObject("apps/v1", "ReplicaSet", "rs", "rs-uid", deploymentRef),
+		newOwnedObject("apps/v1", "ReplicaSet", "cycle", "cycle-uid", cycleRef),
+	)
+
+	cases := []struct {
+		info     string
+		obj      metaV1.Object
+		expected []clientapi.OwnerRef
+	}{
+		{
+			"should resolve pod to replica set to deployment chain",
+			newOwnedObject("v1", "Pod", "pod", "pod-uid",
+				newOwnerReference("v1", "ConfigMap", "not-controller", "cm-uid", false), replicaSetRef),
+			[]clientapi.OwnerRef{
+				{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "rs", Namespace: "default", UID: "rs-uid"},
+				{APIVersion: "apps/v1", Kind: "Deployment", Name: "deploy", Namespace: "default", UID: "deploy-uid"},
+			},
+		},
+		{
+			"should report missing owner",
+			newOwnedObject("v1", "Pod", "pod", "pod-uid", missingRef),
+			[]clientapi.OwnerRef{
+				{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "missing", Namespace: "default", UID: "missing-uid",
+					Missing: true},
+			},
+		},
+		{
+			"should stop when cycle is detected",
+			newOwnedObject("v1", "Pod", "pod", "pod-uid", cycleRef),
+			[]clientapi.OwnerRef{
+				{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "cycle", Namespace: "default", UID: "cycle-uid"},
+			},
+		},
+		{
+			"should return empty chain for object without owners",
+			newOwnedObject("v1", "Pod", "pod", "pod-uid"),
+			[]clientapi.OwnerRef{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.info, func(t *testing.T) {
+			actual, err := resolveOwners(client, newTestRESTMapper(), c.obj, "default")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("Expected %+v, got %+v", c.expected, actual)
+			}
+		})
+	}
+}
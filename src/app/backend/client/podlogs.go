@@ -0,0 +1,180 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+	resourcecontainer "github.com/CAPS-Cloud/dashboard/src/app/backend/resource/container"
+)
+
+// LogTailLinesCappedHeader is set on log responses whose number of tail lines was capped, see LimitTailLines.
+const LogTailLinesCappedHeader = "X-Log-Tail-Lines-Capped"
+
+// StreamPodLogs opens a stream of the container logs using credentials of the user. Logs of the default container
+// are streamed if container is empty, see container.DefaultContainerName. Number of tail lines is limited by the
+// 'default-log-tail-lines' and 'max-log-tail-lines' arguments, see IsTailLinesCapped. Stream is terminated when the
+// request is cancelled, in which case reading from it returns io.EOF. Caller is responsible for closing returned
+// stream.
+func (self *clientManager) StreamPodLogs(req *restful.Request, namespace, pod, container string,
+	opts clientapi.LogStreamOptions) (io.ReadCloser, error) {
+	client, err := self.Client(req)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, capped := limitTailLines(opts, args.Holder.GetDefaultLogTailLines(), args.Holder.GetMaxLogTailLines())
+	stream, err := streamPodLogs(req.Request.Context(), client, namespace, pod, container, opts)
+	return withTailLinesCapped(stream, err, capped)
+}
+
+// LimitTailLines applies the 'default-log-tail-lines' and 'max-log-tail-lines' arguments to the number of tail lines
+// requested by the client. Returns true as the second value if the requested number was capped.
+func LimitTailLines(tailLines *int64) (*int64, bool) {
+	opts := clientapi.LogStreamOptions{TailLines: tailLines}
+	opts, capped := limitTailLines(opts, args.Holder.GetDefaultLogTailLines(), args.Holder.GetMaxLogTailLines())
+	return opts.TailLines, capped
+}
+
+// IsTailLinesCapped returns true if the log stream does not include all requested tail lines, because the request
+// exceeded the 'max-log-tail-lines' argument.
+func IsTailLinesCapped(stream io.ReadCloser) bool {
+	_, capped := stream.(*cappedLogStream)
+	return capped
+}
+
+// Applies the default number of tail lines if none is requested and caps the requested number to the max. Returns
+// true as the second value only if the requested number was lowered. Limits lower than 1 are not applied.
+func limitTailLines(opts clientapi.LogStreamOptions, defaultLines, maxLines int) (clientapi.LogStreamOptions, bool) {
+	if opts.TailLines == nil {
+		if defaultLines > 0 {
+			lines := int64(defaultLines)
+			if maxLines > 0 && lines > int64(maxLines) {
+				lines = int64(maxLines)
+			}
+			opts.TailLines = &lines
+		}
+		return opts, false
+	}
+
+	if maxLines > 0 && *opts.TailLines > int64(maxLines) {
+		lines := int64(maxLines)
+		opts.TailLines = &lines
+		return opts, true
+	}
+
+	return opts, false
+}
+
+// cappedLogStream marks the log stream whose tail lines were capped.
+type cappedLogStream struct {
+	io.ReadCloser
+}
+
+func withTailLinesCapped(stream io.ReadCloser, err error, capped bool) (io.ReadCloser, error) {
+	if err != nil || !capped {
+		return stream, err
+	}
+
+	return &cappedLogStream{ReadCloser: stream}, nil
+}
+
+func streamPodLogs(ctx context.Context, client kubernetes.Interface, namespace, podName, container string,
+	opts clientapi.LogStreamOptions) (io.ReadCloser, error) {
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, podName, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(container) == 0 {
+		container = resourcecontainer.DefaultContainerName(pod)
+	}
+
+	if err := checkContainerLogsAvailable(pod, container); err != nil {
+		return nil, err
+	}
+
+	logOptions := &v1.PodLogOptions{
+		Container:  container,
+		Follow:     opts.Follow,
+		Timestamps: opts.Timestamps,
+		TailLines:  opts.TailLines,
+	}
+
+	if opts.SinceTime != nil {
+		sinceTime := metaV1.NewTime(*opts.SinceTime)
+		logOptions.SinceTime = &sinceTime
+	}
+
+	stream, err := client.CoreV1().Pods(namespace).GetLogs(podName, logOptions).Stream(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &logStream{ReadCloser: stream, ctx: ctx}, nil
+}
+
+// Checks if the container exists and has already started, so that its logs can be read. Containers that are waiting
+// but were restarted still have logs of the previous run.
+func checkContainerLogsAvailable(pod *v1.Pod, container string) error {
+	statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...),
+		pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.Name != container {
+			continue
+		}
+
+		if status.State.Waiting != nil && status.RestartCount == 0 && status.LastTerminationState.Terminated == nil {
+			return errors.NewBadRequest(fmt.Sprintf("container %s in pod %s is not ready: %s", container, pod.Name,
+				status.State.Waiting.Reason))
+		}
+
+		return nil
+	}
+
+	for _, c := range append(append([]v1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+		if c.Name == container {
+			return errors.NewBadRequest(fmt.Sprintf("container %s in pod %s is not ready", container, pod.Name))
+		}
+	}
+
+	return errors.NewNotFound(fmt.Sprintf("container %s not found in pod %s", container, pod.Name))
+}
+
+// logStream reports end of the stream instead of an error when it is terminated by cancelled context.
+type logStream struct {
+	io.ReadCloser
+	ctx context.Context
+}
+
+func (self *logStream) Read(p []byte) (int, error) {
+	n, err := self.ReadCloser.Read(p)
+	if err != nil && self.ctx.Err() != nil {
+		return n, io.EOF
+	}
+
+	return n, err
+}
@@ -0,0 +1,215 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	clientTesting "k8s.io/client-go/testing"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/container"
+)
+
+func newLogsPod(statuses ...v1.ContainerStatus) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "pod", Namespace: "default"},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "app"}, {Name: "sidecar"}}},
+		Status:     v1.PodStatus{ContainerStatuses: statuses},
+	}
+}
+
+func TestStreamPodLogs(t *testing.T) {
+	running := v1.ContainerStatus{Name: "app", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}}
+	client := fake.NewSimpleClientset(newLogsPod(running))
+	tailLines := int64(10)
+	sinceTime := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	stream, err := streamPodLogs(context.TODO(), client, "default", "pod", "", clientapi.LogStreamOptions{
+		Follow:     true,
+		Timestamps: true,
+		SinceTime:  &sinceTime,
+		TailLines:  &tailLines,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	logs, err := io.ReadAll(stream)
+	if err != nil || string(logs) != "fake logs" {
+		t.Fatalf("Expected canned logs to be streamed, but got %s (%v)", logs, err)
+	}
+
+	var actual *v1.PodLogOptions
+	for _, action := range client.Actions() {
+		if action.GetSubresource() == "log" {
+			actual = action.(clientTesting.GenericAction).GetValue().(*v1.PodLogOptions)
+		}
+	}
+
+	since := metaV1.NewTime(sinceTime)
+	expected := &v1.PodLogOptions{Container: "app", Follow: true, Timestamps: true, SinceTime: &since,
+		TailLines: &tailLines}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected log options %#v, but got %#v", expected, actual)
+	}
+}
+
+func TestStreamPodLogsContainerNotReady(t *testing.T) {
+	waiting := v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ContainerCreating"}}
+	cases := []struct {
+		info      string
+		pod       *v1.Pod
+		container string
+		check     func(error) bool
+	}{
+		{"waiting container", newLogsPod(v1.ContainerStatus{Name: "app", State: waiting}), "app",
+			errors.IsBadRequest},
+		{"container without status", newLogsPod(), "sidecar", errors.IsBadRequest},
+		{"missing container", newLogsPod(), "missing", errors.IsNotFoundError},
+		{"restarted container", newLogsPod(v1.ContainerStatus{Name: "app", State: waiting, RestartCount: 1}), "app",
+			func(err error) bool { return err == nil }},
+	}
+
+	for _, c := range cases {
+		_, err := streamPodLogs(context.TODO(), fake.NewSimpleClientset(c.pod), "default", "pod", c.container,
+			clientapi.LogStreamOptions{})
+		if !c.check(err) {
+			t.Errorf("%s: unexpected error %v", c.info, err)
+		}
+	}
+}
+
+func TestStreamPodLogsDefaultContainer(t *testing.T) {
+	running := v1.ContainerState{Running: &v1.ContainerStateRunning{}}
+	cases := []struct {
+		annotation string
+		expected   string
+	}{
+		{"sidecar", "sidecar"},
+		{"missing", "app"},
+		{"", "app"},
+	}
+
+	for _, c := range cases {
+		pod := newLogsPod(v1.ContainerStatus{Name: "app", State: running},
+			v1.ContainerStatus{Name: "sidecar", State: running})
+		if len(c.annotation) > 0 {
+			pod.Annotations = map[string]string{container.DefaultContainerAnnotation: c.annotation}
+		}
+		client := fake.NewSimpleClientset(pod)
+
+		stream, err := streamPodLogs(context.TODO(), client, "default", "pod", "", clientapi.LogStreamOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		stream.Close()
+
+		for _, action := range client.Actions() {
+			if action.GetSubresource() != "log" {
+				continue
+			}
+
+			actual := action.(clientTesting.GenericAction).GetValue().(*v1.PodLogOptions).Container
+			if actual != c.expected {
+				t.Errorf("Expected logs of %s for annotation %q, but got %s", c.expected, c.annotation, actual)
+			}
+		}
+	}
+}
+
+type failingReadCloser struct{}
+
+func (failingReadCloser) Read([]byte) (int, error) { return 0, context.Canceled }
+func (failingReadCloser) Close() error             { return nil }
+
+func TestLogStreamCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &logStream{ReadCloser: failingReadCloser{}, ctx: ctx}
+
+	if _, err := stream.Read(make([]byte, 1)); err != context.Canceled {
+		t.Fatalf("Expected error to be returned before context is cancelled, but got %v", err)
+	}
+
+	cancel()
+	if _, err := stream.Read(make([]byte, 1)); err != io.EOF {
+		t.Fatalf("Expected EOF after context is cancelled, but got %v", err)
+	}
+}
+
+func TestLimitTailLines(t *testing.T) {
+	lines := func(n int64) *int64 { return &n }
+	cases := []struct {
+		requested      *int64
+		defaultLines   int
+		maxLines       int
+		expected       *int64
+		expectedCapped bool
+	}{
+		{nil, 1000, 10000, lines(1000), false},
+		{lines(50), 1000, 10000, lines(50), false},
+		{lines(20000), 1000, 10000, lines(10000), true},
+		{nil, 0, 10000, nil, false},
+		{nil, 20000, 10000, lines(10000), false},
+		{lines(10000), 1000, 10000, lines(10000), false},
+		{nil, 0, 0, nil, false},
+		{lines(20000), 0, 0, lines(20000), false},
+	}
+
+	for _, c := range cases {
+		opts, capped := limitTailLines(clientapi.LogStreamOptions{TailLines: c.requested}, c.defaultLines,
+			c.maxLines)
+		if capped != c.expectedCapped || !reflect.DeepEqual(opts.TailLines, c.expected) {
+			t.Errorf("Expected tail lines %v (capped %t) for %v with default %d and max %d, but got %v (%t)",
+				c.expected, c.expectedCapped, c.requested, c.defaultLines, c.maxLines, opts.TailLines, capped)
+		}
+	}
+}
+
+func TestStreamPodLogsCappedTailLines(t *testing.T) {
+	running := v1.ContainerStatus{Name: "app", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}}
+	client := fake.NewSimpleClientset(newLogsPod(running))
+	requested := int64(50000)
+
+	opts, capped := limitTailLines(clientapi.LogStreamOptions{TailLines: &requested}, 1000, 10000)
+	stream, err := streamPodLogs(context.TODO(), client, "default", "pod", "app", opts)
+	stream, err = withTailLinesCapped(stream, err, capped)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	if !IsTailLinesCapped(stream) {
+		t.Error("Expected stream to be marked as capped")
+	}
+
+	for _, action := range client.Actions() {
+		if action.GetSubresource() == "log" {
+			actual := action.(clientTesting.GenericAction).GetValue().(*v1.PodLogOptions).TailLines
+			if actual == nil || *actual != 10000 {
+				t.Errorf("Expected tail lines to be clamped to 10000, but got %v", actual)
+			}
+		}
+	}
+}
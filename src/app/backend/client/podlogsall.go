@@ -0,0 +1,187 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+const (
+	// MaxContainerLogStreams is the maximum number of container log streams opened at once for a single pod.
+	MaxContainerLogStreams = 8
+)
+
+// How often containers that did not start yet are checked when following logs of all containers.
+var containerLogsPollInterval = 2 * time.Second
+
+// StreamPodLogsAllContainers opens a single stream of logs of all init and regular containers of the pod using
+// credentials of the user. Every line is prefixed with the container name, i.e. '[sidecar] ', and lines of
+// different containers are interleaved in order of arrival. When following, containers that did not start yet are
+// streamed once they start. Otherwise, they are skipped. Number of tail lines of every container is limited the
+// same way as in StreamPodLogs. Caller is responsible for closing returned stream.
+func (self *clientManager) StreamPodLogsAllContainers(req *restful.Request, namespace, pod string,
+	opts clientapi.LogStreamOptions) (io.ReadCloser, error) {
+	client, err := self.Client(req)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, capped := limitTailLines(opts, args.Holder.GetDefaultLogTailLines(), args.Holder.GetMaxLogTailLines())
+	stream, err := streamPodLogsAllContainers(req.Request.Context(), client, namespace, pod, opts,
+		MaxContainerLogStreams)
+	return withTailLinesCapped(stream, err, capped)
+}
+
+// Streams logs of the containers in order of the pod spec, at most maxStreams at once. Remaining containers are
+// streamed once earlier streams end, i.e. when init containers complete. First error of any stream terminates the
+// whole stream and is returned by the reader.
+func streamPodLogsAllContainers(ctx context.Context, client kubernetes.Interface, namespace, podName string,
+	opts clientapi.LogStreamOptions, maxStreams int) (io.ReadCloser, error) {
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, podName, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	containers := make([]string, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers))
+	for _, container := range append(append([]v1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+		containers = append(containers, container.Name)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	reader, writer := io.Pipe()
+	done := make(chan struct{})
+	var once sync.Once
+	fail := func(err error) {
+		once.Do(func() {
+			writer.CloseWithError(err)
+			cancel()
+		})
+	}
+
+	go func() {
+		var wg sync.WaitGroup
+		defer func() {
+			wg.Wait()
+			writer.Close()
+			close(done)
+		}()
+
+		semaphore := make(chan struct{}, maxStreams)
+		for _, container := range containers {
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			wg.Add(1)
+			go func(container string) {
+				defer wg.Done()
+				defer func() { <-semaphore }()
+
+				err := copyContainerLogs(ctx, client, namespace, podName, container, opts, writer)
+				if err != nil && ctx.Err() == nil {
+					fail(err)
+				}
+			}(container)
+		}
+	}()
+
+	return &mergedLogStream{PipeReader: reader, cancel: cancel, done: done}, nil
+}
+
+// Writes lines of the container logs prefixed with the container name. Every line is written at once, so that
+// lines of different containers are never mixed.
+func copyContainerLogs(ctx context.Context, client kubernetes.Interface, namespace, podName, container string,
+	opts clientapi.LogStreamOptions, writer io.Writer) error {
+	stream, err := openContainerLogs(ctx, client, namespace, podName, container, opts)
+	if errors.IsBadRequest(err) && !opts.Follow {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	prefix := "[" + container + "] "
+	lines := bufio.NewReader(stream)
+	for {
+		line, err := lines.ReadString('\n')
+		if len(line) > 0 {
+			if !strings.HasSuffix(line, "\n") {
+				line += "\n"
+			}
+
+			if _, err := io.WriteString(writer, prefix+line); err != nil {
+				return err
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Opens logs of the container. When following, containers that did not start yet are polled until they start.
+func openContainerLogs(ctx context.Context, client kubernetes.Interface, namespace, podName, container string,
+	opts clientapi.LogStreamOptions) (io.ReadCloser, error) {
+	for {
+		stream, err := streamPodLogs(ctx, client, namespace, podName, container, opts)
+		if !opts.Follow || !errors.IsBadRequest(err) {
+			return stream, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(containerLogsPollInterval):
+		}
+	}
+}
+
+// mergedLogStream stops all container log streams when closed and waits until they are stopped.
+type mergedLogStream struct {
+	*io.PipeReader
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func (self *mergedLogStream) Close() error {
+	self.cancel()
+	err := self.PipeReader.Close()
+	<-self.done
+	return err
+}
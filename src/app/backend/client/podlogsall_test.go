@@ -0,0 +1,129 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+func readLogLines(t *testing.T, stream io.ReadCloser) []string {
+	defer stream.Close()
+	logs, err := io.ReadAll(stream)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(logs), "\n"), "\n")
+	sort.Strings(lines)
+	return lines
+}
+
+func TestStreamPodLogsAllContainers(t *testing.T) {
+	running := v1.ContainerState{Running: &v1.ContainerStateRunning{}}
+	pod := newLogsPod(
+		v1.ContainerStatus{Name: "app", State: running},
+		v1.ContainerStatus{Name: "sidecar", State: running},
+	)
+	pod.Spec.InitContainers = []v1.Container{{Name: "init"}}
+	pod.Status.InitContainerStatuses = []v1.ContainerStatus{{Name: "init",
+		State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Completed"}}}}
+
+	expected := []string{"[app] fake logs", "[init] fake logs", "[sidecar] fake logs"}
+	for _, maxStreams := range []int{1, MaxContainerLogStreams} {
+		stream, err := streamPodLogsAllContainers(context.TODO(), fake.NewSimpleClientset(pod), "default", "pod",
+			clientapi.LogStreamOptions{}, maxStreams)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if lines := readLogLines(t, stream); !reflect.DeepEqual(lines, expected) {
+			t.Errorf("Expected %v with %d streams, got %v", expected, maxStreams, lines)
+		}
+	}
+}
+
+func TestStreamPodLogsAllContainersNotStarted(t *testing.T) {
+	running := v1.ContainerState{Running: &v1.ContainerStateRunning{}}
+	waiting := v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ContainerCreating"}}
+	pod := newLogsPod(
+		v1.ContainerStatus{Name: "app", State: running},
+		v1.ContainerStatus{Name: "sidecar", State: waiting},
+	)
+
+	stream, err := streamPodLogsAllContainers(context.TODO(), fake.NewSimpleClientset(pod), "default", "pod",
+		clientapi.LogStreamOptions{}, MaxContainerLogStreams)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if lines := readLogLines(t, stream); !reflect.DeepEqual(lines, []string{"[app] fake logs"}) {
+		t.Errorf("Expected container that did not start to be skipped, got %v", lines)
+	}
+
+	defer func(interval time.Duration) { containerLogsPollInterval = interval }(containerLogsPollInterval)
+	containerLogsPollInterval = 10 * time.Millisecond
+
+	client := fake.NewSimpleClientset(pod)
+	stream, err = streamPodLogsAllContainers(context.TODO(), client, "default", "pod",
+		clientapi.LogStreamOptions{Follow: true}, MaxContainerLogStreams)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	buf := make([]byte, len("[app] fake logs\n"))
+	if _, err := io.ReadFull(stream, buf); err != nil || string(buf) != "[app] fake logs\n" {
+		t.Fatalf("Expected logs of the running container first, got %q (%v)", buf, err)
+	}
+
+	started := pod.DeepCopy()
+	started.Status.ContainerStatuses[1].State = running
+	if _, err := client.CoreV1().Pods("default").UpdateStatus(context.TODO(), started,
+		metaV1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if lines := readLogLines(t, stream); !reflect.DeepEqual(lines, []string{"[sidecar] fake logs"}) {
+		t.Errorf("Expected logs of the container once it started, got %v", lines)
+	}
+}
+
+func TestStreamPodLogsAllContainersClose(t *testing.T) {
+	waiting := v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ContainerCreating"}}
+	pod := newLogsPod(v1.ContainerStatus{Name: "app", State: waiting}, v1.ContainerStatus{Name: "sidecar",
+		State: waiting})
+
+	stream, err := streamPodLogsAllContainers(context.TODO(), fake.NewSimpleClientset(pod), "default", "pod",
+		clientapi.LogStreamOptions{Follow: true}, MaxContainerLogStreams)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	stream.Close()
+	if _, err := stream.Read(make([]byte, 1)); err != io.ErrClosedPipe {
+		t.Errorf("Expected closed stream, got %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/namespace"
+)
+
+// PodSecurityLevels returns pod security admission levels of the namespaces visible to the user, keyed by the
+// namespace name. Modes not configured on the namespace are reported as unset.
+func (self *clientManager) PodSecurityLevels(req *restful.Request) (map[string]namespace.PSALevels, error) {
+	client, err := self.Client(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return namespace.GetPodSecurityLevels(client)
+}
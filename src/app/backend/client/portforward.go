@@ -0,0 +1,242 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+// PortForward opens port forwarding session to the port of the pod over SPDY using credentials of the user. Data
+// written to the returned stream is sent to the port and data received from it can be read. Session is closed when
+// the pod is deleted or the connection is lost, in which case reads return io.EOF. Number of concurrent sessions
+// per user can be limited with 'max-port-forwards-per-user' argument.
+func (self *clientManager) PortForward(req *restful.Request, namespace, pod string, port int32) (io.ReadWriteCloser,
+	error) {
+	cfg, err := self.Config(req)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	url := client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("portforward").
+		URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", url)
+
+	userKey := self.userCacheKey(req)
+	if !self.portForwardLimiter.acquire(userKey, args.Holder.GetMaxPortForwardsPerUser()) {
+		return nil, errors.NewTooManyRequests("too many concurrent port forwarding sessions")
+	}
+
+	session, err := portForward(client, dialer, namespace, pod, port, func() {
+		self.portForwardLimiter.release(userKey)
+	})
+	if err != nil {
+		self.portForwardLimiter.release(userKey)
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func portForward(client kubernetes.Interface, dialer httpstream.Dialer, namespace, podName string, port int32,
+	onClose func()) (*portForwardSession, error) {
+	pod, err := client.CoreV1().Pods(namespace).Get(context.TODO(), podName, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if pod.Status.Phase != v1.PodRunning {
+		return nil, errors.NewBadRequest(fmt.Sprintf("unable to forward port because pod is not running, current "+
+			"status: %s", pod.Status.Phase))
+	}
+
+	// Deletion of the pod is watched as the kubelet does not always close the connection right away.
+	watcher, err := client.CoreV1().Pods(namespace).Watch(context.TODO(), metaV1.ListOptions{
+		FieldSelector:   fields.OneTermEqualSelector("metadata.name", podName).String(),
+		ResourceVersion: pod.ResourceVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	conn, _, err := dialer.Dial(portforward.PortForwardProtocolV1Name)
+	if err != nil {
+		watcher.Stop()
+		return nil, err
+	}
+
+	session, err := newPortForwardSession(conn, port, watcher, onClose)
+	if err != nil {
+		watcher.Stop()
+		conn.Close()
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// portForwardSession implements io.ReadWriteCloser on top of the data stream of the forwarded port.
+type portForwardSession struct {
+	conn        httpstream.Connection
+	errorStream httpstream.Stream
+	dataStream  httpstream.Stream
+	watcher     watch.Interface
+	onClose     func()
+	closed      chan struct{}
+	closeOnce   sync.Once
+}
+
+func newPortForwardSession(conn httpstream.Connection, port int32, watcher watch.Interface,
+	onClose func()) (*portForwardSession, error) {
+	headers := http.Header{}
+	headers.Set(v1.StreamType, v1.StreamTypeError)
+	headers.Set(v1.PortHeader, strconv.Itoa(int(port)))
+	headers.Set(v1.PortForwardRequestIDHeader, "0")
+	errorStream, err := conn.CreateStream(headers)
+	if err != nil {
+		return nil, err
+	}
+	// Error stream is only read from.
+	errorStream.Close()
+
+	headers.Set(v1.StreamType, v1.StreamTypeData)
+	dataStream, err := conn.CreateStream(headers)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &portForwardSession{
+		conn:        conn,
+		errorStream: errorStream,
+		dataStream:  dataStream,
+		watcher:     watcher,
+		onClose:     onClose,
+		closed:      make(chan struct{}),
+	}
+	go session.monitor()
+	return session, nil
+}
+
+// Closes the session when the pod is deleted, the connection is lost or an error is reported by the kubelet.
+func (self *portForwardSession) monitor() {
+	errorCh := make(chan error, 1)
+	go func() {
+		message, err := io.ReadAll(self.errorStream)
+		switch {
+		case err != nil:
+			errorCh <- err
+		case len(message) > 0:
+			errorCh <- fmt.Errorf("%s", message)
+		}
+	}()
+
+	events := self.watcher.ResultChan()
+	for {
+		select {
+		case <-self.closed:
+			return
+		case <-self.conn.CloseChan():
+			self.Close()
+			return
+		case err := <-errorCh:
+			log.Printf("Port forwarding failed: %s", err.Error())
+			self.Close()
+			return
+		case event, ok := <-events:
+			if !ok {
+				// Connection is still monitored when the watch expires.
+				events = nil
+				continue
+			}
+
+			if event.Type == watch.Deleted {
+				self.Close()
+				return
+			}
+		}
+	}
+}
+
+// Read implements io.Reader interface. Returns io.EOF once the session is closed.
+func (self *portForwardSession) Read(p []byte) (int, error) {
+	n, err := self.dataStream.Read(p)
+	if err != nil && self.isClosed() {
+		return n, io.EOF
+	}
+	return n, err
+}
+
+// Write implements io.Writer interface.
+func (self *portForwardSession) Write(p []byte) (int, error) {
+	if self.isClosed() {
+		return 0, io.ErrClosedPipe
+	}
+	return self.dataStream.Write(p)
+}
+
+// Close implements io.Closer interface.
+func (self *portForwardSession) Close() error {
+	var err error
+	self.closeOnce.Do(func() {
+		close(self.closed)
+		self.watcher.Stop()
+		self.conn.RemoveStreams(self.errorStream, self.dataStream)
+		err = self.conn.Close()
+		self.onClose()
+	})
+	return err
+}
+
+func (self *portForwardSession) isClosed() bool {
+	select {
+	case <-self.closed:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/portforward"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+type fakePortForwardStream struct {
+	headers http.Header
+	reader  *io.PipeReader
+	writer  *io.PipeWriter
+	mux     sync.Mutex
+	written bytes.Buffer
+}
+
+func newFakePortForwardStream(headers http.Header) *fakePortForwardStream {
+	reader, writer := io.Pipe()
+	return &fakePortForwardStream{headers: headers, reader: reader, writer: writer}
+}
+
+func (self *fakePortForwardStream) Read(p []byte) (int, error) {
+	return self.reader.Read(p)
+}
+
+func (self *fakePortForwardStream) Write(p []byte) (int, error) {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+	return self.written.Write(p)
+}
+
+// Close closes the writing side of the stream only, the same as SPDY streams do.
+func (self *fakePortForwardStream) Close() error {
+	return nil
+}
+
+func (self *fakePortForwardStream) Reset() error {
+	return self.reader.CloseWithError(io.ErrClosedPipe)
+}
+
+func (self *fakePortForwardStream) Headers() http.Header {
+	return self.headers
+}
+
+func (self *fakePortForwardStream) Identifier() uint32 {
+	return 0
+}
+
+type fakePortForwardConnection struct {
+	streams []*fakePortForwardStream
+	closeCh chan bool
+	closed  bool
+}
+
+func (self *fakePortForwardConnection) CreateStream(headers http.Header) (httpstream.Stream, error) {
+	stream := newFakePortForwardStream(headers.Clone())
+	self.streams = append(self.streams, stream)
+	return stream, nil
+}
+
+func (self *fakePortForwardConnection) Close() error {
+	self.closed = true
+	for _, stream := range self.streams {
+		stream.Reset()
+	}
+	return nil
+}
+
+func (self *fakePortForwardConnection) CloseChan() <-chan bool {
+	return self.closeCh
+}
+
+func (self *fakePortForwardConnection) SetIdleTimeout(time.Duration) {}
+
+func (self *fakePortForwardConnection) RemoveStreams(...httpstream.Stream) {}
+
+type fakePortForwardDialer struct {
+	conn      *fakePortForwardConnection
+	protocols []string
+}
+
+func (self *fakePortForwardDialer) Dial(protocols ...string) (httpstream.Connection, string, error) {
+	self.protocols = protocols
+	return self.conn, protocols[0], nil
+}
+
+func newPortForwardTest(phase v1.PodPhase) (*fake.Clientset, *fakePortForwardDialer) {
+	pod := &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+		Status:     v1.PodStatus{Phase: phase},
+	}
+
+	return fake.NewSimpleClientset(pod),
+		&fakePortForwardDialer{conn: &fakePortForwardConnection{closeCh: make(chan bool)}}
+}
+
+func TestPortForward(t *testing.T) {
+	client, dialer := newPortForwardTest(v1.PodRunning)
+	closed := false
+	session, err := portForward(client, dialer, "default", "web", 8080, func() { closed = true })
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer session.Close()
+
+	if len(dialer.protocols) != 1 || dialer.protocols[0] != portforward.PortForwardProtocolV1Name {
+		t.Errorf("Expected %s protocol, got %v", portforward.PortForwardProtocolV1Name, dialer.protocols)
+	}
+
+	streams := dialer.conn.streams
+	if len(streams) != 2 || streams[0].headers.Get(v1.StreamType) != v1.StreamTypeError ||
+		streams[1].headers.Get(v1.StreamType) != v1.StreamTypeData || streams[1].headers.Get(v1.PortHeader) != "8080" {
+		t.Fatalf("Expected error and data streams for port 8080, got %v", streams)
+	}
+
+	if _, err := session.Write([]byte("GET / HTTP/1.1\r\n")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data := streams[1]
+	data.mux.Lock()
+	if data.written.String() != "GET / HTTP/1.1\r\n" {
+		t.Errorf("Expected request to be written to the data stream, got %q", data.written.String())
+	}
+	data.mux.Unlock()
+
+	go data.writer.Write([]byte("HTTP/1.1 200 OK"))
+	buf := make([]byte, 15)
+	if _, err := io.ReadFull(session, buf); err != nil || string(buf) != "HTTP/1.1 200 OK" {
+		t.Errorf("Expected response to be read from the data stream, got %q (%v)", buf, err)
+	}
+
+	if closed {
+		t.Error("Expected session to stay open")
+	}
+}
+
+func TestPortForwardClosedOnPodDeletion(t *testing.T) {
+	client, dialer := newPortForwardTest(v1.PodRunning)
+	released := make(chan struct{})
+	session, err := portForward(client, dialer, "default", "web", 8080, func() { close(released) })
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := client.CoreV1().Pods("default").Delete(context.TODO(), "web", metaV1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-released:
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("Expected session to be closed when the pod is deleted")
+	}
+
+	if _, err := session.Read(make([]byte, 1)); err != io.EOF {
+		t.Errorf("Expected EOF after pod deletion, got %v", err)
+	}
+
+	if _, err := session.Write([]byte("data")); err == nil {
+		t.Error("Expected write to fail after pod deletion")
+	}
+
+	if !dialer.conn.closed {
+		t.Error("Expected connection to be closed")
+	}
+}
+
+func TestPortForwardPodNotRunning(t *testing.T) {
+	client, dialer := newPortForwardTest(v1.PodPending)
+
+	_, err := portForward(client, dialer, "default", "web", 8080, func() {})
+	if !errors.IsBadRequest(err) {
+		t.Errorf("Expected bad request error, got %v", err)
+	}
+
+	if dialer.protocols != nil {
+		t.Error("Expected connection not to be opened")
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+// ProxyRequest forwards GET request for the raw apiserver path, i.e. '/apis/apps/v1/deployments', using credentials
+// of the user and streams back the response. Only paths matching one of the prefixes configured with
+// 'api-proxy-allowed-paths' argument can be requested. Caller is responsible for closing returned stream.
+func (self *clientManager) ProxyRequest(req *restful.Request, path string) (io.ReadCloser, int, error) {
+	client, err := self.Client(req)
+	if err != nil {
+		return nil, http.StatusUnauthorized, err
+	}
+
+	return proxyRequest(client.CoreV1().RESTClient(), path, args.Holder.GetAPIProxyAllowedPaths())
+}
+
+func proxyRequest(client RESTClient, rawPath string, allowedPrefixes []string) (io.ReadCloser, int, error) {
+	cleanPath, err := validateProxyPath(rawPath, allowedPrefixes)
+	if errors.IsBadRequest(err) {
+		return nil, http.StatusBadRequest, err
+	}
+
+	if err != nil {
+		return nil, http.StatusForbidden, err
+	}
+
+	stream, err := client.Get().AbsPath(cleanPath).Stream(context.TODO())
+	if err != nil {
+		return nil, proxyErrorCode(err), err
+	}
+
+	return stream, http.StatusOK, nil
+}
+
+// Normalizes the path and checks if it matches one of the allowed prefixes. Prefixes are matched on the path segment
+// boundaries, so '/apis/apps' allows '/apis/apps/v1' but not '/apis/appsv2'.
+func validateProxyPath(rawPath string, allowedPrefixes []string) (string, error) {
+	if !strings.HasPrefix(rawPath, "/") || strings.ContainsAny(rawPath, "?#") {
+		return "", errors.NewBadRequest(fmt.Sprintf("invalid proxy path: %s", rawPath))
+	}
+
+	cleanPath := path.Clean(rawPath)
+	for _, prefix := range allowedPrefixes {
+		prefix = path.Clean("/" + strings.TrimSpace(prefix))
+		if prefix == "/" || cleanPath == prefix || strings.HasPrefix(cleanPath, prefix+"/") {
+			return cleanPath, nil
+		}
+	}
+
+	return "", k8serrors.NewForbidden(schema.GroupResource{}, cleanPath,
+		fmt.Errorf("path is not allowed to be proxied"))
+}
@@ -0,0 +1,78 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+func TestProxyRequest(t *testing.T) {
+	allowed := []string{"/apis/apps", "/api/v1/namespaces/"}
+	cases := []struct {
+		path         string
+		expectedCode int
+		expectedBody string
+	}{
+		{"/apis/apps", http.StatusOK, "ok"},
+		{"/apis/apps/v1/deployments", http.StatusOK, "ok"},
+		{"/api/v1/namespaces/default/pods", http.StatusOK, "ok"},
+		{"/apis/appsv2/foo", http.StatusForbidden, ""},
+		{"/apis/apps/../../api/v1/secrets", http.StatusForbidden, ""},
+		{"/api/v1/secrets", http.StatusForbidden, ""},
+		{"apis/apps/v1", http.StatusBadRequest, ""},
+		{"/apis/apps/v1?watch=true", http.StatusBadRequest, ""},
+	}
+
+	for _, c := range cases {
+		client := &FakeRESTClient{response: &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("ok")),
+		}}
+
+		stream, code, err := proxyRequest(client, c.path, allowed)
+		if code != c.expectedCode {
+			t.Errorf("Expected code %d for path %s, but got %d (%v)", c.expectedCode, c.path, code, err)
+			continue
+		}
+
+		if c.expectedCode != http.StatusOK {
+			if err == nil || (!k8serrors.IsForbidden(err) && !errors.IsBadRequest(err)) {
+				t.Errorf("Expected forbidden or bad request error for path %s, but got %v", c.path, err)
+			}
+			continue
+		}
+
+		body, err := io.ReadAll(stream)
+		stream.Close()
+		if err != nil || string(body) != c.expectedBody {
+			t.Errorf("Expected body %s for path %s, but got %s (%v)", c.expectedBody, c.path, body, err)
+		}
+	}
+}
+
+func TestProxyRequestDisabled(t *testing.T) {
+	client := &FakeRESTClient{}
+
+	if _, code, _ := proxyRequest(client, "/apis/apps", nil); code != http.StatusForbidden {
+		t.Fatalf("Expected proxy to be disabled without allowed paths, but got code %d", code)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/emicklei/go-restful/v3"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+// dockerConfigEntry is a single registry entry of the docker config. Only presence of the credentials is reported.
+type dockerConfigEntry struct {
+	Username      string `json:"username,omitempty"`
+	Password      string `json:"password,omitempty"`
+	Auth          string `json:"auth,omitempty"`
+	IdentityToken string `json:"identitytoken,omitempty"`
+}
+
+// ValidateImagePullSecrets checks image pull secrets using credentials of the user. Secrets are read and parsed
+// only, no registry is contacted. Statuses are returned in the order of the secret names.
+func (self *clientManager) ValidateImagePullSecrets(req *restful.Request, namespace string,
+	secretNames []string) ([]clientapi.PullSecretStatus, error) {
+	client, err := self.Client(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return validateImagePullSecrets(client, namespace, secretNames)
+}
+
+func validateImagePullSecrets(client kubernetes.Interface, namespace string,
+	secretNames []string) ([]clientapi.PullSecretStatus, error) {
+	result := make([]clientapi.PullSecretStatus, 0, len(secretNames))
+	for _, name := range secretNames {
+		secret, err := client.CoreV1().Secrets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			result = append(result, clientapi.PullSecretStatus{Name: name,
+				Registries: []clientapi.PullSecretRegistry{}, Error: fmt.Sprintf("secret %s not found", name)})
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, toPullSecretStatus(secret))
+	}
+
+	return result, nil
+}
+
+// Parses docker config of the secret. Secrets of both kubernetes.io/dockerconfigjson and the legacy
+// kubernetes.io/dockercfg types are supported. Passwords, auth strings and tokens never leave this function.
+func toPullSecretStatus(secret *v1.Secret) clientapi.PullSecretStatus {
+	status := clientapi.PullSecretStatus{Name: secret.Name, Found: true, Type: string(secret.Type),
+		Registries: []clientapi.PullSecretRegistry{}}
+
+	var entries map[string]dockerConfigEntry
+	switch secret.Type {
+	case v1.SecretTypeDockerConfigJson:
+		data, ok := secret.Data[v1.DockerConfigJsonKey]
+		if !ok {
+			status.Error = fmt.Sprintf("missing %s key", v1.DockerConfigJsonKey)
+			return status
+		}
+
+		config := struct {
+			Auths map[string]dockerConfigEntry `json:"auths"`
+		}{}
+		if err := json.Unmarshal(data, &config); err != nil {
+			status.Error = fmt.Sprintf("malformed %s: %s", v1.DockerConfigJsonKey, err.Error())
+			return status
+		}
+		entries = config.Auths
+	case v1.SecretTypeDockercfg:
+		data, ok := secret.Data[v1.DockerConfigKey]
+		if !ok {
+			status.Error = fmt.Sprintf("missing %s key", v1.DockerConfigKey)
+			return status
+		}
+
+		if err := json.Unmarshal(data, &entries); err != nil {
+			status.Error = fmt.Sprintf("malformed %s: %s", v1.DockerConfigKey, err.Error())
+			return status
+		}
+	default:
+		status.Error = fmt.Sprintf("unsupported secret type %s, expected %s or %s", secret.Type,
+			v1.SecretTypeDockerConfigJson, v1.SecretTypeDockercfg)
+		return status
+	}
+
+	if len(entries) == 0 {
+		status.Error = "no registries configured"
+		return status
+	}
+
+	problems := make([]string, 0)
+	for server, entry := range entries {
+		registry := clientapi.PullSecretRegistry{Server: server}
+		registry.Username, registry.HasCredentials = parseDockerConfigEntry(entry)
+		if !registry.HasCredentials {
+			problems = append(problems, fmt.Sprintf("registry %s has no valid credentials", server))
+		}
+		status.Registries = append(status.Registries, registry)
+	}
+
+	sort.Slice(status.Registries, func(i, j int) bool {
+		return status.Registries[i].Server < status.Registries[j].Server
+	})
+	sort.Strings(problems)
+	status.Error = strings.Join(problems, "; ")
+	status.Valid = len(problems) == 0
+	return status
+}
+
+// Returns username of the entry and whether it contains credentials. Auth string has to be base64 encoded
+// 'username:password', otherwise username and password or identity token have to be set.
+func parseDockerConfigEntry(entry dockerConfigEntry) (string, bool) {
+	if len(entry.Auth) > 0 {
+		decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+		if err != nil {
+			return entry.Username, false
+		}
+
+		parts := strings.SplitN(string(decoded), ":", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+			return entry.Username, false
+		}
+
+		return parts[0], true
+	}
+
+	if len(entry.IdentityToken) > 0 {
+		return entry.Username, true
+	}
+
+	return entry.Username, len(entry.Username) > 0 && len(entry.Password) > 0
+}
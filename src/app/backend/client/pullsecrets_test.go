@@ -0,0 +1,85 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+func newPullSecret(name string, secretType v1.SecretType, key, data string) *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"},
+		Type:       secretType,
+		Data:       map[string][]byte{key: []byte(data)},
+	}
+}
+
+func TestValidateImagePullSecrets(t *testing.T) {
+	auth := base64.StdEncoding.EncodeToString([]byte("robot:s3cr3t"))
+	client := fake.NewSimpleClientset(
+		newPullSecret("valid", v1.SecretTypeDockerConfigJson, v1.DockerConfigJsonKey,
+			`{"auths": {"registry.example.com": {"auth": "`+auth+`"},
+				"ghcr.io": {"username": "bot", "password": "t0ken"}}}`),
+		newPullSecret("legacy", v1.SecretTypeDockercfg, v1.DockerConfigKey,
+			`{"quay.io": {"auth": "`+auth+`", "email": "robot@example.com"}}`),
+		newPullSecret("malformed", v1.SecretTypeDockerConfigJson, v1.DockerConfigJsonKey, `{"auths": `),
+		newPullSecret("no-credentials", v1.SecretTypeDockerConfigJson, v1.DockerConfigJsonKey,
+			`{"auths": {"docker.io": {"auth": "bm90LWEtcGFpcg=="}}}`),
+		newPullSecret("opaque", v1.SecretTypeOpaque, "password", "s3cr3t"),
+	)
+
+	statuses, err := validateImagePullSecrets(client, "default",
+		[]string{"valid", "legacy", "malformed", "no-credentials", "opaque", "missing"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []clientapi.PullSecretStatus{
+		{Name: "valid", Found: true, Type: string(v1.SecretTypeDockerConfigJson), Valid: true,
+			Registries: []clientapi.PullSecretRegistry{
+				{Server: "ghcr.io", Username: "bot", HasCredentials: true},
+				{Server: "registry.example.com", Username: "robot", HasCredentials: true},
+			}},
+		{Name: "legacy", Found: true, Type: string(v1.SecretTypeDockercfg), Valid: true,
+			Registries: []clientapi.PullSecretRegistry{{Server: "quay.io", Username: "robot", HasCredentials: true}}},
+		{Name: "malformed", Found: true, Type: string(v1.SecretTypeDockerConfigJson),
+			Registries: []clientapi.PullSecretRegistry{}, Error: "malformed .dockerconfigjson: unexpected end of JSON input"},
+		{Name: "no-credentials", Found: true, Type: string(v1.SecretTypeDockerConfigJson),
+			Registries: []clientapi.PullSecretRegistry{{Server: "docker.io"}},
+			Error:      "registry docker.io has no valid credentials"},
+		{Name: "opaque", Found: true, Type: string(v1.SecretTypeOpaque), Registries: []clientapi.PullSecretRegistry{},
+			Error: "unsupported secret type Opaque, expected kubernetes.io/dockerconfigjson or kubernetes.io/dockercfg"},
+		{Name: "missing", Registries: []clientapi.PullSecretRegistry{}, Error: "secret missing not found"},
+	}
+
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, statuses)
+	}
+
+	if dump := fmt.Sprintf("%+v", statuses); strings.Contains(dump, "s3cr3t") || strings.Contains(dump, "t0ken") ||
+		strings.Contains(dump, auth) {
+		t.Errorf("Expected credentials to be redacted, got %s", dump)
+	}
+}
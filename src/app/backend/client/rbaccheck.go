@@ -0,0 +1,105 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	v1 "k8s.io/api/authorization/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	authApi "github.com/CAPS-Cloud/dashboard/src/app/backend/auth/api"
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+// RequiredPermissions returns permissions that have to be granted to the dashboard service account for the core
+// features to work properly. Namespace is the one where dashboard keeps its secrets.
+func RequiredPermissions(namespace string) []v1.ResourceAttributes {
+	return []v1.ResourceAttributes{
+		{Verb: "list", Resource: "pods"},
+		{Verb: "list", Resource: "namespaces"},
+		{Verb: "create", Group: "authentication.k8s.io", Resource: "tokenreviews"},
+		{Verb: "get", Resource: "secrets", Namespace: namespace, Name: authApi.EncryptionKeyHolderName},
+		{Verb: "update", Resource: "secrets", Namespace: namespace, Name: authApi.EncryptionKeyHolderName},
+		{Verb: "get", Resource: "secrets", Namespace: namespace, Name: clientapi.CsrfTokenSecretName},
+		{Verb: "update", Resource: "secrets", Namespace: namespace, Name: clientapi.CsrfTokenSecretName},
+		// CSRF key secret is created on first start. Create cannot be restricted to the secret name.
+		{Verb: "create", Resource: "secrets", Namespace: namespace},
+	}
+}
+
+// MissingPermissions runs SelfSubjectAccessReview for every provided permission and returns the ones that are not
+// granted. Permissions that could not be checked are treated as missing.
+func MissingPermissions(client kubernetes.Interface, permissions []v1.ResourceAttributes) []v1.ResourceAttributes {
+	missing := make([]v1.ResourceAttributes, 0)
+	for _, permission := range permissions {
+		attributes := permission
+		review, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(context.TODO(),
+			&v1.SelfSubjectAccessReview{
+				Spec: v1.SelfSubjectAccessReviewSpec{ResourceAttributes: &attributes},
+			}, metaV1.CreateOptions{})
+		if err != nil {
+			log.Printf("Could not check %s permission: %s", permissionString(permission), err.Error())
+			missing = append(missing, permission)
+			continue
+		}
+
+		if !review.Status.Allowed {
+			missing = append(missing, permission)
+		}
+	}
+
+	return missing
+}
+
+// CheckRBAC checks if provided client has all permissions required by dashboard and logs a summary of the missing
+// ones. Returns true if all required permissions are granted.
+func CheckRBAC(client kubernetes.Interface, namespace string) bool {
+	missing := MissingPermissions(client, RequiredPermissions(namespace))
+	if len(missing) == 0 {
+		log.Print("RBAC check passed, all required permissions are granted")
+		return true
+	}
+
+	descriptions := make([]string, 0, len(missing))
+	for _, permission := range missing {
+		descriptions = append(descriptions, permissionString(permission))
+	}
+
+	log.Printf("RBAC check failed, dashboard is missing %d required permission(s): %s. Some features will not "+
+		"work until they are granted.", len(missing), strings.Join(descriptions, ", "))
+	return false
+}
+
+func permissionString(permission v1.ResourceAttributes) string {
+	resource := permission.Resource
+	if len(permission.Group) > 0 {
+		resource = resource + "." + permission.Group
+	}
+
+	if len(permission.Name) > 0 {
+		resource = resource + "/" + permission.Name
+	}
+
+	if len(permission.Namespace) > 0 {
+		return fmt.Sprintf("%s %s in %s namespace", permission.Verb, resource, permission.Namespace)
+	}
+
+	return fmt.Sprintf("%s %s", permission.Verb, resource)
+}
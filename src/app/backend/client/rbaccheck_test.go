@@ -0,0 +1,100 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientTesting "k8s.io/client-go/testing"
+)
+
+func newAccessReviewClient(denied ...v1.ResourceAttributes) *fake.Clientset {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "selfsubjectaccessreviews",
+		func(action clientTesting.Action) (bool, runtime.Object, error) {
+			review := action.(clientTesting.CreateAction).GetObject().(*v1.SelfSubjectAccessReview)
+			review.Status.Allowed = true
+			for _, d := range denied {
+				if reflect.DeepEqual(*review.Spec.ResourceAttributes, d) {
+					review.Status.Allowed = false
+				}
+			}
+			return true, review, nil
+		})
+
+	return client
+}
+
+func TestMissingPermissions(t *testing.T) {
+	permissions := RequiredPermissions("kubernetes-dashboard")
+	cases := []struct {
+		info     string
+		denied   []v1.ResourceAttributes
+		expected []v1.ResourceAttributes
+	}{
+		{"all granted", nil, []v1.ResourceAttributes{}},
+		{
+			"list pods and token reviews denied",
+			[]v1.ResourceAttributes{permissions[0], permissions[2]},
+			[]v1.ResourceAttributes{permissions[0], permissions[2]},
+		},
+	}
+
+	for _, c := range cases {
+		actual := MissingPermissions(newAccessReviewClient(c.denied...), permissions)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: MissingPermissions() == %v, expected %v", c.info, actual, c.expected)
+		}
+	}
+}
+
+func TestCheckRBAC(t *testing.T) {
+	namespace := "kubernetes-dashboard"
+	if !CheckRBAC(newAccessReviewClient(), namespace) {
+		t.Error("CheckRBAC(): expected check to pass when all permissions are granted")
+	}
+
+	if CheckRBAC(newAccessReviewClient(RequiredPermissions(namespace)[3]), namespace) {
+		t.Error("CheckRBAC(): expected check to fail when secret access is denied")
+	}
+
+	createSecrets := v1.ResourceAttributes{Verb: "create", Resource: "secrets", Namespace: namespace}
+	if CheckRBAC(newAccessReviewClient(createSecrets), namespace) {
+		t.Error("CheckRBAC(): expected check to fail when creating the CSRF key secret is denied")
+	}
+}
+
+func TestPermissionString(t *testing.T) {
+	cases := []struct {
+		permission v1.ResourceAttributes
+		expected   string
+	}{
+		{v1.ResourceAttributes{Verb: "list", Resource: "pods"}, "list pods"},
+		{v1.ResourceAttributes{Verb: "create", Group: "authentication.k8s.io", Resource: "tokenreviews"},
+			"create tokenreviews.authentication.k8s.io"},
+		{v1.ResourceAttributes{Verb: "get", Resource: "secrets", Namespace: "ns", Name: "key"},
+			"get secrets/key in ns namespace"},
+	}
+
+	for _, c := range cases {
+		if actual := permissionString(c.permission); actual != c.expected {
+			t.Errorf("permissionString(%v) == %s, expected %s", c.permission, actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/metadata"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+)
+
+// RefreshHintObjectsPerStep is the number of objects in the cluster that extends suggested refresh interval by
+// the minimal interval.
+const RefreshHintObjectsPerStep = 500
+
+// refreshHintResources are counted to estimate the size of the cluster.
+var refreshHintResources = []schema.GroupVersionResource{
+	v1.SchemeGroupVersion.WithResource("pods"),
+	v1.SchemeGroupVersion.WithResource("nodes"),
+}
+
+// RefreshHint suggests how often UI should poll the backend, based on the number of pods and nodes visible to the
+// user. Interval grows with the size of the cluster and is bounded by 'min-refresh-interval' and
+// 'max-refresh-interval' arguments. Objects are counted cheaply with single-item metadata lists.
+func (self *clientManager) RefreshHint(req *restful.Request) (time.Duration, error) {
+	cfg, err := self.Config(req)
+	if err != nil {
+		return 0, err
+	}
+
+	client, err := metadata.NewForConfig(cfg)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	for _, gvr := range refreshHintResources {
+		n, err := countObjects(client, gvr)
+		if err != nil {
+			return 0, err
+		}
+		count += n
+	}
+
+	return refreshInterval(count, time.Duration(args.Holder.GetMinRefreshInterval())*time.Second,
+		time.Duration(args.Holder.GetMaxRefreshInterval())*time.Second), nil
+}
+
+// Counts objects using remaining item count of the first page, so that only a single item is transferred.
+func countObjects(client metadata.Interface, gvr schema.GroupVersionResource) (int64, error) {
+	list, err := client.Resource(gvr).List(context.TODO(), metaV1.ListOptions{Limit: 1})
+	if err != nil {
+		return 0, err
+	}
+
+	count := int64(len(list.Items))
+	if list.RemainingItemCount != nil {
+		count += *list.RemainingItemCount
+	}
+
+	return count, nil
+}
+
+// Returns minimal interval extended by another minimal interval for every RefreshHintObjectsPerStep objects, capped
+// at maximal interval.
+func refreshInterval(count int64, min, max time.Duration) time.Duration {
+	interval := min * time.Duration(1+count/RefreshHintObjectsPerStep)
+	if max > 0 && interval > max {
+		return max
+	}
+
+	return interval
+}
@@ -0,0 +1,73 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	clientTesting "k8s.io/client-go/testing"
+)
+
+func TestRefreshInterval(t *testing.T) {
+	cases := []struct {
+		count    int64
+		expected time.Duration
+	}{
+		{0, 5 * time.Second},
+		{499, 5 * time.Second},
+		{500, 10 * time.Second},
+		{2400, 25 * time.Second},
+		{100000, time.Minute},
+	}
+
+	for _, c := range cases {
+		if actual := refreshInterval(c.count, 5*time.Second, time.Minute); actual != c.expected {
+			t.Errorf("refreshInterval(%d) == %s, expected %s", c.count, actual, c.expected)
+		}
+	}
+
+	if actual := refreshInterval(100000, 5*time.Second, 0); actual != 1005*time.Second {
+		t.Errorf("Expected interval not to be capped without maximum, got %s", actual)
+	}
+}
+
+func TestCountObjects(t *testing.T) {
+	scheme := runtime.NewScheme()
+	metaV1.AddMetaToScheme(scheme)
+	client := metadatafake.NewSimpleMetadataClient(scheme)
+	client.PrependReactor("list", "pods", func(action clientTesting.Action) (bool, runtime.Object, error) {
+		remaining := int64(1234)
+		return true, &metaV1.List{
+			ListMeta: metaV1.ListMeta{RemainingItemCount: &remaining},
+			Items: []runtime.RawExtension{
+				{Object: &metaV1.PartialObjectMetadata{ObjectMeta: metaV1.ObjectMeta{Name: "web"}}},
+			},
+		}, nil
+	})
+
+	count, err := countObjects(client, v1.SchemeGroupVersion.WithResource("pods"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if count != 1235 {
+		t.Errorf("Expected 1235 pods, got %d", count)
+	}
+}
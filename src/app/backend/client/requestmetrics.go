@@ -0,0 +1,150 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/rest"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+// InsecureClientMetrics returns number of requests and their latencies per apiserver endpoint for requests sent with
+// the credentials of the dashboard service account. It does not include requests made with the credentials of the
+// users, so it helps to find internal loops that load the apiserver. Endpoints are identified by the method and the
+// path with namespace and object names replaced by placeholders, i.e. 'GET /api/v1/namespaces/{namespace}/secrets'.
+func (self *clientManager) InsecureClientMetrics() map[string]clientapi.EndpointMetrics {
+	return self.insecureMetrics.snapshot()
+}
+
+// requestMetrics records requests passing through wrapped transports.
+type requestMetrics struct {
+	mux       sync.Mutex
+	endpoints map[string]*endpointMetrics
+	// Used to override time in tests.
+	now func() time.Time
+}
+
+type endpointMetrics struct {
+	requests     int64
+	errors       int64
+	totalLatency time.Duration
+	maxLatency   time.Duration
+}
+
+func newRequestMetrics() *requestMetrics {
+	return &requestMetrics{endpoints: make(map[string]*endpointMetrics), now: time.Now}
+}
+
+// Adds request recording to the transport of the given config.
+func (self *requestMetrics) configure(cfg *rest.Config) {
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &metricsRoundTripper{delegate: rt, metrics: self}
+	})
+}
+
+// Records request to the endpoint. Transport errors and server errors are counted as errors.
+func (self *requestMetrics) record(endpoint string, latency time.Duration, failed bool) {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	metrics, ok := self.endpoints[endpoint]
+	if !ok {
+		metrics = &endpointMetrics{}
+		self.endpoints[endpoint] = metrics
+	}
+
+	metrics.requests++
+	metrics.totalLatency += latency
+	if latency > metrics.maxLatency {
+		metrics.maxLatency = latency
+	}
+
+	if failed {
+		metrics.errors++
+	}
+}
+
+func (self *requestMetrics) snapshot() map[string]clientapi.EndpointMetrics {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	result := make(map[string]clientapi.EndpointMetrics, len(self.endpoints))
+	for endpoint, metrics := range self.endpoints {
+		result[endpoint] = clientapi.EndpointMetrics{
+			Requests:       metrics.requests,
+			Errors:         metrics.errors,
+			AverageLatency: metrics.totalLatency / time.Duration(metrics.requests),
+			MaxLatency:     metrics.maxLatency,
+		}
+	}
+
+	return result
+}
+
+// metricsRoundTripper reports every request to the request metrics.
+type metricsRoundTripper struct {
+	delegate http.RoundTripper
+	metrics  *requestMetrics
+}
+
+// RoundTrip implements http.RoundTripper.
+func (self *metricsRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := self.metrics.now()
+	resp, err := self.delegate.RoundTrip(req)
+	failed := err != nil || resp.StatusCode >= http.StatusInternalServerError
+	self.metrics.record(endpointKey(req.Method, req.URL.Path), self.metrics.now().Sub(start), failed)
+	return resp, err
+}
+
+// WrappedRoundTripper allows client-go to reach the underlying transport, i.e. to close idle connections.
+func (self *metricsRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return self.delegate
+}
+
+// Returns method and path of the request with the names of namespaces and objects replaced by placeholders, so that
+// the number of endpoints stays bounded.
+func endpointKey(method, path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	// Skips API prefix, i.e. '/api/v1' or '/apis/apps/v1'.
+	prefix := 0
+	switch segments[0] {
+	case "api":
+		prefix = 2
+	case "apis":
+		prefix = 3
+	}
+
+	if prefix == 0 || len(segments) <= prefix {
+		return method + " " + path
+	}
+
+	resource := segments[prefix:]
+	if len(resource) > 2 && resource[0] == "namespaces" {
+		resource[1] = "{namespace}"
+		resource = resource[2:]
+	}
+
+	if len(resource) > 1 {
+		resource[1] = "{name}"
+	}
+
+	return method + " /" + strings.Join(segments, "/")
+}
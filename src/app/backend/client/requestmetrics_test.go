@@ -0,0 +1,86 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+func TestEndpointKey(t *testing.T) {
+	cases := []struct {
+		method, path, expected string
+	}{
+		{"GET", "/api/v1/namespaces/default/secrets", "GET /api/v1/namespaces/{namespace}/secrets"},
+		{"GET", "/api/v1/namespaces/default/secrets/token", "GET /api/v1/namespaces/{namespace}/secrets/{name}"},
+		{"GET", "/api/v1/namespaces/default", "GET /api/v1/namespaces/{name}"},
+		{"GET", "/api/v1/nodes", "GET /api/v1/nodes"},
+		{"POST", "/apis/apps/v1/namespaces/default/deployments/web/scale",
+			"POST /apis/apps/v1/namespaces/{namespace}/deployments/{name}/scale"},
+		{"GET", "/apis/rbac.authorization.k8s.io/v1/clusterroles/admin",
+			"GET /apis/rbac.authorization.k8s.io/v1/clusterroles/{name}"},
+		{"GET", "/version", "GET /version"},
+		{"GET", "/apis", "GET /apis"},
+	}
+
+	for _, c := range cases {
+		if actual := endpointKey(c.method, c.path); actual != c.expected {
+			t.Errorf("endpointKey(%s, %s) == %s, expected %s", c.method, c.path, actual, c.expected)
+		}
+	}
+}
+
+func TestMetricsRoundTripper(t *testing.T) {
+	metrics := newRequestMetrics()
+	now := time.Now()
+	metrics.now = func() time.Time {
+		now = now.Add(100 * time.Millisecond)
+		return now
+	}
+
+	latencies := map[string]time.Duration{"/api/v1/namespaces/a/pods": 0, "/api/v1/namespaces/b/pods": 200}
+	rt := &metricsRoundTripper{metrics: metrics, delegate: roundTripperFunc(func(req *http.Request) (
+		*http.Response, error) {
+		if req.URL.Path == "/api/v1/nodes" {
+			return nil, errors.New("connection refused")
+		}
+
+		now = now.Add(latencies[req.URL.Path] * time.Millisecond)
+		if req.URL.Path == "/api/v1/namespaces/b/pods" {
+			return &http.Response{StatusCode: http.StatusServiceUnavailable}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})}
+
+	for _, path := range []string{"/api/v1/namespaces/a/pods", "/api/v1/namespaces/b/pods", "/api/v1/nodes"} {
+		req, _ := http.NewRequest(http.MethodGet, "https://apiserver"+path, nil)
+		rt.RoundTrip(req)
+	}
+
+	expected := map[string]clientapi.EndpointMetrics{
+		"GET /api/v1/namespaces/{namespace}/pods": {Requests: 2, Errors: 1, AverageLatency: 200 * time.Millisecond,
+			MaxLatency: 300 * time.Millisecond},
+		"GET /api/v1/nodes": {Requests: 1, Errors: 1, AverageLatency: 100 * time.Millisecond,
+			MaxLatency: 100 * time.Millisecond},
+	}
+	if actual := metrics.snapshot(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected metrics %+v, got %+v", expected, actual)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+// Limits size of the responses returned through the transport of the given config. Responses larger than the limit
+// are replaced with the error suggesting pagination. Limit lower than 1 disables the check.
+func configureResponseSizeLimit(cfg *rest.Config, limit int64) {
+	if limit <= 0 {
+		return
+	}
+
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &responseSizeLimiter{delegate: rt, limit: limit}
+	})
+}
+
+// responseSizeLimiter buffers responses up to the limit, so that too large responses can be rejected before they
+// are decoded by the client.
+type responseSizeLimiter struct {
+	delegate http.RoundTripper
+	limit    int64
+}
+
+// RoundTrip implements http.RoundTripper.
+func (self *responseSizeLimiter) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := self.delegate.RoundTrip(req)
+	if err != nil || resp.Body == nil || isStreamingRequest(req) {
+		return resp, err
+	}
+
+	if resp.ContentLength > self.limit {
+		resp.Body.Close()
+		return self.tooLarge(req), nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, self.limit+1))
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > self.limit {
+		return self.tooLarge(req), nil
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	resp.ContentLength = int64(len(data))
+	return resp, nil
+}
+
+// WrappedRoundTripper allows client-go to reach the underlying transport, i.e. to close idle connections.
+func (self *responseSizeLimiter) WrappedRoundTripper() http.RoundTripper {
+	return self.delegate
+}
+
+// Returns response with the status error, so that it is decoded by the client as any other apiserver error.
+func (self *responseSizeLimiter) tooLarge(req *http.Request) *http.Response {
+	body, _ := json.Marshal(errors.NewResponseTooLarge(self.limit).ErrStatus)
+	return &http.Response{
+		Status:        strconv.Itoa(http.StatusRequestEntityTooLarge) + " " + http.StatusText(http.StatusRequestEntityTooLarge),
+		StatusCode:    http.StatusRequestEntityTooLarge,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// Watches and followed logs are long-running streams and are not limited.
+func isStreamingRequest(req *http.Request) bool {
+	query := req.URL.Query()
+	return query.Get("watch") == "true" || query.Get("watch") == "1" || query.Get("follow") == "true" ||
+		strings.HasSuffix(req.URL.Path, "/log")
+}
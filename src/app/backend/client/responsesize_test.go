@@ -0,0 +1,91 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+func TestResponseSizeLimit(t *testing.T) {
+	podList := `{"kind":"PodList","apiVersion":"v1","metadata":{},"items":[{"metadata":{"name":"` +
+		strings.Repeat("a", 200) + `"}}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// Disable content length, so that the limit is checked while reading the body.
+		if strings.Contains(r.URL.Path, "/namespaces/chunked/") {
+			w.(http.Flusher).Flush()
+		}
+		w.Write([]byte(podList))
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		limit     int64
+		namespace string
+		tooLarge  bool
+	}{
+		{0, "default", false},
+		{1024, "default", false},
+		{100, "default", true},
+		{100, "chunked", true},
+	}
+
+	for _, c := range cases {
+		cfg := &rest.Config{Host: server.URL}
+		configureResponseSizeLimit(cfg, c.limit)
+		client, err := kubernetes.NewForConfig(cfg)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		_, err = client.CoreV1().Pods(c.namespace).List(context.TODO(), metaV1.ListOptions{})
+		if c.tooLarge && !errors.IsResponseTooLarge(err) {
+			t.Errorf("Expected response too large error for limit %d, but got %v", c.limit, err)
+		}
+
+		if !c.tooLarge && err != nil {
+			t.Errorf("Unexpected error for limit %d: %v", c.limit, err)
+		}
+	}
+}
+
+func TestIsStreamingRequest(t *testing.T) {
+	cases := []struct {
+		url      string
+		expected bool
+	}{
+		{"https://localhost/api/v1/pods", false},
+		{"https://localhost/api/v1/pods?watch=true", true},
+		{"https://localhost/api/v1/namespaces/default/pods/foo/log", true},
+		{"https://localhost/api/v1/namespaces/default/pods/foo/log?follow=true", true},
+	}
+
+	for _, c := range cases {
+		req, _ := http.NewRequest(http.MethodGet, c.url, nil)
+		if actual := isStreamingRequest(req); actual != c.expected {
+			t.Errorf("isStreamingRequest(%s) == %t, expected %t", c.url, actual, c.expected)
+		}
+	}
+}
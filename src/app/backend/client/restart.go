@@ -0,0 +1,82 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	apps "k8s.io/api/apps/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+// RestartedAtAnnotation is set on the pod template to trigger rolling restart, the same as 'kubectl rollout restart'.
+const RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+var restartableResources = map[schema.GroupResource]bool{
+	apps.Resource("deployments"):  true,
+	apps.Resource("statefulsets"): true,
+	apps.Resource("daemonsets"):   true,
+}
+
+// RestartWorkload triggers rolling restart of the deployment, stateful set or daemon set using credentials of the
+// user by setting restartedAt annotation on its pod template to the current time.
+func (self *clientManager) RestartWorkload(req *restful.Request, gvr schema.GroupVersionResource, namespace,
+	name string) error {
+	cfg, err := self.Config(req)
+	if err != nil {
+		return err
+	}
+
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	return restartWorkload(client, gvr, namespace, name, time.Now())
+}
+
+func restartWorkload(client dynamic.Interface, gvr schema.GroupVersionResource, namespace, name string,
+	now time.Time) error {
+	if !restartableResources[gvr.GroupResource()] {
+		return errors.NewBadRequest(fmt.Sprintf("restart is not supported for %s", gvr.GroupResource()))
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{RestartedAtAnnotation: now.Format(time.RFC3339)},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Resource(gvr).Namespace(namespace).Patch(context.TODO(), name, types.MergePatchType, patch,
+		metaV1.PatchOptions{})
+	return err
+}
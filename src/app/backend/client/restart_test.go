@@ -0,0 +1,99 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clientTesting "k8s.io/client-go/testing"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+func TestRestartWorkload(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	expected := `{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":` +
+		`"2022-06-01T12:00:00Z"}}}}}`
+
+	for _, resource := range []string{"deployments", "statefulsets", "daemonsets"} {
+		gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: resource}
+		client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+		var patch clientTesting.PatchActionImpl
+		client.PrependReactor("patch", resource, func(action clientTesting.Action) (bool, runtime.Object, error) {
+			patch = action.(clientTesting.PatchActionImpl)
+			return true, &unstructured.Unstructured{Object: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "web", "namespace": "default"},
+			}}, nil
+		})
+
+		if err := restartWorkload(client, gvr, "default", "web", now); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if patch.GetName() != "web" || patch.GetNamespace() != "default" ||
+			patch.GetPatchType() != types.MergePatchType || string(patch.GetPatch()) != expected {
+			t.Errorf("Expected pod template of %s to be annotated, got %+v", resource, patch)
+		}
+	}
+}
+
+func TestRestartWorkloadUpdatesTemplate(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	deployment := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "web", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{"team": "web"},
+				},
+			},
+		},
+	}}
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), deployment)
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	if err := restartWorkload(client, gvr, "default", "web", now); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	result, err := client.Resource(gvr).Namespace("default").Get(context.TODO(), "web", metaV1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	annotations, _, _ := unstructured.NestedStringMap(result.Object, "spec", "template", "metadata", "annotations")
+	if annotations[RestartedAtAnnotation] != "2022-06-01T12:00:00Z" || annotations["team"] != "web" {
+		t.Errorf("Expected restartedAt annotation to be added to the template, got %v", annotations)
+	}
+}
+
+func TestRestartWorkloadUnsupportedResource(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	gvr := schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}
+
+	if err := restartWorkload(client, gvr, "default", "web", time.Now()); !errors.IsBadRequest(err) {
+		t.Errorf("Expected bad request error, got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+)
+
+// Returns RESTMapper shared by all requests. It is backed by the cached discovery of the insecure client, as
+// mapping of the resources does not depend on the user. Resets are debounced with 'rest-mapper-reset-window'
+// argument.
+func (self *clientManager) restMapper() meta.ResettableRESTMapper {
+	self.restMapperOnce.Do(func() {
+		mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(self.insecureClient.Discovery()))
+		self.mapper = newDebouncedRESTMapper(mapper,
+			time.Duration(args.Holder.GetRESTMapperResetWindow())*time.Second)
+	})
+
+	return self.mapper
+}
+
+// Returns mapping of the given kind. Mapper is reset and mapping is retried once if kind is not known, i.e. when
+// it comes from the recently created CRD.
+func restMapping(mapper meta.ResettableRESTMapper, gvk schema.GroupVersionKind) (*meta.RESTMapping, error) {
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		mapper.Reset()
+		mapping, err = mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+
+	return mapping, err
+}
+
+// debouncedRESTMapper coalesces resets requested within the window into the first one. Every unknown kind resets
+// the mapper, so a burst of requests for a missing resource would otherwise trigger a discovery refresh each.
+type debouncedRESTMapper struct {
+	meta.ResettableRESTMapper
+	window time.Duration
+	now    func() time.Time
+
+	mux       sync.Mutex
+	lastReset time.Time
+}
+
+func newDebouncedRESTMapper(mapper meta.ResettableRESTMapper, window time.Duration) *debouncedRESTMapper {
+	return &debouncedRESTMapper{ResettableRESTMapper: mapper, window: window, now: time.Now}
+}
+
+// Reset resets the underlying mapper unless it was reset within the window. Concurrent callers wait until the
+// reset is done, so that their retry uses the refreshed mapping.
+func (self *debouncedRESTMapper) Reset() {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	now := self.now()
+	if !self.lastReset.IsZero() && now.Sub(self.lastReset) < self.window {
+		return
+	}
+
+	self.lastReset = now
+	self.ResettableRESTMapper.Reset()
+}
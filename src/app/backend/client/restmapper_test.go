@@ -0,0 +1,127 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// fakeRESTMapper counts resets and registers kinds passed to it only after the first reset, to simulate kinds that
+// were not discovered yet.
+type fakeRESTMapper struct {
+	*meta.DefaultRESTMapper
+	resets     int
+	discovered []schema.GroupVersionKind
+}
+
+func (self *fakeRESTMapper) Reset() {
+	self.resets++
+	for _, gvk := range self.discovered {
+		self.Add(gvk, meta.RESTScopeNamespace)
+	}
+	self.discovered = nil
+}
+
+// Returns RESTMapper that knows core, apps and rbac kinds used in the tests.
+func newTestRESTMapper(discovered ...schema.GroupVersionKind) *fakeRESTMapper {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	for _, kind := range []string{"Pod", "ConfigMap", "Secret", "Service", "PersistentVolumeClaim", "Event"} {
+		mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: kind}, meta.RESTScopeNamespace)
+	}
+	for _, kind := range []string{"Namespace", "Node", "PersistentVolume"} {
+		mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: kind}, meta.RESTScopeRoot)
+	}
+	for _, kind := range []string{"Deployment", "ReplicaSet", "StatefulSet", "DaemonSet"} {
+		mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: kind}, meta.RESTScopeNamespace)
+	}
+	for _, kind := range []string{"Job", "CronJob"} {
+		mapper.Add(schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: kind}, meta.RESTScopeNamespace)
+	}
+	mapper.Add(schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"},
+		meta.RESTScopeRoot)
+
+	return &fakeRESTMapper{DefaultRESTMapper: mapper, discovered: discovered}
+}
+
+func TestRESTMapping(t *testing.T) {
+	crd := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	mapper := newTestRESTMapper(crd)
+
+	mapping, err := restMapping(mapper, schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"})
+	if err != nil || mapping.Resource.Resource != "deployments" || mapper.resets != 0 {
+		t.Errorf("Expected known kind to be mapped without reset, got %v, %v, %d resets", mapping, err, mapper.resets)
+	}
+
+	mapping, err = restMapping(mapper, crd)
+	if err != nil || mapping.Resource.Resource != "widgets" || mapper.resets != 1 {
+		t.Errorf("Expected new kind to be mapped after reset, got %v, %v, %d resets", mapping, err, mapper.resets)
+	}
+
+	_, err = restMapping(mapper, schema.GroupVersionKind{Group: "unknown", Version: "v1", Kind: "Unknown"})
+	if !meta.IsNoMatchError(err) {
+		t.Errorf("Expected no match error for unknown kind, got %v", err)
+	}
+}
+
+func TestDebouncedRESTMapper(t *testing.T) {
+	crd := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	fake := newTestRESTMapper(crd)
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	mapper := newDebouncedRESTMapper(fake, 5*time.Second)
+	mapper.now = func() time.Time { return now }
+
+	// Burst of requests for the kind that is not known yet.
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mapper.Reset()
+		}()
+	}
+	wg.Wait()
+
+	if fake.resets != 1 {
+		t.Fatalf("Expected concurrent resets to trigger a single reset, got %d", fake.resets)
+	}
+
+	if mapping, err := restMapping(mapper, crd); err != nil || mapping.Resource.Resource != "widgets" {
+		t.Errorf("Expected new kind to be mapped after debounced reset, got %v, %v", mapping, err)
+	}
+
+	now = now.Add(4 * time.Second)
+	mapper.Reset()
+	if fake.resets != 1 {
+		t.Errorf("Expected reset within the window to be skipped, got %d resets", fake.resets)
+	}
+
+	now = now.Add(time.Second)
+	mapper.Reset()
+	if fake.resets != 2 {
+		t.Errorf("Expected reset after the window to be applied, got %d resets", fake.resets)
+	}
+
+	disabled := newDebouncedRESTMapper(fake, 0)
+	disabled.Reset()
+	disabled.Reset()
+	if fake.resets != 4 {
+		t.Errorf("Expected every reset to be applied without window, got %d resets", fake.resets)
+	}
+}
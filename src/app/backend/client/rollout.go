@@ -0,0 +1,179 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	apps "k8s.io/api/apps/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/emicklei/go-restful/v3"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+const timedOutReason = "ProgressDeadlineExceeded"
+
+// RolloutStatus returns rollout status of the deployment, stateful set or daemon set using credentials of the user.
+// Status is computed in the same way as 'kubectl rollout status' does.
+func (self *clientManager) RolloutStatus(req *restful.Request, gvr schema.GroupVersionResource, namespace,
+	name string) (clientapi.RolloutStatus, error) {
+	cfg, err := self.Config(req)
+	if err != nil {
+		return clientapi.RolloutStatus{}, err
+	}
+
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return clientapi.RolloutStatus{}, err
+	}
+
+	return rolloutStatus(client, gvr, namespace, name)
+}
+
+func rolloutStatus(client dynamic.Interface, gvr schema.GroupVersionResource, namespace, name string) (
+	clientapi.RolloutStatus, error) {
+	var obj interface{}
+	switch gvr.GroupResource() {
+	case apps.Resource("deployments"):
+		obj = &apps.Deployment{}
+	case apps.Resource("statefulsets"):
+		obj = &apps.StatefulSet{}
+	case apps.Resource("daemonsets"):
+		obj = &apps.DaemonSet{}
+	default:
+		return clientapi.RolloutStatus{}, errors.NewBadRequest(fmt.Sprintf("rollout status is not supported for %s",
+			gvr.GroupResource()))
+	}
+
+	unstructuredObj, err := client.Resource(gvr).Namespace(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return clientapi.RolloutStatus{}, err
+	}
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredObj.Object, obj); err != nil {
+		return clientapi.RolloutStatus{}, err
+	}
+
+	switch typed := obj.(type) {
+	case *apps.Deployment:
+		return deploymentRolloutStatus(typed), nil
+	case *apps.StatefulSet:
+		return statefulSetRolloutStatus(typed), nil
+	default:
+		return daemonSetRolloutStatus(obj.(*apps.DaemonSet)), nil
+	}
+}
+
+func rolloutInProgress(format string, a ...interface{}) clientapi.RolloutStatus {
+	return clientapi.RolloutStatus{Message: fmt.Sprintf(format, a...)}
+}
+
+func rolloutComplete(format string, a ...interface{}) clientapi.RolloutStatus {
+	return clientapi.RolloutStatus{Message: fmt.Sprintf(format, a...), Complete: true}
+}
+
+func deploymentRolloutStatus(deployment *apps.Deployment) clientapi.RolloutStatus {
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		return rolloutInProgress("Waiting for deployment spec update to be observed...")
+	}
+
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == apps.DeploymentProgressing && condition.Reason == timedOutReason {
+			return rolloutInProgress("deployment %q exceeded its progress deadline", deployment.Name)
+		}
+	}
+
+	status := deployment.Status
+	if deployment.Spec.Replicas != nil && status.UpdatedReplicas < *deployment.Spec.Replicas {
+		return rolloutInProgress("Waiting for deployment %q rollout to finish: %d out of %d new replicas have been updated...",
+			deployment.Name, status.UpdatedReplicas, *deployment.Spec.Replicas)
+	}
+
+	if status.Replicas > status.UpdatedReplicas {
+		return rolloutInProgress("Waiting for deployment %q rollout to finish: %d old replicas are pending termination...",
+			deployment.Name, status.Replicas-status.UpdatedReplicas)
+	}
+
+	if status.AvailableReplicas < status.UpdatedReplicas {
+		return rolloutInProgress("Waiting for deployment %q rollout to finish: %d of %d updated replicas are available...",
+			deployment.Name, status.AvailableReplicas, status.UpdatedReplicas)
+	}
+
+	return rolloutComplete("deployment %q successfully rolled out", deployment.Name)
+}
+
+func daemonSetRolloutStatus(daemonSet *apps.DaemonSet) clientapi.RolloutStatus {
+	if daemonSet.Spec.UpdateStrategy.Type != apps.RollingUpdateDaemonSetStrategyType {
+		return rolloutComplete("rollout status is only available for %s strategy type", apps.RollingUpdateDaemonSetStrategyType)
+	}
+
+	if daemonSet.Generation > daemonSet.Status.ObservedGeneration {
+		return rolloutInProgress("Waiting for daemon set spec update to be observed...")
+	}
+
+	status := daemonSet.Status
+	if status.UpdatedNumberScheduled < status.DesiredNumberScheduled {
+		return rolloutInProgress("Waiting for daemon set %q rollout to finish: %d out of %d new pods have been updated...",
+			daemonSet.Name, status.UpdatedNumberScheduled, status.DesiredNumberScheduled)
+	}
+
+	if status.NumberAvailable < status.DesiredNumberScheduled {
+		return rolloutInProgress("Waiting for daemon set %q rollout to finish: %d of %d updated pods are available...",
+			daemonSet.Name, status.NumberAvailable, status.DesiredNumberScheduled)
+	}
+
+	return rolloutComplete("daemon set %q successfully rolled out", daemonSet.Name)
+}
+
+func statefulSetRolloutStatus(statefulSet *apps.StatefulSet) clientapi.RolloutStatus {
+	if statefulSet.Spec.UpdateStrategy.Type != apps.RollingUpdateStatefulSetStrategyType {
+		return rolloutComplete("rollout status is only available for %s strategy type", apps.RollingUpdateStatefulSetStrategyType)
+	}
+
+	status := statefulSet.Status
+	if status.ObservedGeneration == 0 || statefulSet.Generation > status.ObservedGeneration {
+		return rolloutInProgress("Waiting for statefulset spec update to be observed...")
+	}
+
+	if statefulSet.Spec.Replicas != nil && status.ReadyReplicas < *statefulSet.Spec.Replicas {
+		return rolloutInProgress("Waiting for %d pods to be ready...", *statefulSet.Spec.Replicas-status.ReadyReplicas)
+	}
+
+	rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate
+	if rollingUpdate != nil && statefulSet.Spec.Replicas != nil && rollingUpdate.Partition != nil {
+		partitioned := *statefulSet.Spec.Replicas - *rollingUpdate.Partition
+		if status.UpdatedReplicas < partitioned {
+			return rolloutInProgress("Waiting for partitioned roll out to finish: %d out of %d new pods have been updated...",
+				status.UpdatedReplicas, partitioned)
+		}
+
+		return rolloutComplete("partitioned roll out complete: %d new pods have been updated...", status.UpdatedReplicas)
+	}
+
+	if status.UpdateRevision != status.CurrentRevision {
+		return rolloutInProgress("waiting for statefulset rolling update to complete %d pods at revision %s...",
+			status.UpdatedReplicas, status.UpdateRevision)
+	}
+
+	return rolloutComplete("statefulset rolling update complete %d pods at revision %s...", status.CurrentReplicas,
+		status.CurrentRevision)
+}
@@ -0,0 +1,150 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func toUnstructured(t *testing.T, obj runtime.Object) *unstructured.Unstructured {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &unstructured.Unstructured{Object: content}
+}
+
+func TestRolloutStatus(t *testing.T) {
+	meta := metaV1.ObjectMeta{Name: "app", Namespace: "default", Generation: 2}
+	cases := []struct {
+		info         string
+		resource     string
+		obj          runtime.Object
+		wantComplete bool
+		wantMessage  string
+	}{
+		{
+			"deployment spec update not observed", "deployments",
+			&apps.Deployment{TypeMeta: metaV1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"}, ObjectMeta: meta,
+				Status: apps.DeploymentStatus{ObservedGeneration: 1}},
+			false, "Waiting for deployment spec update to be observed...",
+		},
+		{
+			"deployment rollout in progress", "deployments",
+			&apps.Deployment{TypeMeta: metaV1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"}, ObjectMeta: meta,
+				Spec:   apps.DeploymentSpec{Replicas: int32Ptr(3)},
+				Status: apps.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 1}},
+			false, `Waiting for deployment "app" rollout to finish: 1 out of 3 new replicas have been updated...`,
+		},
+		{
+			"deployment old replicas pending termination", "deployments",
+			&apps.Deployment{TypeMeta: metaV1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"}, ObjectMeta: meta,
+				Spec:   apps.DeploymentSpec{Replicas: int32Ptr(3)},
+				Status: apps.DeploymentStatus{ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 3}},
+			false, `Waiting for deployment "app" rollout to finish: 1 old replicas are pending termination...`,
+		},
+		{
+			"deployment exceeded progress deadline", "deployments",
+			&apps.Deployment{TypeMeta: metaV1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"}, ObjectMeta: meta,
+				Spec: apps.DeploymentSpec{Replicas: int32Ptr(3)},
+				Status: apps.DeploymentStatus{ObservedGeneration: 2, Conditions: []apps.DeploymentCondition{
+					{Type: apps.DeploymentProgressing, Reason: timedOutReason}}}},
+			false, `deployment "app" exceeded its progress deadline`,
+		},
+		{
+			"deployment rolled out", "deployments",
+			&apps.Deployment{TypeMeta: metaV1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"}, ObjectMeta: meta,
+				Spec: apps.DeploymentSpec{Replicas: int32Ptr(3)},
+				Status: apps.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3,
+					AvailableReplicas: 3}},
+			true, `deployment "app" successfully rolled out`,
+		},
+		{
+			"stateful set pods not ready", "statefulsets",
+			&apps.StatefulSet{TypeMeta: metaV1.TypeMeta{Kind: "StatefulSet", APIVersion: "apps/v1"}, ObjectMeta: meta,
+				Spec: apps.StatefulSetSpec{Replicas: int32Ptr(3),
+					UpdateStrategy: apps.StatefulSetUpdateStrategy{Type: apps.RollingUpdateStatefulSetStrategyType}},
+				Status: apps.StatefulSetStatus{ObservedGeneration: 2, ReadyReplicas: 1}},
+			false, "Waiting for 2 pods to be ready...",
+		},
+		{
+			"stateful set rolled out", "statefulsets",
+			&apps.StatefulSet{TypeMeta: metaV1.TypeMeta{Kind: "StatefulSet", APIVersion: "apps/v1"}, ObjectMeta: meta,
+				Spec: apps.StatefulSetSpec{Replicas: int32Ptr(3),
+					UpdateStrategy: apps.StatefulSetUpdateStrategy{Type: apps.RollingUpdateStatefulSetStrategyType}},
+				Status: apps.StatefulSetStatus{ObservedGeneration: 2, ReadyReplicas: 3, CurrentReplicas: 3,
+					CurrentRevision: "app-1", UpdateRevision: "app-1"}},
+			true, "statefulset rolling update complete 3 pods at revision app-1...",
+		},
+		{
+			"daemon set rollout in progress", "daemonsets",
+			&apps.DaemonSet{TypeMeta: metaV1.TypeMeta{Kind: "DaemonSet", APIVersion: "apps/v1"}, ObjectMeta: meta,
+				Spec: apps.DaemonSetSpec{
+					UpdateStrategy: apps.DaemonSetUpdateStrategy{Type: apps.RollingUpdateDaemonSetStrategyType}},
+				Status: apps.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 2,
+					UpdatedNumberScheduled: 2, NumberAvailable: 1}},
+			false, `Waiting for daemon set "app" rollout to finish: 1 of 2 updated pods are available...`,
+		},
+		{
+			"daemon set rolled out", "daemonsets",
+			&apps.DaemonSet{TypeMeta: metaV1.TypeMeta{Kind: "DaemonSet", APIVersion: "apps/v1"}, ObjectMeta: meta,
+				Spec: apps.DaemonSetSpec{
+					UpdateStrategy: apps.DaemonSetUpdateStrategy{Type: apps.RollingUpdateDaemonSetStrategyType}},
+				Status: apps.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 2,
+					UpdatedNumberScheduled: 2, NumberAvailable: 2}},
+			true, `daemon set "app" successfully rolled out`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.info, func(t *testing.T) {
+			client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), toUnstructured(t, c.obj))
+			gvr := apps.SchemeGroupVersion.WithResource(c.resource)
+
+			status, err := rolloutStatus(client, gvr, "default", "app")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if status.Complete != c.wantComplete || status.Message != c.wantMessage {
+				t.Errorf("Expected status (%t, %q), got (%t, %q)", c.wantComplete, c.wantMessage, status.Complete,
+					status.Message)
+			}
+		})
+	}
+}
+
+func TestRolloutStatusUnsupportedResource(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "replicasets"}
+
+	if _, err := rolloutStatus(client, gvr, "default", "app"); !errors.IsBadRequest(err) {
+		t.Errorf("Expected bad request error, got %v", err)
+	}
+}
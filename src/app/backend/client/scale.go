@@ -0,0 +1,70 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+// ScaleResource sets number of replicas of the resource through its scale subresource using credentials of the
+// user, so that the rest of the object is not modified. It works for all resources that support scale subresource,
+// i.e. deployments, stateful sets, replica sets and custom resources. Returns desired number of replicas after
+// the update.
+func (self *clientManager) ScaleResource(req *restful.Request, gvr schema.GroupVersionResource, namespace,
+	name string, replicas int32) (int32, error) {
+	cfg, err := self.Config(req)
+	if err != nil {
+		return 0, err
+	}
+
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return 0, err
+	}
+
+	return scaleResource(client, gvr, namespace, name, replicas)
+}
+
+func scaleResource(client dynamic.Interface, gvr schema.GroupVersionResource, namespace, name string,
+	replicas int32) (int32, error) {
+	if replicas < 0 {
+		return 0, errors.NewBadRequest(fmt.Sprintf("invalid number of replicas: %d", replicas))
+	}
+
+	patch := []byte(fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas))
+	scale, err := client.Resource(gvr).Namespace(namespace).Patch(context.TODO(), name, types.MergePatchType, patch,
+		metaV1.PatchOptions{}, "scale")
+	if err != nil {
+		return 0, err
+	}
+
+	result, _, err := unstructured.NestedInt64(scale.Object, "spec", "replicas")
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(result), nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clientTesting "k8s.io/client-go/testing"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+func TestScaleResource(t *testing.T) {
+	gvrs := []schema.GroupVersionResource{
+		{Group: "apps", Version: "v1", Resource: "deployments"},
+		{Group: "apps", Version: "v1", Resource: "statefulsets"},
+		{Group: "apps", Version: "v1", Resource: "replicasets"},
+	}
+
+	for _, gvr := range gvrs {
+		client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+		var patch clientTesting.PatchActionImpl
+		client.PrependReactor("patch", gvr.Resource, func(action clientTesting.Action) (bool, runtime.Object, error) {
+			patch = action.(clientTesting.PatchActionImpl)
+			scale := &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "autoscaling/v1",
+				"kind":       "Scale",
+				"metadata":   map[string]interface{}{"name": "web", "namespace": "default"},
+				"spec":       map[string]interface{}{"replicas": int64(3)},
+			}}
+			return true, scale, nil
+		})
+
+		replicas, err := scaleResource(client, gvr, "default", "web", 3)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if replicas != 3 {
+			t.Errorf("Expected 3 replicas, got %d", replicas)
+		}
+
+		if patch.GetSubresource() != "scale" || patch.GetName() != "web" || patch.GetNamespace() != "default" ||
+			patch.GetPatchType() != types.MergePatchType || string(patch.GetPatch()) != `{"spec":{"replicas":3}}` {
+			t.Errorf("Expected scale subresource of %s to be patched, got %+v", gvr.Resource, patch)
+		}
+	}
+
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	if _, err := scaleResource(client, gvrs[0], "default", "web", -1); !errors.IsBadRequest(err) {
+		t.Errorf("Expected bad request error for negative replicas, got %v", err)
+	}
+}
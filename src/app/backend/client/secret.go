@@ -0,0 +1,62 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	v1 "k8s.io/api/authorization/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+// GetSecretDecoded returns decoded data of the secret using credentials of the user. Access is verified with
+// SelfSubjectAccessReview for get on this specific secret before it is read, so that list permission alone is
+// never enough to reveal secret values.
+func (self *clientManager) GetSecretDecoded(req *restful.Request, namespace, name string) (map[string]string, error) {
+	client, err := self.Client(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return getSecretDecoded(client, namespace, name)
+}
+
+func getSecretDecoded(client kubernetes.Interface, namespace, name string) (map[string]string, error) {
+	permission := v1.ResourceAttributes{Verb: "get", Resource: "secrets", Namespace: namespace, Name: name}
+	if len(MissingPermissions(client, []v1.ResourceAttributes{permission})) > 0 {
+		return nil, errors.NewForbidden(fmt.Sprintf("not allowed to get %s/%s secret", namespace, name))
+	}
+
+	secret, err := client.CoreV1().Secrets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Data values are base64 encoded on the wire and are decoded by the client already. Only keys are logged.
+	result := make(map[string]string, len(secret.Data))
+	for key, value := range secret.Data {
+		result[key] = string(value)
+	}
+
+	log.Printf("Decoded %d keys of %s/%s secret", len(result), namespace, name)
+	return result, nil
+}
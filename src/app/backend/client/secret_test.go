@@ -0,0 +1,79 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/authorization/v1"
+	coreV1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetSecretDecoded(t *testing.T) {
+	secret := &coreV1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{Name: "credentials", Namespace: "default"},
+		Data:       map[string][]byte{"username": []byte("admin"), "password": []byte("s3cr3t")},
+	}
+	client := newAccessReviewClient()
+	if err := client.Tracker().Add(secret); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := getSecretDecoded(client, "default", "credentials")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]string{"username": "admin", "password": "s3cr3t"}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("Expected %v, got %v", expected, data)
+	}
+
+	actions := client.Actions()
+	if len(actions) != 2 || actions[0].GetResource().Resource != "selfsubjectaccessreviews" ||
+		actions[1].GetVerb() != "get" || actions[1].GetResource().Resource != "secrets" {
+		t.Errorf("Expected access review to precede reading the secret, got %v", actions)
+	}
+}
+
+func TestGetSecretDecodedDenied(t *testing.T) {
+	secret := &coreV1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{Name: "credentials", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("s3cr3t")},
+	}
+	client := newAccessReviewClient(v1.ResourceAttributes{Verb: "get", Resource: "secrets", Namespace: "default",
+		Name: "credentials"})
+	if err := client.Tracker().Add(secret); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := getSecretDecoded(client, "default", "credentials")
+	if !k8serrors.IsForbidden(err) {
+		t.Errorf("Expected forbidden error, got %v", err)
+	}
+
+	if data != nil {
+		t.Errorf("Expected no data to be returned, got %v", data)
+	}
+
+	for _, action := range client.Actions() {
+		if action.GetResource().Resource == "secrets" {
+			t.Errorf("Expected secret not to be read when access is denied, got %v", action)
+		}
+	}
+}
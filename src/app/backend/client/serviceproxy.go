@@ -0,0 +1,157 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+// ProxyService forwards GET request for the path to the service through the apiserver service proxy using
+// credentials of the user and streams back the response. Service can be given as 'name' or 'name:port', where port
+// is a name or number of the service port, the first port is used otherwise. Only services configured with
+// 'service-proxy-allowed-services' argument can be requested. Caller is responsible for closing returned stream.
+func (self *clientManager) ProxyService(req *restful.Request, namespace, service, path string) (io.ReadCloser, int,
+	error) {
+	client, err := self.Client(req)
+	if err != nil {
+		return nil, http.StatusUnauthorized, err
+	}
+
+	return proxyService(client, client.CoreV1().RESTClient(), namespace, service, path,
+		args.Holder.GetServiceProxyAllowedServices())
+}
+
+func proxyService(client kubernetes.Interface, restClient RESTClient, namespace, service, rawPath string,
+	allowedServices []string) (io.ReadCloser, int, error) {
+	name, port := service, ""
+	if i := strings.Index(service, ":"); i >= 0 {
+		name, port = service[:i], service[i+1:]
+	}
+
+	if len(name) == 0 || strings.Contains(port, ":") {
+		return nil, http.StatusBadRequest, errors.NewBadRequest(fmt.Sprintf("invalid service: %s", service))
+	}
+
+	if !isServiceProxyAllowed(namespace, name, allowedServices) {
+		return nil, http.StatusForbidden, k8serrors.NewForbidden(schema.GroupResource{Resource: "services"}, name,
+			fmt.Errorf("service is not allowed to be proxied"))
+	}
+
+	if strings.ContainsAny(rawPath, "?#") {
+		return nil, http.StatusBadRequest, errors.NewBadRequest(fmt.Sprintf("invalid proxy path: %s", rawPath))
+	}
+
+	svc, err := client.CoreV1().Services(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, proxyErrorCode(err), err
+	}
+
+	servicePort, err := findServicePort(svc, port)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	portRef := servicePort.Name
+	if len(portRef) == 0 {
+		portRef = strconv.Itoa(int(servicePort.Port))
+	}
+
+	stream, err := restClient.Get().
+		Namespace(namespace).
+		Resource("services").
+		Name(serviceProxyScheme(servicePort) + ":" + name + ":" + portRef).
+		SubResource("proxy").
+		Suffix(path.Clean("/" + rawPath)).
+		Stream(context.TODO())
+	if err != nil {
+		return nil, proxyErrorCode(err), err
+	}
+
+	return stream, http.StatusOK, nil
+}
+
+// Checks if service is on the list of allowed services. Entries have 'namespace/name' format, where name can be '*'
+// to allow all services in the namespace.
+func isServiceProxyAllowed(namespace, name string, allowedServices []string) bool {
+	for _, allowed := range allowedServices {
+		allowed = strings.TrimSpace(allowed)
+		if allowed == namespace+"/"+name || allowed == namespace+"/*" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns service port with the given name or number. First port is returned if no port is given.
+func findServicePort(svc *v1.Service, port string) (*v1.ServicePort, error) {
+	if len(svc.Spec.Ports) == 0 {
+		return nil, errors.NewBadRequest(fmt.Sprintf("service %s does not expose any ports", svc.Name))
+	}
+
+	if len(port) == 0 {
+		return &svc.Spec.Ports[0], nil
+	}
+
+	for i := range svc.Spec.Ports {
+		servicePort := &svc.Spec.Ports[i]
+		if servicePort.Name == port || strconv.Itoa(int(servicePort.Port)) == port {
+			return servicePort, nil
+		}
+	}
+
+	return nil, errors.NewBadRequest(fmt.Sprintf("service %s does not have port %s", svc.Name, port))
+}
+
+// Selects scheme based on the application protocol of the port, falling back to its name and number.
+func serviceProxyScheme(port *v1.ServicePort) string {
+	if port.AppProtocol != nil {
+		if strings.EqualFold(*port.AppProtocol, "https") {
+			return "https"
+		}
+		return "http"
+	}
+
+	if port.Name == "https" || strings.HasPrefix(port.Name, "https-") || port.Port == 443 {
+		return "https"
+	}
+
+	return "http"
+}
+
+func proxyErrorCode(err error) int {
+	if status, ok := err.(k8serrors.APIStatus); ok {
+		return int(status.Status().Code)
+	}
+
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,104 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestProxyService(t *testing.T) {
+	https := "https"
+	client := fake.NewSimpleClientset(
+		&v1.Service{
+			ObjectMeta: metaV1.ObjectMeta{Name: "grafana", Namespace: "monitoring"},
+			Spec: v1.ServiceSpec{Ports: []v1.ServicePort{
+				{Name: "web", Port: 3000},
+				{Name: "metrics", Port: 9090, AppProtocol: &https},
+			}},
+		},
+		&v1.Service{
+			ObjectMeta: metaV1.ObjectMeta{Name: "api", Namespace: "apps"},
+			Spec:       v1.ServiceSpec{Ports: []v1.ServicePort{{Port: 443}}},
+		},
+		&v1.Service{
+			ObjectMeta: metaV1.ObjectMeta{Name: "secret", Namespace: "monitoring"},
+			Spec:       v1.ServiceSpec{Ports: []v1.ServicePort{{Port: 80}}},
+		},
+	)
+	allowed := []string{"monitoring/grafana", "apps/*"}
+
+	cases := []struct {
+		namespace, service, path string
+		expectedCode             int
+		expectedPath             string
+	}{
+		{"monitoring", "grafana", "/api/health", http.StatusOK,
+			"/api/v1/namespaces/monitoring/services/http:grafana:web/proxy/api/health"},
+		{"monitoring", "grafana:metrics", "metrics", http.StatusOK,
+			"/api/v1/namespaces/monitoring/services/https:grafana:metrics/proxy/metrics"},
+		{"monitoring", "grafana:3000", "", http.StatusOK,
+			"/api/v1/namespaces/monitoring/services/http:grafana:web/proxy"},
+		{"apps", "api", "/../../secrets", http.StatusOK,
+			"/api/v1/namespaces/apps/services/https:api:443/proxy/secrets"},
+		{"monitoring", "secret", "/", http.StatusForbidden, ""},
+		{"default", "grafana", "/", http.StatusForbidden, ""},
+		{"apps", "missing", "/", http.StatusNotFound, ""},
+		{"monitoring", "grafana:admin", "/", http.StatusBadRequest, ""},
+		{"monitoring", "grafana", "/?x=1", http.StatusBadRequest, ""},
+	}
+
+	for _, c := range cases {
+		restClient := &FakeRESTClient{response: &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("ok")),
+		}}
+
+		stream, code, err := proxyService(client, restClient, c.namespace, c.service, c.path, allowed)
+		if code != c.expectedCode {
+			t.Errorf("Expected code %d for service %s/%s, but got %d (%v)", c.expectedCode, c.namespace,
+				c.service, code, err)
+			continue
+		}
+
+		if c.expectedCode != http.StatusOK {
+			if c.expectedCode == http.StatusForbidden && !k8serrors.IsForbidden(err) {
+				t.Errorf("Expected forbidden error for service %s/%s, but got %v", c.namespace, c.service, err)
+			}
+			continue
+		}
+
+		body, _ := io.ReadAll(stream)
+		stream.Close()
+		if string(body) != "ok" || restClient.request.URL.Path != c.expectedPath {
+			t.Errorf("Expected %s to be proxied, but got %s (%s)", c.expectedPath, restClient.request.URL.Path, body)
+		}
+	}
+}
+
+func TestProxyServiceDisabled(t *testing.T) {
+	client := fake.NewSimpleClientset()
+
+	if _, code, _ := proxyService(client, &FakeRESTClient{}, "default", "web", "/", nil); code != http.StatusForbidden {
+		t.Fatalf("Expected service proxy to be disabled without allowed services, but got code %d", code)
+	}
+}
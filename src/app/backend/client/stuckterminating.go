@@ -0,0 +1,137 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	appsV1 "k8s.io/api/apps/v1"
+	batchV1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/metadata"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+var (
+	// stuckTerminatingResources are namespaced resources checked for objects stuck in terminating state.
+	stuckTerminatingResources = []schema.GroupVersionResource{
+		v1.SchemeGroupVersion.WithResource("pods"),
+		v1.SchemeGroupVersion.WithResource("persistentvolumeclaims"),
+		v1.SchemeGroupVersion.WithResource("services"),
+		v1.SchemeGroupVersion.WithResource("configmaps"),
+		v1.SchemeGroupVersion.WithResource("secrets"),
+		appsV1.SchemeGroupVersion.WithResource("deployments"),
+		appsV1.SchemeGroupVersion.WithResource("replicasets"),
+		appsV1.SchemeGroupVersion.WithResource("statefulsets"),
+		appsV1.SchemeGroupVersion.WithResource("daemonsets"),
+		batchV1.SchemeGroupVersion.WithResource("jobs"),
+		batchV1.SchemeGroupVersion.WithResource("cronjobs"),
+	}
+
+	// stuckTerminatingClusterResources are checked only when objects from all namespaces are requested.
+	stuckTerminatingClusterResources = []schema.GroupVersionResource{
+		v1.SchemeGroupVersion.WithResource("namespaces"),
+		v1.SchemeGroupVersion.WithResource("persistentvolumes"),
+	}
+)
+
+// StuckTerminating returns objects of the common resources that are being deleted for longer than the
+// 'stuck-terminating-threshold' argument and still have finalizers, using credentials of the user. Empty namespace
+// checks all namespaces as well as namespaces and persistent volumes themselves. Resources that user is not allowed
+// to list are skipped.
+func (self *clientManager) StuckTerminating(req *restful.Request, namespace string) ([]clientapi.StuckResource,
+	error) {
+	cfg, err := self.Config(req)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := metadata.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	threshold := time.Duration(args.Holder.GetStuckTerminatingThreshold()) * time.Second
+	return stuckTerminating(client, namespace, threshold, time.Now())
+}
+
+// Returns terminating objects with finalizers sorted from the longest terminating one.
+func stuckTerminating(client metadata.Interface, namespace string, threshold time.Duration,
+	now time.Time) ([]clientapi.StuckResource, error) {
+	resources := stuckTerminatingResources
+	if len(namespace) == 0 {
+		resources = append(append([]schema.GroupVersionResource{}, resources...),
+			stuckTerminatingClusterResources...)
+	}
+
+	result := make([]clientapi.StuckResource, 0)
+	for _, gvr := range resources {
+		var list *metaV1.PartialObjectMetadataList
+		var err error
+		if isClusterScopedStuckResource(gvr) {
+			list, err = client.Resource(gvr).List(context.TODO(), metaV1.ListOptions{})
+		} else {
+			list, err = client.Resource(gvr).Namespace(namespace).List(context.TODO(), metaV1.ListOptions{})
+		}
+
+		if k8serrors.IsForbidden(err) || k8serrors.IsNotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range list.Items {
+			deletion := item.DeletionTimestamp
+			if deletion == nil || len(item.Finalizers) == 0 || now.Sub(deletion.Time) < threshold {
+				continue
+			}
+
+			result = append(result, clientapi.StuckResource{
+				Group:             gvr.Group,
+				Version:           gvr.Version,
+				Resource:          gvr.Resource,
+				Namespace:         item.Namespace,
+				Name:              item.Name,
+				DeletionTimestamp: *deletion,
+				Finalizers:        item.Finalizers,
+			})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].DeletionTimestamp.Before(&result[j].DeletionTimestamp)
+	})
+	return result, nil
+}
+
+func isClusterScopedStuckResource(gvr schema.GroupVersionResource) bool {
+	for _, resource := range stuckTerminatingClusterResources {
+		if resource == gvr {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	clientTesting "k8s.io/client-go/testing"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+func newTerminatingObject(apiVersion, kind, namespace, name string, deletion *metaV1.Time,
+	finalizers ...string) *metaV1.PartialObjectMetadata {
+	return &metaV1.PartialObjectMetadata{
+		TypeMeta: metaV1.TypeMeta{APIVersion: apiVersion, Kind: kind},
+		ObjectMeta: metaV1.ObjectMeta{Namespace: namespace, Name: name, DeletionTimestamp: deletion,
+			Finalizers: finalizers},
+	}
+}
+
+func TestStuckTerminating(t *testing.T) {
+	now := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	hourAgo, dayAgo, minuteAgo := metaV1.NewTime(now.Add(-time.Hour)), metaV1.NewTime(now.Add(-24*time.Hour)),
+		metaV1.NewTime(now.Add(-time.Minute))
+
+	scheme := runtime.NewScheme()
+	metaV1.AddMetaToScheme(scheme)
+	client := metadatafake.NewSimpleMetadataClient(scheme,
+		newTerminatingObject("v1", "PersistentVolumeClaim", "default", "data", &hourAgo,
+			"kubernetes.io/pvc-protection"),
+		newTerminatingObject("apps/v1", "Deployment", "default", "web", &dayAgo, "example.com/cleanup",
+			"foregroundDeletion"),
+		newTerminatingObject("v1", "Pod", "default", "recent", &minuteAgo, "example.com/cleanup"),
+		newTerminatingObject("v1", "Pod", "default", "no-finalizers", &dayAgo),
+		newTerminatingObject("v1", "Pod", "default", "running", nil, "example.com/cleanup"),
+		newTerminatingObject("v1", "Pod", "other", "other", &dayAgo, "example.com/cleanup"),
+		newTerminatingObject("v1", "Namespace", "", "old", &dayAgo, "kubernetes"),
+	)
+	client.PrependReactor("list", "secrets", func(action clientTesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.NewForbidden("secrets are not allowed")
+	})
+
+	stuck, err := stuckTerminating(client, "default", 5*time.Minute, now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []clientapi.StuckResource{
+		{Group: "apps", Version: "v1", Resource: "deployments", Namespace: "default", Name: "web",
+			DeletionTimestamp: dayAgo, Finalizers: []string{"example.com/cleanup", "foregroundDeletion"}},
+		{Version: "v1", Resource: "persistentvolumeclaims", Namespace: "default", Name: "data",
+			DeletionTimestamp: hourAgo, Finalizers: []string{"kubernetes.io/pvc-protection"}},
+	}
+	if !reflect.DeepEqual(stuck, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, stuck)
+	}
+
+	stuck, err = stuckTerminating(client, "", 5*time.Minute, now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	names := make([]string, 0, len(stuck))
+	for _, resource := range stuck {
+		names = append(names, resource.Resource+"/"+resource.Name)
+	}
+	// Objects deleted at the same time keep the order of the checked resources.
+	expectedNames := []string{"pods/other", "deployments/web", "namespaces/old", "persistentvolumeclaims/data"}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Errorf("Expected objects from all namespaces and cluster-scoped objects %v, got %v", expectedNames, names)
+	}
+}
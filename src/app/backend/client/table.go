@@ -0,0 +1,91 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/common"
+)
+
+const (
+	// TableAcceptHeader requests list to be returned as a Table with columns defined by the apiserver, the same
+	// ones kubectl shows including additionalPrinterColumns of custom resources. Plain JSON is accepted as well,
+	// so that servers without table support respond with a list that can be reported as unsupported.
+	TableAcceptHeader = "application/json;as=Table;g=meta.k8s.io;v=v1,application/json"
+)
+
+// ListTable lists the given resources as a server-side Table using credentials of the user. Empty namespace lists
+// resources from all namespaces or cluster-scoped resources, limit and timeout of the options are subject to the
+// page size and list timeout arguments.
+func (self *clientManager) ListTable(req *restful.Request, gvr schema.GroupVersionResource, namespace string,
+	opts metaV1.ListOptions) (*metaV1.Table, error) {
+	client, err := self.Client(req)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err = common.WithPageLimit(opts, opts.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err = common.WithListTimeout(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return listTable(client.CoreV1().RESTClient(), gvr, namespace, opts)
+}
+
+// Core client is used for all groups, as the path of the resource is built here.
+func listTable(client RESTClient, gvr schema.GroupVersionResource, namespace string,
+	opts metaV1.ListOptions) (*metaV1.Table, error) {
+	path := []string{"/apis", gvr.Group, gvr.Version}
+	if len(gvr.Group) == 0 {
+		path = []string{"/api", gvr.Version}
+	}
+
+	if len(namespace) > 0 {
+		path = append(path, "namespaces", namespace)
+	}
+
+	raw, err := client.Get().AbsPath(append(path, gvr.Resource)...).
+		SetHeader("Accept", TableAcceptHeader).
+		VersionedParams(&opts, metaV1.ParameterCodec).
+		Do(context.TODO()).
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	table := &metaV1.Table{}
+	if err := json.Unmarshal(raw, table); err != nil {
+		return nil, err
+	}
+
+	if table.Kind != "Table" {
+		return nil, fmt.Errorf("server did not return a table for %s, got %s", gvr.String(), table.Kind)
+	}
+
+	return table, nil
+}
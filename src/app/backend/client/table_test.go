@@ -0,0 +1,82 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	restful "github.com/emicklei/go-restful/v3"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestListTable(t *testing.T) {
+	var accept, path, limit string
+	body := `{"kind":"Table","apiVersion":"meta.k8s.io/v1","metadata":{"resourceVersion":"7"},` +
+		`"columnDefinitions":[{"name":"Name","type":"string","format":"name","description":"Name","priority":0},` +
+		`{"name":"Size","type":"integer","format":"","description":"From additionalPrinterColumns","priority":1}],` +
+		`"rows":[{"cells":["small",3],"object":{"kind":"PartialObjectMetadata","apiVersion":"meta.k8s.io/v1",` +
+		`"metadata":{"name":"small","namespace":"default"}}}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+		path = r.URL.Path
+		limit = r.URL.Query().Get("limit")
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	manager := NewClientManager("", server.URL)
+	req := &restful.Request{Request: &http.Request{
+		Header: http.Header{"Authorization": {"Bearer test-token"}},
+		TLS:    &tls.ConnectionState{},
+	}}
+	gvr := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+
+	table, err := manager.ListTable(req, gvr, "default", metaV1.ListOptions{Limit: 10})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if accept != TableAcceptHeader {
+		t.Errorf("Expected table to be requested, got Accept header %q", accept)
+	}
+
+	if path != "/apis/example.com/v1/namespaces/default/widgets" || limit != "10" {
+		t.Errorf("Expected namespaced list request with limit, got %s?limit=%s", path, limit)
+	}
+
+	if len(table.ColumnDefinitions) != 2 || table.ColumnDefinitions[1].Name != "Size" ||
+		table.ColumnDefinitions[1].Priority != 1 || table.ResourceVersion != "7" {
+		t.Errorf("Unexpected columns: %+v", table.ColumnDefinitions)
+	}
+
+	if len(table.Rows) != 1 || len(table.Rows[0].Cells) != 2 || table.Rows[0].Cells[0] != "small" ||
+		table.Rows[0].Cells[1] != float64(3) || len(table.Rows[0].Object.Raw) == 0 {
+		t.Errorf("Unexpected rows: %+v", table.Rows)
+	}
+
+	body = `{"kind":"PodList","apiVersion":"v1","metadata":{},"items":[]}`
+	if _, err := manager.ListTable(req, schema.GroupVersionResource{Version: "v1", Resource: "pods"}, "",
+		metaV1.ListOptions{}); err == nil {
+		t.Error("Expected error when server does not return a table")
+	}
+
+	if path != "/api/v1/pods" {
+		t.Errorf("Expected core resources to be requested from /api, got %s", path)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+const (
+	// Maximum time to wait before retrying request throttled by the apiserver, regardless of the Retry-After header.
+	MaxThrottleRetryWait = 5 * time.Second
+	// Time to wait before retrying throttled request that does not specify Retry-After header.
+	DefaultThrottleRetryWait = time.Second
+)
+
+// Configures transport of the given config to retry requests throttled by the apiserver once.
+func configureThrottleRetry(cfg *rest.Config) {
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &throttleRetryRoundTripper{delegate: rt, maxWait: MaxThrottleRetryWait, sleep: sleepWithContext}
+	})
+}
+
+// throttleRetryRoundTripper retries requests rejected with 429 status once after waiting for the time requested
+// by the apiserver. If the retry is throttled too, the response is replaced with the throttled error without
+// Retry-After header, so that the client does not keep retrying and the error is reported to the user instead.
+type throttleRetryRoundTripper struct {
+	delegate http.RoundTripper
+	maxWait  time.Duration
+	// Used to override waiting in tests. Returns false if the request was cancelled while waiting.
+	sleep func(req *http.Request, duration time.Duration) bool
+}
+
+// RoundTrip implements http.RoundTripper.
+func (self *throttleRetryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := self.delegate.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusTooManyRequests || isEvictionRequest(req) {
+		return resp, err
+	}
+
+	wait := self.retryWait(resp)
+	retry, canRetry := cloneForRetry(req)
+	if !canRetry {
+		return resp, nil
+	}
+
+	drainBody(resp)
+	if !self.sleep(req, wait) {
+		return nil, req.Context().Err()
+	}
+
+	resp, err = self.delegate.RoundTrip(retry)
+	if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+		return resp, err
+	}
+
+	wait = self.retryWait(resp)
+	drainBody(resp)
+	return throttledResponse(req, wait), nil
+}
+
+// WrappedRoundTripper allows client-go to reach the underlying transport, i.e. to close idle connections.
+func (self *throttleRetryRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return self.delegate
+}
+
+// Returns time to wait requested with Retry-After header capped by the max wait.
+func (self *throttleRetryRoundTripper) retryWait(resp *http.Response) time.Duration {
+	wait := DefaultThrottleRetryWait
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		wait = time.Duration(seconds) * time.Second
+	}
+
+	if wait > self.maxWait {
+		return self.maxWait
+	}
+
+	return wait
+}
+
+// Returns copy of the request that can be sent again. Requests with body can be retried only if it can be recreated.
+func cloneForRetry(req *http.Request) (*http.Request, bool) {
+	retry := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return retry, true
+	}
+
+	if req.GetBody == nil {
+		return nil, false
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+
+	retry.Body = body
+	return retry, true
+}
+
+// Evictions blocked by the pod disruption budget are rejected with 429 status too, but they are not throttled and
+// should be reported to the user as they are.
+func isEvictionRequest(req *http.Request) bool {
+	return strings.HasSuffix(req.URL.Path, "/eviction")
+}
+
+func drainBody(resp *http.Response) {
+	if resp.Body != nil {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+}
+
+// Returns response with the throttled error, so that it is decoded by the client as any other apiserver error.
+func throttledResponse(req *http.Request, retryAfter time.Duration) *http.Response {
+	body, _ := json.Marshal(errors.NewThrottled(int(retryAfter.Seconds())).ErrStatus)
+	return &http.Response{
+		Status:        strconv.Itoa(http.StatusTooManyRequests) + " " + http.StatusText(http.StatusTooManyRequests),
+		StatusCode:    http.StatusTooManyRequests,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+func sleepWithContext(req *http.Request, duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-req.Context().Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+func TestThrottleRetry(t *testing.T) {
+	cases := []struct {
+		info          string
+		throttled     int32
+		wantThrottled bool
+		wantRequests  int32
+	}{
+		{"should not retry request that was not throttled", 0, false, 1},
+		{"should retry throttled request once", 1, false, 2},
+		{"should return throttled error when retry is throttled", 10, true, 2},
+	}
+
+	for _, c := range cases {
+		t.Run(c.info, func(t *testing.T) {
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if atomic.AddInt32(&requests, 1) <= c.throttled {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(http.StatusTooManyRequests)
+					w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"TooManyRequests","code":429}`))
+					return
+				}
+				w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","metadata":{},"items":[]}`))
+			}))
+			defer server.Close()
+
+			cfg := &rest.Config{Host: server.URL}
+			configureThrottleRetry(cfg)
+			client, err := kubernetes.NewForConfig(cfg)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			_, err = client.CoreV1().Pods("default").List(context.TODO(), metaV1.ListOptions{})
+			if errors.IsThrottled(err) != c.wantThrottled {
+				t.Errorf("Expected throttled error to be %v, got %v", c.wantThrottled, err)
+			}
+
+			if !c.wantThrottled && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+
+			if got := atomic.LoadInt32(&requests); got != c.wantRequests {
+				t.Errorf("Expected %d requests, got %d", c.wantRequests, got)
+			}
+		})
+	}
+}
+
+func TestThrottleRetryWait(t *testing.T) {
+	cases := []struct {
+		retryAfter string
+		expected   time.Duration
+	}{
+		{"", DefaultThrottleRetryWait},
+		{"invalid", DefaultThrottleRetryWait},
+		{"0", 0},
+		{"2", 2 * time.Second},
+		{"120", MaxThrottleRetryWait},
+	}
+
+	for _, c := range cases {
+		var slept time.Duration
+		rt := &throttleRetryRoundTripper{
+			delegate: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}, Body: http.NoBody}
+				if len(c.retryAfter) > 0 {
+					resp.Header.Set("Retry-After", c.retryAfter)
+				}
+				return resp, nil
+			}),
+			maxWait: MaxThrottleRetryWait,
+			sleep: func(req *http.Request, duration time.Duration) bool {
+				slept = duration
+				return true
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil)
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if slept != c.expected {
+			t.Errorf("Expected to wait %v for Retry-After %q, got %v", c.expected, c.retryAfter, slept)
+		}
+
+		if resp.StatusCode != http.StatusTooManyRequests || len(resp.Header.Get("Retry-After")) > 0 {
+			t.Errorf("Expected throttled response without Retry-After header, got %d %v", resp.StatusCode, resp.Header)
+		}
+	}
+}
+
+func TestThrottleRetrySkipsEvictions(t *testing.T) {
+	requests := 0
+	rt := &throttleRetryRoundTripper{
+		delegate: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			requests++
+			return &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"10"}},
+				Body: http.NoBody}, nil
+		}),
+		maxWait: MaxThrottleRetryWait,
+		sleep: func(req *http.Request, duration time.Duration) bool {
+			t.Error("Expected eviction not to be retried")
+			return true
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/namespaces/default/pods/pod/eviction", nil)
+	resp, err := rt.RoundTrip(req)
+	if err != nil || requests != 1 || resp.Header.Get("Retry-After") != "10" {
+		t.Errorf("Expected response of the eviction to be returned unchanged, got %v, %v", resp, err)
+	}
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (self roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return self(req)
+}
@@ -0,0 +1,78 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	v1 "k8s.io/api/authorization/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+// MinRequestedTokenTTL is the shortest validity of the token accepted by the TokenRequest API.
+const MinRequestedTokenTTL = 10 * time.Minute
+
+// RequestToken mints a token of the service account restricted to the given audiences using the TokenRequest API
+// and credentials of the user. Zero TTL means that the default validity set by the apiserver is used. Forbidden
+// error is returned if user is not allowed to request tokens of the service account.
+func (self *clientManager) RequestToken(req *restful.Request, serviceAccount, namespace string, audiences []string,
+	ttl time.Duration) (string, error) {
+	client, err := self.Client(req)
+	if err != nil {
+		return "", err
+	}
+
+	return requestToken(client, serviceAccount, namespace, audiences, ttl)
+}
+
+func requestToken(client kubernetes.Interface, serviceAccount, namespace string, audiences []string,
+	ttl time.Duration) (string, error) {
+	if len(serviceAccount) == 0 || len(namespace) == 0 {
+		return "", errors.NewBadRequest("service account name and namespace are required")
+	}
+
+	if ttl != 0 && ttl < MinRequestedTokenTTL {
+		return "", errors.NewBadRequest(fmt.Sprintf("token TTL must be at least %s", MinRequestedTokenTTL))
+	}
+
+	permission := v1.ResourceAttributes{Verb: "create", Resource: "serviceaccounts", Subresource: "token",
+		Namespace: namespace, Name: serviceAccount}
+	if len(MissingPermissions(client, []v1.ResourceAttributes{permission})) > 0 {
+		return "", errors.NewForbidden(fmt.Sprintf("not allowed to request token of %s/%s service account",
+			namespace, serviceAccount))
+	}
+
+	request := &authenticationv1.TokenRequest{Spec: authenticationv1.TokenRequestSpec{Audiences: audiences}}
+	if ttl != 0 {
+		seconds := int64(ttl.Seconds())
+		request.Spec.ExpirationSeconds = &seconds
+	}
+
+	response, err := client.CoreV1().ServiceAccounts(namespace).CreateToken(context.TODO(), serviceAccount, request,
+		metaV1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	return response.Status.Token, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	v1 "k8s.io/api/authorization/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientTesting "k8s.io/client-go/testing"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+func TestRequestToken(t *testing.T) {
+	tokenPermission := v1.ResourceAttributes{Verb: "create", Resource: "serviceaccounts", Subresource: "token",
+		Namespace: "default", Name: "integration"}
+	cases := []struct {
+		info           string
+		serviceAccount string
+		ttl            time.Duration
+		denied         []v1.ResourceAttributes
+		wantToken      string
+		wantCheck      func(error) bool
+		wantExpiration *int64
+	}{
+		{"should return minted token", "integration", time.Hour, nil, "minted-token", nil, int64Ptr(3600)},
+		{"should use default TTL", "integration", 0, nil, "minted-token", nil, nil},
+		{"should require permission to create token", "integration", time.Hour,
+			[]v1.ResourceAttributes{tokenPermission}, "", k8serrors.IsForbidden, nil},
+		{"should reject too short TTL", "integration", time.Minute, nil, "", errors.IsBadRequest, nil},
+		{"should require service account name", "", time.Hour, nil, "", errors.IsBadRequest, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.info, func(t *testing.T) {
+			client := newAccessReviewClient(c.denied...)
+			var request *authenticationv1.TokenRequest
+			client.PrependReactor("create", "serviceaccounts",
+				func(action clientTesting.Action) (bool, runtime.Object, error) {
+					if action.GetSubresource() != "token" {
+						return false, nil, nil
+					}
+
+					request = action.(clientTesting.CreateAction).GetObject().(*authenticationv1.TokenRequest)
+					response := request.DeepCopy()
+					response.Status.Token = "minted-token"
+					return true, response, nil
+				})
+
+			token, err := requestToken(client, c.serviceAccount, "default", []string{"vault"}, c.ttl)
+			if c.wantCheck != nil {
+				if !c.wantCheck(err) {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if token != c.wantToken {
+				t.Errorf("Expected token %s, got %s", c.wantToken, token)
+			}
+
+			if len(request.Spec.Audiences) != 1 || request.Spec.Audiences[0] != "vault" {
+				t.Errorf("Expected token to be restricted to vault audience, got %v", request.Spec.Audiences)
+			}
+
+			if (c.wantExpiration == nil) != (request.Spec.ExpirationSeconds == nil) ||
+				(c.wantExpiration != nil && *c.wantExpiration != *request.Spec.ExpirationSeconds) {
+				t.Errorf("Expected expiration %v, got %v", c.wantExpiration, request.Spec.ExpirationSeconds)
+			}
+		})
+	}
+}
+
+func int64Ptr(value int64) *int64 {
+	return &value
+}
@@ -0,0 +1,172 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/rest"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+)
+
+// Time after which idle connections are considered closed by the transport. It matches idle connection timeout set
+// by the client-go transport defaults.
+const idleConnectionTimeout = 90 * time.Second
+
+// TransportStats returns number of active and idle connections per apiserver host held by the transports of all
+// clients created by the client manager. Connections are observed from the outside of the transport, so idle
+// connections closed by the transport itself are counted until idle connection timeout elapses.
+func (self *clientManager) TransportStats() map[string]clientapi.ConnectionStats {
+	return self.connectionTracker.stats()
+}
+
+// connectionTracker observes connections used by the requests passing through wrapped transports. It does not
+// change the transports, so the client-go transport cache keeps working as before.
+type connectionTracker struct {
+	mux   sync.Mutex
+	conns map[net.Conn]*trackedConnection
+	// Used to override time in tests.
+	now func() time.Time
+}
+
+type trackedConnection struct {
+	host      string
+	active    int
+	idleSince time.Time
+}
+
+func newConnectionTracker() *connectionTracker {
+	return &connectionTracker{conns: make(map[net.Conn]*trackedConnection), now: time.Now}
+}
+
+// Adds connection tracking to the transport of the given config.
+func (self *connectionTracker) configure(cfg *rest.Config) {
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &countingRoundTripper{delegate: rt, tracker: self}
+	})
+}
+
+func (self *connectionTracker) acquire(host string, conn net.Conn) {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	tracked, ok := self.conns[conn]
+	if !ok {
+		tracked = &trackedConnection{host: host}
+		self.conns[conn] = tracked
+	}
+
+	tracked.active++
+}
+
+func (self *connectionTracker) release(conn net.Conn) {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	if tracked, ok := self.conns[conn]; ok && tracked.active > 0 {
+		tracked.active--
+		if tracked.active == 0 {
+			tracked.idleSince = self.now()
+		}
+	}
+}
+
+func (self *connectionTracker) stats() map[string]clientapi.ConnectionStats {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	result := make(map[string]clientapi.ConnectionStats)
+	for conn, tracked := range self.conns {
+		if tracked.active == 0 && self.now().Sub(tracked.idleSince) > idleConnectionTimeout {
+			delete(self.conns, conn)
+			continue
+		}
+
+		stats := result[tracked.host]
+		if tracked.active > 0 {
+			stats.Active++
+		} else {
+			stats.Idle++
+		}
+
+		result[tracked.host] = stats
+	}
+
+	return result
+}
+
+// countingRoundTripper reports connections used by requests to the connection tracker. Connection is considered
+// active until response body is closed or fully read.
+type countingRoundTripper struct {
+	delegate http.RoundTripper
+	tracker  *connectionTracker
+}
+
+// RoundTrip implements http.RoundTripper.
+func (self *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	var conn net.Conn
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			conn = info.Conn
+			self.tracker.acquire(req.URL.Host, conn)
+		},
+	}
+
+	resp, err := self.delegate.RoundTrip(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+	if conn == nil {
+		return resp, err
+	}
+
+	if err != nil || resp.Body == nil {
+		self.tracker.release(conn)
+		return resp, err
+	}
+
+	resp.Body = &trackedBody{ReadCloser: resp.Body, release: func() { self.tracker.release(conn) }}
+	return resp, nil
+}
+
+// WrappedRoundTripper allows client-go to reach the underlying transport, i.e. to close idle connections.
+func (self *countingRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return self.delegate
+}
+
+// trackedBody releases tracked connection once it is closed or fully read.
+type trackedBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (self *trackedBody) Read(p []byte) (int, error) {
+	n, err := self.ReadCloser.Read(p)
+	if err == io.EOF {
+		self.once.Do(self.release)
+	}
+
+	return n, err
+}
+
+func (self *trackedBody) Close() error {
+	err := self.ReadCloser.Close()
+	self.once.Do(self.release)
+	return err
+}
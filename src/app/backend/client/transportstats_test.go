@@ -0,0 +1,94 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestCountingRoundTripper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	host, _ := url.Parse(server.URL)
+
+	now := time.Now()
+	tracker := newConnectionTracker()
+	tracker.now = func() time.Time { return now }
+
+	cfg := &rest.Config{}
+	tracker.configure(cfg)
+	client := &http.Client{Transport: cfg.WrapTransport(&http.Transport{})}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if stats := tracker.stats()[host.Host]; stats.Active != 1 || stats.Idle != 0 {
+		t.Fatalf("Expected connection to be active until body is read, but got %#v", stats)
+	}
+
+	io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if stats := tracker.stats()[host.Host]; stats.Active != 0 || stats.Idle != 1 {
+		t.Fatalf("Expected connection to be idle after body is read, but got %#v", stats)
+	}
+
+	resp, err = client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if stats := tracker.stats()[host.Host]; stats.Active != 0 || stats.Idle != 1 {
+		t.Fatalf("Expected idle connection to be reused, but got %#v", stats)
+	}
+
+	now = now.Add(2 * idleConnectionTimeout)
+	if stats, ok := tracker.stats()[host.Host]; ok {
+		t.Fatalf("Expected connections idle for longer than timeout to be dropped, but got %#v", stats)
+	}
+}
+
+func TestCountingRoundTripperRequestError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	defer server.Close()
+
+	tracker := newConnectionTracker()
+	cfg := &rest.Config{}
+	tracker.configure(cfg)
+	client := &http.Client{Transport: cfg.WrapTransport(&http.Transport{})}
+
+	if _, err := client.Get(server.URL); err == nil {
+		t.Fatal("Expected request to fail")
+	}
+
+	for host, stats := range tracker.stats() {
+		if stats.Active != 0 {
+			t.Fatalf("Expected failed request to release connection to %s, but got %#v", host, stats)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/emicklei/go-restful/v3"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+// ValidateManifest validates every document of the YAML or JSON manifest with a dry-run create using credentials of
+// the user, so that schema validation and admission are done by the apiserver. Problems found in the documents are
+// returned as validation errors, error is returned only when manifest could not be validated at all.
+func (self *clientManager) ValidateManifest(req *restful.Request, manifest []byte) ([]clientapi.ValidationError,
+	error) {
+	cfg, err := self.Config(req)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return validateManifest(client, self.restMapper(), manifest)
+}
+
+func validateManifest(client dynamic.Interface, mapper meta.ResettableRESTMapper,
+	manifest []byte) ([]clientapi.ValidationError, error) {
+	objects, err := decodeManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]clientapi.ValidationError, 0)
+	for i, obj := range objects {
+		resource, err := manifestResource(client, mapper, obj, metaV1.NamespaceDefault)
+		if err == nil {
+			_, err = resource.Create(context.TODO(), obj, metaV1.CreateOptions{DryRun: []string{metaV1.DryRunAll}})
+		}
+
+		if err == nil {
+			continue
+		}
+
+		errs, ok := toValidationErrors(err)
+		if !ok {
+			return nil, err
+		}
+
+		for _, e := range errs {
+			e.Document, e.Kind, e.Name = i, obj.GetKind(), obj.GetName()
+			result = append(result, e)
+		}
+	}
+
+	return result, nil
+}
+
+// Splits YAML or JSON manifest into objects. Empty documents are skipped.
+func decodeManifest(manifest []byte) ([]*unstructured.Unstructured, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifest), 4096)
+	result := make([]*unstructured.Unstructured, 0)
+	for {
+		raw := json.RawMessage{}
+		if err := decoder.Decode(&raw); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("document %d could not be parsed: %s", len(result),
+				err.Error()))
+		}
+
+		if raw = bytes.TrimSpace(raw); len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(raw); err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("document %d could not be parsed: %s", len(result),
+				err.Error()))
+		}
+
+		if len(obj.GetAPIVersion()) == 0 || len(obj.GetKind()) == 0 {
+			return nil, errors.NewBadRequest(fmt.Sprintf("document %d is missing apiVersion or kind", len(result)))
+		}
+
+		result = append(result, obj)
+	}
+
+	if len(result) == 0 {
+		return nil, errors.NewBadRequest("manifest does not contain any objects")
+	}
+
+	return result, nil
+}
+
+// Returns client of the resource of the given object. Namespaced objects without namespace are placed in the
+// given default namespace, the same way as kubectl does.
+func manifestResource(client dynamic.Interface, mapper meta.ResettableRESTMapper, obj *unstructured.Unstructured,
+	defaultNamespace string) (dynamic.ResourceInterface, error) {
+	gv, err := schema.ParseGroupVersion(obj.GetAPIVersion())
+	if err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+
+	mapping, err := restMapping(mapper, gv.WithKind(obj.GetKind()))
+	if meta.IsNoMatchError(err) {
+		return nil, errors.NewBadRequest(fmt.Sprintf("kind %s is not served by the apiserver", obj.GroupVersionKind()))
+	} else if err != nil {
+		return nil, err
+	}
+
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return client.Resource(mapping.Resource), nil
+	}
+
+	if len(obj.GetNamespace()) == 0 {
+		obj.SetNamespace(defaultNamespace)
+	}
+
+	return client.Resource(mapping.Resource).Namespace(obj.GetNamespace()), nil
+}
+
+// Converts error returned for the document into validation errors. Only client errors other than missing
+// credentials describe problems of the document, all other errors are not converted.
+func toValidationErrors(err error) ([]clientapi.ValidationError, bool) {
+	status, ok := err.(k8serrors.APIStatus)
+	if !ok {
+		return nil, false
+	}
+
+	code := status.Status().Code
+	if code < http.StatusBadRequest || code >= http.StatusInternalServerError || code == http.StatusUnauthorized {
+		return nil, false
+	}
+
+	reason := string(k8serrors.ReasonForError(err))
+	if details := status.Status().Details; details != nil && len(details.Causes) > 0 {
+		result := make([]clientapi.ValidationError, 0, len(details.Causes))
+		for _, cause := range details.Causes {
+			e := clientapi.ValidationError{Field: cause.Field, Reason: string(cause.Type), Message: cause.Message}
+			if len(e.Reason) == 0 {
+				e.Reason = reason
+			}
+			result = append(result, e)
+		}
+		return result, true
+	}
+
+	return []clientapi.ValidationError{{Reason: reason, Message: status.Status().Message}}, true
+}
@@ -0,0 +1,116 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clientTesting "k8s.io/client-go/testing"
+
+	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+const validManifest = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data:
+  key: value
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: team
+spec:
+  replicas: 1
+`
+
+const invalidManifest = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+---
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: -1
+---
+apiVersion: example.com/v1
+kind: Unknown
+metadata:
+  name: unknown
+`
+
+func newValidationClient() *dynamicfake.FakeDynamicClient {
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	client.PrependReactor("create", "deployments", func(action clientTesting.Action) (bool, runtime.Object, error) {
+		content := action.(clientTesting.CreateAction).GetObject().(runtime.Unstructured).UnstructuredContent()
+		spec, _ := content["spec"].(map[string]interface{})
+		if replicas, _ := spec["replicas"].(int64); replicas < 0 {
+			return true, nil, k8serrors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "Deployment"}, "web",
+				field.ErrorList{field.Invalid(field.NewPath("spec", "replicas"), replicas,
+					"must be greater than or equal to 0")})
+		}
+
+		return true, nil, nil
+	})
+	return client
+}
+
+func TestValidateManifest(t *testing.T) {
+	errs, err := validateManifest(newValidationClient(), newTestRESTMapper(), []byte(validManifest))
+	if err != nil || len(errs) != 0 {
+		t.Errorf("Expected valid manifest to have no validation errors, got %v, %v", errs, err)
+	}
+}
+
+func TestValidateManifestInvalid(t *testing.T) {
+	errs, err := validateManifest(newValidationClient(), newTestRESTMapper(), []byte(invalidManifest))
+	if err != nil {
+		t.Fatalf("Expected validation errors instead of error, got %v", err)
+	}
+
+	expected := []clientapi.ValidationError{
+		{Document: 1, Kind: "Deployment", Name: "web", Field: "spec.replicas", Reason: "FieldValueInvalid",
+			Message: "Invalid value: -1: must be greater than or equal to 0"},
+		{Document: 2, Kind: "Unknown", Name: "unknown", Reason: "BadRequest",
+			Message: "kind example.com/v1, Kind=Unknown is not served by the apiserver"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Expected validation errors %v, got %v", expected, errs)
+	}
+}
+
+func TestValidateManifestMalformed(t *testing.T) {
+	for _, manifest := range []string{"key: [", "---\n---\n", "metadata:\n  name: web\n"} {
+		_, err := validateManifest(newValidationClient(), newTestRESTMapper(), []byte(manifest))
+		if !errors.IsBadRequest(err) {
+			t.Errorf("Expected bad request for manifest %q, got %v", manifest, err)
+		}
+	}
+}
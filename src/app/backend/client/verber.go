@@ -15,16 +15,22 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
 	restclient "k8s.io/client-go/rest"
 
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/api"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
 	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/customresourcedefinition"
@@ -144,19 +150,29 @@ type RESTClient interface {
 	Delete() *restclient.Request
 	Put() *restclient.Request
 	Get() *restclient.Request
+	Patch(pt types.PatchType) *restclient.Request
 }
 
+// ApplyFieldManager is the name of the field manager used for server-side apply requests made by the verber.
+const ApplyFieldManager = "dashboard"
+
+// ManagedByLabel is set on resources created or edited by the verber when 'stamp-managed-by' argument is enabled.
+// Its value is the name of the field manager.
+const ManagedByLabel = "dashboard.k8s.io/managed-by"
+
 // NewResourceVerber creates a new resource verber that uses the given client for performing operations.
 func NewResourceVerber(client, appsClient, batchClient, betaBatchClient, autoscalingClient, storageClient, rbacClient, networkingClient, apiExtensionsClient, pluginsClient RESTClient, config *restclient.Config) clientapi.ResourceVerber {
 	return &resourceVerber{client, appsClient,
 		batchClient, betaBatchClient, autoscalingClient, storageClient, rbacClient, networkingClient, apiExtensionsClient, pluginsClient, config}
 }
 
-// Delete deletes the resource of the given kind in the given namespace with the given name.
-func (verber *resourceVerber) Delete(kind string, namespaceSet bool, namespace string, name string) error {
+// Delete deletes the resource of the given kind in the given namespace with the given name. In dry-run mode the
+// request is only validated by the apiserver and nothing is deleted. Returns response of the apiserver.
+func (verber *resourceVerber) Delete(kind string, namespaceSet bool, namespace string, name string,
+	dryRun bool) (runtime.Object, error) {
 	client, resourceSpec, err := verber.getResourceSpecFromKind(kind, namespaceSet)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Do cascade delete by default, as this is what users typically expect.
@@ -165,39 +181,104 @@ func (verber *resourceVerber) Delete(kind string, namespaceSet bool, namespace s
 		PropagationPolicy: &defaultPropagationPolicy,
 	}
 
+	if dryRun {
+		defaultDeleteOptions.DryRun = []string{v1.DryRunAll}
+	}
+
 	req := client.Delete().Resource(resourceSpec.Resource).Name(name).Body(defaultDeleteOptions)
 
 	if resourceSpec.Namespaced {
 		req.Namespace(namespace)
 	}
 
-	return req.Do(context.TODO()).Error()
+	return doRaw(req)
 }
 
-// Put puts new resource version of the given kind in the given namespace with the given name.
-func (verber *resourceVerber) Put(kind string, namespaceSet bool, namespace string, name string,
-	object *runtime.Unknown) error {
+// Put puts new resource version of the given kind in the given namespace with the given name. Non-empty subresource,
+// e.g. status, is updated instead of the main resource. In dry-run mode the object is not persisted and the object
+// that would be stored is returned.
+func (verber *resourceVerber) Put(kind string, namespaceSet bool, namespace string, name string, subresource string,
+	object *runtime.Unknown, dryRun bool) (runtime.Object, error) {
 
 	client, resourceSpec, err := verber.getResourceSpecFromKind(kind, namespaceSet)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	body := object.Raw
+	if len(subresource) == 0 {
+		if body, err = stampManagedBy(body); err != nil {
+			return nil, err
+		}
 	}
 
 	req := client.Put().
 		Resource(resourceSpec.Resource).
 		Name(name).
 		SetHeader("Content-Type", "application/json").
-		Body([]byte(object.Raw))
+		Body(body)
+
+	if len(subresource) > 0 {
+		req.SubResource(subresource)
+	}
+
+	if dryRun {
+		req.Param("dryRun", v1.DryRunAll)
+	}
 
 	if resourceSpec.Namespaced {
 		req.Namespace(namespace)
 	}
 
-	return req.Do(context.TODO()).Error()
+	return doRaw(req)
 }
 
-// Get gets the resource of the given kind in the given namespace with the given name.
-func (verber *resourceVerber) Get(kind string, namespaceSet bool, namespace string, name string) (runtime.Object, error) {
+// Apply applies the given configuration of the resource of the given kind in the given namespace with the given name
+// using server-side apply. Configuration can be provided either as a JSON or YAML. Fields owned by other managers are
+// overwritten only when force is set. In dry-run mode the object is not persisted and the object that would be stored
+// is returned.
+func (verber *resourceVerber) Apply(kind string, namespaceSet bool, namespace string, name string,
+	object *runtime.Unknown, force, dryRun bool) (runtime.Object, error) {
+	client, resourceSpec, err := verber.getResourceSpecFromKind(kind, namespaceSet)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is a subset of YAML, so both formats are converted to JSON and sent as an apply patch.
+	body, err := yaml.ToJSON(object.Raw)
+	if err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid apply configuration: %s", err.Error()))
+	}
+
+	if body, err = stampManagedBy(body); err != nil {
+		return nil, err
+	}
+
+	req := client.Patch(types.ApplyPatchType).
+		Resource(resourceSpec.Resource).
+		Name(name).
+		Param("fieldManager", ApplyFieldManager).
+		Body(body)
+
+	if force {
+		req.Param("force", "true")
+	}
+
+	if dryRun {
+		req.Param("dryRun", v1.DryRunAll)
+	}
+
+	if resourceSpec.Namespaced {
+		req.Namespace(namespace)
+	}
+
+	return doRaw(req)
+}
+
+// Get gets the resource of the given kind in the given namespace with the given name. Non-empty subresource, e.g.
+// status, is read instead of the main resource.
+func (verber *resourceVerber) Get(kind string, namespaceSet bool, namespace string, name string,
+	subresource string) (runtime.Object, error) {
 	client, resourceSpec, err := verber.getResourceSpecFromKind(kind, namespaceSet)
 	if err != nil {
 		return nil, err
@@ -206,6 +287,10 @@ func (verber *resourceVerber) Get(kind string, namespaceSet bool, namespace stri
 	result := &runtime.Unknown{}
 	req := client.Get().Resource(resourceSpec.Resource).Name(name).SetHeader("Accept", "application/json")
 
+	if len(subresource) > 0 {
+		req.SubResource(subresource)
+	}
+
 	if resourceSpec.Namespaced {
 		req.Namespace(namespace)
 	}
@@ -213,3 +298,47 @@ func (verber *resourceVerber) Get(kind string, namespaceSet bool, namespace stri
 	err = req.Do(context.TODO()).Into(result)
 	return result, err
 }
+
+// Sends the request and returns the response of the apiserver without decoding it.
+func doRaw(req *restclient.Request) (runtime.Object, error) {
+	raw, err := req.SetHeader("Accept", "application/json").Do(context.TODO()).Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	return &runtime.Unknown{Raw: raw, ContentType: runtime.ContentTypeJSON}, nil
+}
+
+// Adds managed-by label to the JSON object if 'stamp-managed-by' argument is enabled. Value set by the user is kept.
+func stampManagedBy(body []byte) ([]byte, error) {
+	if !args.Holder.GetStampManagedBy() {
+		return body, nil
+	}
+
+	// Numbers are kept as they are, so that the rest of the object is not changed by the round trip.
+	obj := make(map[string]interface{})
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid object: %s", err.Error()))
+	}
+
+	labels, _, err := unstructured.NestedStringMap(obj, "metadata", "labels")
+	if err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid object labels: %s", err.Error()))
+	}
+
+	if _, ok := labels[ManagedByLabel]; ok {
+		return body, nil
+	}
+
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[ManagedByLabel] = ApplyFieldManager
+	if err := unstructured.SetNestedStringMap(obj, labels, "metadata", "labels"); err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid object metadata: %s", err.Error()))
+	}
+
+	return json.Marshal(obj)
+}
@@ -15,6 +15,7 @@
 package client
 
 import (
+	"io"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -24,10 +25,12 @@ import (
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/rest/fake"
 
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
 )
 
@@ -40,10 +43,12 @@ func (f clientFunc) Do(req *http.Request) (*http.Response, error) {
 type FakeRESTClient struct {
 	response *http.Response
 	err      error
+	request  *http.Request
 }
 
 func NewFakeClientFunc(c *FakeRESTClient) clientFunc {
 	return clientFunc(func(req *http.Request) (*http.Response, error) {
+		c.request = req
 		return c.response, c.err
 	})
 }
@@ -72,6 +77,10 @@ func (c *FakeRESTClient) Get() *restclient.Request {
 	return restclient.NewRequestWithClient(&url.URL{Path: "/api/v1/"}, "", restclient.ClientContentConfig{}, fake.CreateHTTPClient(NewFakeClientFunc(c))).Verb("GET")
 }
 
+func (c *FakeRESTClient) Patch(pt types.PatchType) *restclient.Request {
+	return restclient.NewRequestWithClient(&url.URL{Path: "/api/v1/"}, "", restclient.ClientContentConfig{}, fake.CreateHTTPClient(NewFakeClientFunc(c))).Verb("PATCH").SetHeader("Content-Type", string(pt))
+}
+
 // Removes all quote signs that might have been added to the message.
 // Might depend on dependencies version how they are constructed.
 func normalize(msg string) string {
@@ -84,19 +93,19 @@ func TestDeleteShouldPropagateErrorsAndChooseClient(t *testing.T) {
 		appsClient: &FakeRESTClient{err: errors.NewInvalid("err from apps")},
 	}
 
-	err := verber.Delete("replicaset", true, "bar", "baz")
+	_, err := verber.Delete("replicaset", true, "bar", "baz", false)
 
 	if !reflect.DeepEqual(normalize(err.Error()), "Delete /api/v1/namespaces/bar/replicasets/baz: err from apps") {
 		t.Fatalf("Expected error on verber delete but got %#v", err.Error())
 	}
 
-	err = verber.Delete("service", true, "bar", "baz")
+	_, err = verber.Delete("service", true, "bar", "baz", false)
 
 	if !reflect.DeepEqual(normalize(err.Error()), "Delete /api/v1/namespaces/bar/services/baz: err") {
 		t.Fatalf("Expected error on verber delete but got %#v", err.Error())
 	}
 
-	err = verber.Delete("statefulset", true, "bar", "baz")
+	_, err = verber.Delete("statefulset", true, "bar", "baz", false)
 
 	if !reflect.DeepEqual(normalize(err.Error()), "Delete /api/v1/namespaces/bar/statefulsets/baz: err from apps") {
 		t.Fatalf("Expected error on verber delete but got %#v", err.Error())
@@ -109,19 +118,19 @@ func TestGetShouldPropagateErrorsAndChoseClient(t *testing.T) {
 		appsClient: &FakeRESTClient{err: errors.NewInvalid("err from apps")},
 	}
 
-	_, err := verber.Get("replicaset", true, "bar", "baz")
+	_, err := verber.Get("replicaset", true, "bar", "baz", "")
 
 	if !reflect.DeepEqual(normalize(err.Error()), "Get /api/v1/namespaces/bar/replicasets/baz: err from apps") {
 		t.Fatalf("Expected error on verber delete but got %#v", err.Error())
 	}
 
-	_, err = verber.Get("service", true, "bar", "baz")
+	_, err = verber.Get("service", true, "bar", "baz", "")
 
 	if !reflect.DeepEqual(normalize(err.Error()), "Get /api/v1/namespaces/bar/services/baz: err") {
 		t.Fatalf("Expected error on verber delete but got %#v", err.Error())
 	}
 
-	_, err = verber.Get("statefulset", true, "bar", "baz")
+	_, err = verber.Get("statefulset", true, "bar", "baz", "")
 
 	if !reflect.DeepEqual(normalize(err.Error()), "Get /api/v1/namespaces/bar/statefulsets/baz: err from apps") {
 		t.Fatalf("Expected error on verber delete but got %#v", err.Error())
@@ -134,7 +143,7 @@ func TestDeleteShouldThrowErrorOnUnknownResourceKind(t *testing.T) {
 		apiExtensionsClient: &FakeRESTClient{err: errors.NewNotFound("err")},
 	}
 
-	err := verber.Delete("foo", true, "bar", "baz")
+	_, err := verber.Delete("foo", true, "bar", "baz", false)
 
 	if !reflect.DeepEqual(normalize(err.Error()), "Get /api/v1/customresourcedefinitions/foo: err") {
 		t.Fatalf("Expected error on verber delete but got %#v", err.Error())
@@ -147,7 +156,7 @@ func TestGetShouldThrowErrorOnUnknownResourceKind(t *testing.T) {
 		apiExtensionsClient: &FakeRESTClient{err: errors.NewNotFound("err")},
 	}
 
-	_, err := verber.Get("foo", true, "bar", "baz")
+	_, err := verber.Get("foo", true, "bar", "baz", "")
 
 	if !reflect.DeepEqual(normalize(err.Error()), "Get /api/v1/customresourcedefinitions/foo: err") {
 		t.Fatalf("Expected error on verber get but got %#v", err.Error())
@@ -160,7 +169,7 @@ func TestPutShouldThrowErrorOnUnknownResourceKind(t *testing.T) {
 		apiExtensionsClient: &FakeRESTClient{err: errors.NewNotFound("err")},
 	}
 
-	err := verber.Put("foo", false, "", "baz", nil)
+	_, err := verber.Put("foo", false, "", "baz", "", nil, false)
 
 	if !reflect.DeepEqual(normalize(err.Error()), "Get /api/v1/customresourcedefinitions/foo: err") {
 		t.Fatalf("Expected error on verber put but got %#v", err.Error())
@@ -170,7 +179,7 @@ func TestPutShouldThrowErrorOnUnknownResourceKind(t *testing.T) {
 func TestGetShouldRespectNamespacednessOfResourceKind(t *testing.T) {
 	verber := resourceVerber{client: &FakeRESTClient{}}
 
-	_, err := verber.Get("service", false, "", "baz")
+	_, err := verber.Get("service", false, "", "baz", "")
 
 	if !reflect.DeepEqual(err, errors.NewInvalid("Set no namespace for namespaced resource kind: service")) {
 		t.Fatalf("Expected error on verber get but got %#v", err)
@@ -180,7 +189,7 @@ func TestGetShouldRespectNamespacednessOfResourceKind(t *testing.T) {
 func TestPutShouldRespectNamespacednessOfResourceKind(t *testing.T) {
 	verber := resourceVerber{client: &FakeRESTClient{}}
 
-	err := verber.Put("service", false, "", "baz", nil)
+	_, err := verber.Put("service", false, "", "baz", "", nil, false)
 
 	if !reflect.DeepEqual(err, errors.NewInvalid("Set no namespace for namespaced resource kind: service")) {
 		t.Fatalf("Expected error on verber put but got %#v", err)
@@ -190,7 +199,7 @@ func TestPutShouldRespectNamespacednessOfResourceKind(t *testing.T) {
 func TestDeleteShouldRespectNamespacednessOfResourceKind(t *testing.T) {
 	verber := resourceVerber{client: &FakeRESTClient{}}
 
-	err := verber.Delete("service", false, "", "baz")
+	_, err := verber.Delete("service", false, "", "baz", false)
 
 	if !reflect.DeepEqual(err, errors.NewInvalid("Set no namespace for namespaced resource kind: service")) {
 		t.Fatalf("Expected error on verber delete but got %#v", err)
@@ -200,7 +209,7 @@ func TestDeleteShouldRespectNamespacednessOfResourceKind(t *testing.T) {
 func TestGetShouldRespectNotNamespacednessOfResourceKind(t *testing.T) {
 	verber := resourceVerber{client: &FakeRESTClient{}}
 
-	_, err := verber.Get("namespace", true, "bar", "baz")
+	_, err := verber.Get("namespace", true, "bar", "baz", "")
 
 	if !reflect.DeepEqual(err, errors.NewInvalid("Set namespace for not-namespaced resource kind: namespace")) {
 		t.Fatalf("Expected error on verber get but got %#v", err)
@@ -210,7 +219,7 @@ func TestGetShouldRespectNotNamespacednessOfResourceKind(t *testing.T) {
 func TestPutShouldRespectNotNamespacednessOfResourceKind(t *testing.T) {
 	verber := resourceVerber{client: &FakeRESTClient{}}
 
-	err := verber.Put("namespace", true, "bar", "baz", nil)
+	_, err := verber.Put("namespace", true, "bar", "baz", "", nil, false)
 
 	if !reflect.DeepEqual(err, errors.NewInvalid("Set namespace for not-namespaced resource kind: namespace")) {
 		t.Fatalf("Expected error on verber put but got %#v", err)
@@ -220,9 +229,214 @@ func TestPutShouldRespectNotNamespacednessOfResourceKind(t *testing.T) {
 func TestDeleteShouldRespectNotNamespacednessOfResourceKind(t *testing.T) {
 	verber := resourceVerber{client: &FakeRESTClient{}}
 
-	err := verber.Delete("namespace", true, "bar", "baz")
+	_, err := verber.Delete("namespace", true, "bar", "baz", false)
 
 	if !reflect.DeepEqual(err, errors.NewInvalid("Set namespace for not-namespaced resource kind: namespace")) {
 		t.Fatalf("Expected error on verber delete but got %#v", err)
 	}
 }
+
+func TestApplyShouldUseServerSideApply(t *testing.T) {
+	cases := []struct {
+		raw   string
+		force bool
+	}{
+		{"apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: baz\n", false},
+		{`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"baz"}}`, true},
+	}
+
+	for _, c := range cases {
+		client := &FakeRESTClient{response: &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("{}")),
+		}}
+		verber := resourceVerber{client: &FakeRESTClient{}, appsClient: client}
+
+		if _, err := verber.Apply("deployment", true, "bar", "baz", &runtime.Unknown{Raw: []byte(c.raw)}, c.force,
+			false); err != nil {
+			t.Fatalf("Unexpected error on verber apply: %v", err)
+		}
+
+		if client.request.Method != http.MethodPatch ||
+			client.request.Header.Get("Content-Type") != string(types.ApplyPatchType) {
+			t.Fatalf("Expected apply patch request but got %s with content type %s", client.request.Method,
+				client.request.Header.Get("Content-Type"))
+		}
+
+		query := client.request.URL.Query()
+		if query.Get("fieldManager") != ApplyFieldManager || (query.Get("force") == "true") != c.force {
+			t.Fatalf("Expected field manager %s and force %t but got query %s", ApplyFieldManager, c.force,
+				client.request.URL.RawQuery)
+		}
+
+		if !strings.HasSuffix(client.request.URL.Path, "/namespaces/bar/deployments/baz") {
+			t.Fatalf("Expected apply request for deployment bar/baz but got %s", client.request.URL.Path)
+		}
+
+		body, _ := io.ReadAll(client.request.Body)
+		if normalize(string(body)) != "{apiVersion:apps/v1,kind:Deployment,metadata:{name:baz}}" {
+			t.Fatalf("Expected configuration to be sent as JSON but got %s", body)
+		}
+	}
+}
+
+func TestApplyShouldRejectInvalidConfiguration(t *testing.T) {
+	verber := resourceVerber{client: &FakeRESTClient{}, appsClient: &FakeRESTClient{}}
+
+	_, err := verber.Apply("deployment", true, "bar", "baz", &runtime.Unknown{Raw: []byte("key: [")}, false, false)
+	if !errors.IsBadRequest(err) {
+		t.Fatalf("Expected bad request error on verber apply but got %#v", err)
+	}
+}
+
+func TestVerberDryRun(t *testing.T) {
+	const stored = `{"kind":"Deployment","metadata":{"name":"baz","generation":2}}`
+	newClient := func() *FakeRESTClient {
+		return &FakeRESTClient{response: &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(stored)),
+		}}
+	}
+	raw := &runtime.Unknown{Raw: []byte(`{"kind":"Deployment","metadata":{"name":"baz"}}`)}
+
+	for _, dryRun := range []bool{true, false} {
+		calls := map[string]func(verber resourceVerber) (runtime.Object, error){
+			"put": func(verber resourceVerber) (runtime.Object, error) {
+				return verber.Put("deployment", true, "bar", "baz", "", raw, dryRun)
+			},
+			"apply": func(verber resourceVerber) (runtime.Object, error) {
+				return verber.Apply("deployment", true, "bar", "baz", raw, false, dryRun)
+			},
+		}
+
+		for verb, call := range calls {
+			client := newClient()
+			result, err := call(resourceVerber{client: &FakeRESTClient{}, appsClient: client})
+			if err != nil {
+				t.Fatalf("Unexpected error on verber %s: %v", verb, err)
+			}
+
+			if (client.request.URL.Query().Get("dryRun") == metaV1.DryRunAll) != dryRun {
+				t.Errorf("Expected dry-run %t on verber %s but got query %s", dryRun, verb,
+					client.request.URL.RawQuery)
+			}
+
+			if string(result.(*runtime.Unknown).Raw) != stored {
+				t.Errorf("Expected response of the apiserver to be returned on verber %s but got %s", verb,
+					result.(*runtime.Unknown).Raw)
+			}
+		}
+
+		client := newClient()
+		verber := resourceVerber{client: &FakeRESTClient{}, appsClient: client}
+		result, err := verber.Delete("deployment", true, "bar", "baz", dryRun)
+		if err != nil {
+			t.Fatalf("Unexpected error on verber delete: %v", err)
+		}
+
+		body, _ := io.ReadAll(client.request.Body)
+		if strings.Contains(normalize(string(body)), "dryRun:[All]") != dryRun {
+			t.Errorf("Expected dry-run %t in delete options but got %s", dryRun, body)
+		}
+
+		if string(result.(*runtime.Unknown).Raw) != stored {
+			t.Errorf("Expected response of the apiserver to be returned on verber delete but got %s",
+				result.(*runtime.Unknown).Raw)
+		}
+	}
+}
+
+func TestVerberStatusSubresource(t *testing.T) {
+	const stored = `{"kind":"Deployment","metadata":{"name":"baz"},"status":{"replicas":1}}`
+	newClient := func() *FakeRESTClient {
+		return &FakeRESTClient{response: &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(stored)),
+		}}
+	}
+	raw := &runtime.Unknown{Raw: []byte(stored)}
+
+	cases := []struct {
+		subresource  string
+		expectedPath string
+	}{
+		{"status", "/api/v1/namespaces/bar/deployments/baz/status"},
+		{"", "/api/v1/namespaces/bar/deployments/baz"},
+	}
+
+	for _, c := range cases {
+		client := newClient()
+		verber := resourceVerber{client: &FakeRESTClient{}, appsClient: client}
+		if _, err := verber.Put("deployment", true, "bar", "baz", c.subresource, raw, false); err != nil {
+			t.Fatalf("Unexpected error on verber put: %v", err)
+		}
+
+		if client.request.Method != http.MethodPut || client.request.URL.Path != c.expectedPath {
+			t.Errorf("Expected PUT %s but got %s %s", c.expectedPath, client.request.Method, client.request.URL.Path)
+		}
+
+		client = newClient()
+		verber = resourceVerber{client: &FakeRESTClient{}, appsClient: client}
+		// Fake client has no serializer configured, so only the request is verified.
+		_, _ = verber.Get("deployment", true, "bar", "baz", c.subresource)
+
+		if client.request.Method != http.MethodGet || client.request.URL.Path != c.expectedPath {
+			t.Errorf("Expected GET %s but got %s %s", c.expectedPath, client.request.Method, client.request.URL.Path)
+		}
+	}
+}
+
+func TestVerberStampsManagedByLabel(t *testing.T) {
+	args.GetHolderBuilder().SetStampManagedBy(true)
+	defer args.GetHolderBuilder().SetStampManagedBy(false)
+
+	cases := []struct {
+		raw         string
+		subresource string
+		expected    string
+	}{
+		{`{"kind":"Deployment","metadata":{"name":"baz"},"spec":{"replicas":3}}`, "",
+			`{kind:Deployment,metadata:{labels:{dashboard.k8s.io/managed-by:dashboard},name:baz},spec:{replicas:3}}`},
+		{`{"kind":"Deployment","metadata":{"name":"baz","labels":{"app":"web"}}}`, "",
+			`{kind:Deployment,metadata:{labels:{app:web,dashboard.k8s.io/managed-by:dashboard},name:baz}}`},
+		{`{"kind":"Deployment","metadata":{"name":"baz","labels":{"dashboard.k8s.io/managed-by":"ci"}}}`, "",
+			`{kind:Deployment,metadata:{name:baz,labels:{dashboard.k8s.io/managed-by:ci}}}`},
+		{`{"kind":"Deployment","metadata":{"name":"baz"},"status":{"replicas":1}}`, "status",
+			`{kind:Deployment,metadata:{name:baz},status:{replicas:1}}`},
+	}
+
+	for _, c := range cases {
+		client := &FakeRESTClient{response: &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("{}")),
+		}}
+		verber := resourceVerber{client: &FakeRESTClient{}, appsClient: client}
+		if _, err := verber.Put("deployment", true, "bar", "baz", c.subresource, &runtime.Unknown{Raw: []byte(c.raw)},
+			false); err != nil {
+			t.Fatalf("Unexpected error on verber put: %v", err)
+		}
+
+		body, _ := io.ReadAll(client.request.Body)
+		if normalize(string(body)) != c.expected {
+			t.Errorf("Expected put body %s but got %s", c.expected, body)
+		}
+	}
+
+	client := &FakeRESTClient{response: &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader("{}")),
+	}}
+	verber := resourceVerber{client: &FakeRESTClient{}, appsClient: client}
+	raw := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: baz\n"
+	if _, err := verber.Apply("deployment", true, "bar", "baz", &runtime.Unknown{Raw: []byte(raw)}, false,
+		false); err != nil {
+		t.Fatalf("Unexpected error on verber apply: %v", err)
+	}
+
+	body, _ := io.ReadAll(client.request.Body)
+	expected := "{apiVersion:apps/v1,kind:Deployment," +
+		"metadata:{labels:{dashboard.k8s.io/managed-by:dashboard},name:baz}}"
+	if normalize(string(body)) != expected {
+		t.Errorf("Expected apply configuration %s but got %s", expected, body)
+	}
+}
@@ -0,0 +1,350 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+// Watch opens a watch for the given resource using credentials of the user. Watch is transparently re-established
+// when it expires and, in case resource version is too old (410 Gone), resources are relisted and sent to the
+// result channel as modified events before watching from the new resource version. Objects seen by the watch that
+// are missing from the relisted ones are sent as deleted events. Number of concurrent watches
+// per user can be limited with 'max-watches-per-user' argument. Bookmarks are requested to keep the tracked resource
+// version fresh, but they are not sent to the result channel.
+func (self *clientManager) Watch(req *restful.Request, gvr schema.GroupVersionResource, namespace string,
+	opts metaV1.ListOptions) (watch.Interface, error) {
+	cfg, err := self.Config(req)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	userKey := self.userCacheKey(req)
+	if !self.watchLimiter.acquire(userKey, args.Holder.GetMaxWatchesPerUser()) {
+		return nil, errors.NewTooManyRequests("too many concurrent watches")
+	}
+
+	w, err := newRelistingWatcher(client.Resource(gvr).Namespace(namespace), opts, func() {
+		self.watchLimiter.release(userKey)
+	})
+	if err != nil {
+		self.watchLimiter.release(userKey)
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// watchLimiter keeps track of the number of concurrent watches opened by every user.
+type watchLimiter struct {
+	mux    sync.Mutex
+	counts map[string]int
+}
+
+func newWatchLimiter() *watchLimiter {
+	return &watchLimiter{counts: make(map[string]int)}
+}
+
+// Reserves watch for the user. Returns false if user already reached the limit. Limit lower than 1 means that the
+// number of watches is not limited.
+func (self *watchLimiter) acquire(userKey string, limit int) bool {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	if limit > 0 && self.counts[userKey] >= limit {
+		return false
+	}
+
+	self.counts[userKey]++
+	return true
+}
+
+func (self *watchLimiter) release(userKey string) {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	if self.counts[userKey] <= 1 {
+		delete(self.counts, userKey)
+		return
+	}
+
+	self.counts[userKey]--
+}
+
+const (
+	// Time to wait before re-establishing the watch that was closed without delivering any events. It is doubled
+	// with every such watch up to maxWatchRetryInterval.
+	watchRetryInterval    = time.Second
+	maxWatchRetryInterval = 30 * time.Second
+)
+
+// relistingWatcher implements watch.Interface. It re-establishes underlying watch when it is closed by the server
+// and relists resources when the last known resource version is gone.
+type relistingWatcher struct {
+	client          dynamic.ResourceInterface
+	opts            metaV1.ListOptions
+	result          chan watch.Event
+	stopCh          chan struct{}
+	stopOnce        sync.Once
+	onStop          func()
+	resourceVersion string
+	// Last known state of the objects sent to the result channel, keyed by namespace and name. Used to send deleted
+	// events for the objects missing after relist.
+	known map[string]runtime.Object
+}
+
+func newRelistingWatcher(client dynamic.ResourceInterface, opts metaV1.ListOptions,
+	onStop func()) (*relistingWatcher, error) {
+	w := &relistingWatcher{
+		client:          client,
+		opts:            opts,
+		result:          make(chan watch.Event),
+		stopCh:          make(chan struct{}),
+		onStop:          onStop,
+		resourceVersion: opts.ResourceVersion,
+		known:           make(map[string]runtime.Object),
+	}
+
+	// Open first watch synchronously, so errors like missing permissions are returned to the caller. Relisting, in
+	// case resource version is gone, is done in the background as listed objects are sent to the result channel.
+	watcher, err := w.client.Watch(context.TODO(), w.watchOptions())
+	if err != nil && !isResourceVersionGone(err) {
+		return nil, err
+	}
+
+	go w.run(watcher)
+	return w, nil
+}
+
+// Stop implements watch.Interface.
+func (self *relistingWatcher) Stop() {
+	self.stopOnce.Do(func() {
+		close(self.stopCh)
+	})
+}
+
+// ResultChan implements watch.Interface.
+func (self *relistingWatcher) ResultChan() <-chan watch.Event {
+	return self.result
+}
+
+func (self *relistingWatcher) watchOptions() metaV1.ListOptions {
+	opts := self.opts
+	opts.ResourceVersion = self.resourceVersion
+	opts.Watch = true
+	opts.AllowWatchBookmarks = true
+	return opts
+}
+
+// Opens watch from the last known resource version. Relists resources first if it is gone.
+func (self *relistingWatcher) watch(gone bool) (watch.Interface, error) {
+	if !gone {
+		watcher, err := self.client.Watch(context.TODO(), self.watchOptions())
+		if !isResourceVersionGone(err) {
+			return watcher, err
+		}
+	}
+
+	if err := self.relist(); err != nil {
+		return nil, err
+	}
+
+	return self.client.Watch(context.TODO(), self.watchOptions())
+}
+
+// Lists resources to get current resource version. Listed objects are sent as modified events, so the consumers
+// can catch up with the changes missed in the meantime. Known objects that were not listed are sent as deleted
+// events.
+func (self *relistingWatcher) relist() error {
+	opts := self.opts
+	opts.ResourceVersion = ""
+	opts.Watch = false
+
+	list, err := self.client.List(context.TODO(), opts)
+	if err != nil {
+		return err
+	}
+
+	listed := make(map[string]bool, len(list.Items))
+	for i := range list.Items {
+		event := watch.Event{Type: watch.Modified, Object: &list.Items[i]}
+		listed[self.track(event)] = true
+		if !self.send(event) {
+			return nil
+		}
+	}
+
+	deleted := make([]string, 0)
+	for key := range self.known {
+		if !listed[key] {
+			deleted = append(deleted, key)
+		}
+	}
+
+	sort.Strings(deleted)
+	for _, key := range deleted {
+		event := watch.Event{Type: watch.Deleted, Object: self.known[key]}
+		self.track(event)
+		if !self.send(event) {
+			return nil
+		}
+	}
+
+	self.resourceVersion = list.GetResourceVersion()
+	return nil
+}
+
+// Updates known state of the object from the event. Returns key of the object.
+func (self *relistingWatcher) track(event watch.Event) string {
+	accessor, err := meta.Accessor(event.Object)
+	if err != nil {
+		return ""
+	}
+
+	key := accessor.GetNamespace() + "/" + accessor.GetName()
+	if event.Type == watch.Deleted {
+		delete(self.known, key)
+	} else {
+		self.known[key] = event.Object
+	}
+
+	return key
+}
+
+// Forwards events and re-establishes the watch until relisting watcher is stopped. Nil watcher means that resource
+// version is gone and resources have to be relisted first. Watches closed without delivering any events are
+// re-established with exponential backoff.
+func (self *relistingWatcher) run(watcher watch.Interface) {
+	defer func() {
+		close(self.result)
+		if self.onStop != nil {
+			self.onStop()
+		}
+	}()
+
+	gone := watcher == nil
+	retryInterval := watchRetryInterval
+	for {
+		if watcher == nil {
+			var err error
+			if watcher, err = self.watch(gone); err != nil {
+				self.send(watch.Event{Type: watch.Error, Object: &k8serrors.NewInternalError(err).ErrStatus})
+				return
+			}
+		}
+
+		var ok, received bool
+		gone, received, ok = self.forward(watcher)
+		watcher.Stop()
+		watcher = nil
+		if !ok {
+			return
+		}
+
+		if received || gone {
+			retryInterval = watchRetryInterval
+			continue
+		}
+
+		select {
+		case <-self.stopCh:
+			return
+		case <-time.After(retryInterval):
+		}
+
+		if retryInterval *= 2; retryInterval > maxWatchRetryInterval {
+			retryInterval = maxWatchRetryInterval
+		}
+	}
+}
+
+// Forwards events of the underlying watch to the result channel until it is closed. Returns true as the first value
+// if the watch ended because resource version is gone, true as the second value if any event was received and false
+// as the third value if watcher was stopped.
+func (self *relistingWatcher) forward(watcher watch.Interface) (gone bool, received bool, ok bool) {
+	for {
+		select {
+		case <-self.stopCh:
+			return false, received, false
+		case event, open := <-watcher.ResultChan():
+			if !open {
+				return false, received, true
+			}
+
+			received = true
+
+			if event.Type == watch.Error {
+				if status, isStatus := event.Object.(*metaV1.Status); isStatus &&
+					(status.Code == http.StatusGone || status.Reason == metaV1.StatusReasonExpired) {
+					return true, received, true
+				}
+			}
+
+			if accessor, err := meta.Accessor(event.Object); err == nil && event.Type != watch.Error &&
+				accessor.GetResourceVersion() != "" {
+				self.resourceVersion = accessor.GetResourceVersion()
+			}
+
+			// Bookmarks only advance the resource version the watch is resumed from.
+			if event.Type == watch.Bookmark {
+				continue
+			}
+
+			if event.Type == watch.Added || event.Type == watch.Modified || event.Type == watch.Deleted {
+				self.track(event)
+			}
+
+			if !self.send(event) {
+				return false, received, false
+			}
+		}
+	}
+}
+
+// Sends event to the result channel. Returns false if watcher was stopped in the meantime.
+func (self *relistingWatcher) send(event watch.Event) bool {
+	select {
+	case <-self.stopCh:
+		return false
+	case self.result <- event:
+		return true
+	}
+}
+
+func isResourceVersionGone(err error) bool {
+	return err != nil && (k8serrors.IsGone(err) || k8serrors.IsResourceExpired(err))
+}
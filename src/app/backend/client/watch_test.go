@@ -0,0 +1,278 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clientTesting "k8s.io/client-go/testing"
+)
+
+var configMapsGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+func newUnstructuredConfigMap(name, resourceVersion string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	obj.SetResourceVersion(resourceVersion)
+	return obj
+}
+
+// Returns fake dynamic client that serves given watchers in order. Nil watcher means that the watch request fails
+// with 410 Gone.
+func newWatchClient(watchers ...*watch.FakeWatcher) (*dynamicfake.FakeDynamicClient, *[]string) {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{configMapsGVR: "ConfigMapList"},
+		newUnstructuredConfigMap("existing", "5"))
+	var resourceVersions []string
+
+	client.PrependWatchReactor("configmaps", func(action clientTesting.Action) (bool, watch.Interface, error) {
+		resourceVersions = append(resourceVersions,
+			action.(clientTesting.WatchActionImpl).GetWatchRestrictions().ResourceVersion)
+		if len(watchers) == 0 {
+			return true, watch.NewFake(), nil
+		}
+
+		next := watchers[0]
+		watchers = watchers[1:]
+		if next == nil {
+			return true, nil, k8serrors.NewResourceExpired("too old resource version")
+		}
+
+		return true, next, nil
+	})
+
+	return client, &resourceVersions
+}
+
+func nextEvent(t *testing.T, w watch.Interface) watch.Event {
+	select {
+	case event := <-w.ResultChan():
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for watch event")
+	}
+
+	return watch.Event{}
+}
+
+func TestRelistingWatcherRelistsWhenWatchFailsWithGone(t *testing.T) {
+	client, _ := newWatchClient(nil)
+
+	w, err := newRelistingWatcher(client.Resource(configMapsGVR).Namespace("default"),
+		metaV1.ListOptions{ResourceVersion: "1"}, nil)
+	if err != nil {
+		t.Fatalf("Expected watch to be established, but got %v", err)
+	}
+	defer w.Stop()
+
+	event := nextEvent(t, w)
+	if event.Type != watch.Modified || event.Object.(*unstructured.Unstructured).GetName() != "existing" {
+		t.Fatalf("Expected relisted object to be sent as modified event, but got %#v", event)
+	}
+}
+
+func TestRelistingWatcherRelistsWhenWatchEndsWithGone(t *testing.T) {
+	first := watch.NewFakeWithChanSize(2, false)
+	second := watch.NewFakeWithChanSize(1, false)
+	client, resourceVersions := newWatchClient(first, second)
+
+	w, err := newRelistingWatcher(client.Resource(configMapsGVR).Namespace("default"), metaV1.ListOptions{}, nil)
+	if err != nil {
+		t.Fatalf("Expected watch to be established, but got %v", err)
+	}
+	defer w.Stop()
+
+	first.Add(newUnstructuredConfigMap("added", "2"))
+	first.Error(&metaV1.Status{Code: http.StatusGone, Reason: metaV1.StatusReasonExpired})
+	second.Modify(newUnstructuredConfigMap("added", "7"))
+
+	expected := []struct {
+		eventType watch.EventType
+		name      string
+	}{
+		{watch.Added, "added"},
+		{watch.Modified, "existing"},
+		{watch.Deleted, "added"},
+		{watch.Modified, "added"},
+	}
+
+	for _, e := range expected {
+		event := nextEvent(t, w)
+		if event.Type != e.eventType || event.Object.(*unstructured.Unstructured).GetName() != e.name {
+			t.Fatalf("Expected %s event for %s, but got %#v", e.eventType, e.name, event)
+		}
+	}
+
+	if len(*resourceVersions) != 2 || (*resourceVersions)[1] == "2" {
+		t.Fatalf("Expected watch to be re-established from relisted resource version, but got %v",
+			*resourceVersions)
+	}
+}
+
+func TestRelistingWatcherSendsDeletedEventsAfterRelist(t *testing.T) {
+	first := watch.NewFakeWithChanSize(4, false)
+	client, _ := newWatchClient(first)
+
+	w, err := newRelistingWatcher(client.Resource(configMapsGVR).Namespace("default"), metaV1.ListOptions{}, nil)
+	if err != nil {
+		t.Fatalf("Expected watch to be established, but got %v", err)
+	}
+	defer w.Stop()
+
+	first.Add(newUnstructuredConfigMap("removed-in-gap", "2"))
+	first.Add(newUnstructuredConfigMap("removed", "3"))
+	first.Delete(newUnstructuredConfigMap("removed", "4"))
+	first.Error(&metaV1.Status{Code: http.StatusGone, Reason: metaV1.StatusReasonExpired})
+
+	expected := []struct {
+		eventType watch.EventType
+		name      string
+	}{
+		{watch.Added, "removed-in-gap"},
+		{watch.Added, "removed"},
+		{watch.Deleted, "removed"},
+		{watch.Modified, "existing"},
+		{watch.Deleted, "removed-in-gap"},
+	}
+
+	for _, e := range expected {
+		event := nextEvent(t, w)
+		if event.Type != e.eventType || event.Object.(*unstructured.Unstructured).GetName() != e.name {
+			t.Fatalf("Expected %s event for %s, but got %#v", e.eventType, e.name, event)
+		}
+	}
+
+	select {
+	case event := <-w.ResultChan():
+		t.Fatalf("Expected no more events, but got %#v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestRelistingWatcherBacksOffWhenWatchClosesImmediately(t *testing.T) {
+	watchers := make([]*watch.FakeWatcher, 10)
+	for i := range watchers {
+		watchers[i] = watch.NewFake()
+		watchers[i].Stop()
+	}
+	client, resourceVersions := newWatchClient(watchers...)
+
+	w, err := newRelistingWatcher(client.Resource(configMapsGVR).Namespace("default"), metaV1.ListOptions{}, nil)
+	if err != nil {
+		t.Fatalf("Expected watch to be established, but got %v", err)
+	}
+
+	time.Sleep(watchRetryInterval / 2)
+	w.Stop()
+	for range w.ResultChan() {
+	}
+
+	if count := len(*resourceVersions); count != 1 {
+		t.Fatalf("Expected closed watch not to be re-established before retry interval, but got %d watches", count)
+	}
+}
+
+func TestRelistingWatcherResumesFromBookmark(t *testing.T) {
+	first := watch.NewFakeWithChanSize(3, false)
+	second := watch.NewFakeWithChanSize(1, false)
+	client, resourceVersions := newWatchClient(first, second)
+
+	w, err := newRelistingWatcher(client.Resource(configMapsGVR).Namespace("default"), metaV1.ListOptions{}, nil)
+	if err != nil {
+		t.Fatalf("Expected watch to be established, but got %v", err)
+	}
+	defer w.Stop()
+
+	if !w.watchOptions().AllowWatchBookmarks {
+		t.Fatal("Expected watch to request bookmarks")
+	}
+
+	first.Add(newUnstructuredConfigMap("added", "2"))
+	first.Action(watch.Bookmark, newUnstructuredConfigMap("", "42"))
+	first.Action(watch.Bookmark, newUnstructuredConfigMap("", ""))
+	first.Stop()
+	second.Modify(newUnstructuredConfigMap("added", "43"))
+
+	expected := []watch.EventType{watch.Added, watch.Modified}
+	for _, eventType := range expected {
+		event := nextEvent(t, w)
+		if event.Type != eventType || event.Object.(*unstructured.Unstructured).GetName() != "added" {
+			t.Fatalf("Expected %s event for added, but got %#v", eventType, event)
+		}
+	}
+
+	if len(*resourceVersions) != 2 || (*resourceVersions)[1] != "42" {
+		t.Fatalf("Expected watch to be resumed from bookmark resource version, but got %v", *resourceVersions)
+	}
+}
+
+func TestRelistingWatcherStop(t *testing.T) {
+	client, _ := newWatchClient()
+	stopped := make(chan struct{})
+
+	w, err := newRelistingWatcher(client.Resource(configMapsGVR).Namespace("default"), metaV1.ListOptions{},
+		func() { close(stopped) })
+	if err != nil {
+		t.Fatalf("Expected watch to be established, but got %v", err)
+	}
+
+	w.Stop()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected stop callback to be called")
+	}
+
+	if _, open := <-w.ResultChan(); open {
+		t.Fatal("Expected result channel to be closed")
+	}
+}
+
+func TestWatchLimiter(t *testing.T) {
+	limiter := newWatchLimiter()
+
+	if !limiter.acquire("user", 2) || !limiter.acquire("user", 2) {
+		t.Fatal("Expected watches within limit to be allowed")
+	}
+
+	if limiter.acquire("user", 2) {
+		t.Fatal("Expected watch over limit to be rejected")
+	}
+
+	if !limiter.acquire("other", 2) {
+		t.Fatal("Expected limit to be applied per user")
+	}
+
+	limiter.release("user")
+	if !limiter.acquire("user", 2) {
+		t.Fatal("Expected released watch to free the limit")
+	}
+
+	if !limiter.acquire("user", 0) {
+		t.Fatal("Expected watches to be unlimited when limit is 0")
+	}
+}
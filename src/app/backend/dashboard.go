@@ -45,30 +45,74 @@ import (
 )
 
 var (
-	argInsecurePort              = pflag.Int("insecure-port", 9090, "port to listen to for incoming HTTP requests")
-	argPort                      = pflag.Int("port", 8443, "secure port to listen to for incoming HTTPS requests")
-	argInsecureBindAddress       = pflag.IP("insecure-bind-address", net.IPv4(127, 0, 0, 1), "IP address on which to serve the --insecure-port, set to 127.0.0.1 for all interfaces")
-	argBindAddress               = pflag.IP("bind-address", net.IPv4(0, 0, 0, 0), "IP address on which to serve the --port, set to 0.0.0.0 for all interfaces")
-	argDefaultCertDir            = pflag.String("default-cert-dir", "/certs", "directory path containing files from --tls-cert-file and --tls-key-file, used also when auto-generating certificates flag is set")
-	argCertFile                  = pflag.String("tls-cert-file", "", "file containing the default x509 certificate for HTTPS")
-	argKeyFile                   = pflag.String("tls-key-file", "", "file containing the default x509 private key matching --tls-cert-file")
-	argApiserverHost             = pflag.String("apiserver-host", "", "address of the Kubernetes API server to connect to in the format of protocol://address:port, leave it empty if the binary runs inside cluster for local discovery attempt")
-	argMetricsProvider           = pflag.String("metrics-provider", "sidecar", "select provider type for metrics, 'none' will not check metrics")
-	argHeapsterHost              = pflag.String("heapster-host", "", "address of the Heapster API server to connect to in the format of protocol://address:port, leave it empty if the binary runs inside cluster for service proxy usage")
-	argSidecarHost               = pflag.String("sidecar-host", "", "address of the Sidecar API server to connect to in the format of protocol://address:port, leave it empty if the binary runs inside cluster for service proxy usage")
-	argKubeConfigFile            = pflag.String("kubeconfig", "", "path to kubeconfig file with authorization and master location information")
-	argTokenTTL                  = pflag.Int("token-ttl", authApi.DefaultTokenTTL, "expiration time in seconds of JWE tokens generated by dashboard, set to 0 to avoid expiration")
-	argAuthenticationMode        = pflag.StringSlice("authentication-mode", []string{authApi.Token.String()}, "enabled authentication options, supports 'token' and 'basic' that should only be used if Kubernetes API server has --authorization-mode=ABAC and --basic-auth-file flags set")
-	argMetricClientCheckPeriod   = pflag.Int("metric-client-check-period", 30, "time interval between separate metric client health checks in seconds")
-	argAutoGenerateCertificates  = pflag.Bool("auto-generate-certificates", false, "enables automatic certificates generation used to serve HTTPS")
-	argEnableInsecureLogin       = pflag.Bool("enable-insecure-login", false, "enables login view when the app is not served over HTTPS")
-	argEnableSkip                = pflag.Bool("enable-skip-login", false, "enables skip button on the login page")
-	argSystemBanner              = pflag.String("system-banner", "", "system banner message displayed in the app if non-empty, it accepts simple HTML")
-	argSystemBannerSeverity      = pflag.String("system-banner-severity", "INFO", "severity of system banner, should be one of 'INFO', 'WARNING' or 'ERROR'")
-	argAPILogLevel               = pflag.String("api-log-level", "INFO", "level of API request logging, should be one of 'NONE', 'INFO' or 'DEBUG'")
-	argDisableSettingsAuthorizer = pflag.Bool("disable-settings-authorizer", false, "disables settings page user authorizer so anyone can access settings page")
-	argNamespace                 = pflag.String("namespace", getEnv("POD_NAMESPACE", "kube-system"), "if non-default namespace is used encryption key will be created in the specified namespace")
-	localeConfig                 = pflag.String("locale-config", "./locale_conf.json", "path to file containing the locale configuration")
+	argInsecurePort                     = pflag.Int("insecure-port", 9090, "port to listen to for incoming HTTP requests")
+	argPort                             = pflag.Int("port", 8443, "secure port to listen to for incoming HTTPS requests")
+	argInsecureBindAddress              = pflag.IP("insecure-bind-address", net.IPv4(127, 0, 0, 1), "IP address on which to serve the --insecure-port, set to 127.0.0.1 for all interfaces")
+	argBindAddress                      = pflag.IP("bind-address", net.IPv4(0, 0, 0, 0), "IP address on which to serve the --port, set to 0.0.0.0 for all interfaces")
+	argDefaultCertDir                   = pflag.String("default-cert-dir", "/certs", "directory path containing files from --tls-cert-file and --tls-key-file, used also when auto-generating certificates flag is set")
+	argCertFile                         = pflag.String("tls-cert-file", "", "file containing the default x509 certificate for HTTPS")
+	argKeyFile                          = pflag.String("tls-key-file", "", "file containing the default x509 private key matching --tls-cert-file")
+	argApiserverHost                    = pflag.String("apiserver-host", "", "address of the Kubernetes API server to connect to in the format of protocol://address:port, leave it empty if the binary runs inside cluster for local discovery attempt")
+	argMetricsProvider                  = pflag.String("metrics-provider", "sidecar", "select provider type for metrics, 'none' will not check metrics")
+	argHeapsterHost                     = pflag.String("heapster-host", "", "address of the Heapster API server to connect to in the format of protocol://address:port, leave it empty if the binary runs inside cluster for service proxy usage")
+	argSidecarHost                      = pflag.String("sidecar-host", "", "address of the Sidecar API server to connect to in the format of protocol://address:port, leave it empty if the binary runs inside cluster for service proxy usage")
+	argKubeConfigFile                   = pflag.String("kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	argTokenTTL                         = pflag.Int("token-ttl", authApi.DefaultTokenTTL, "expiration time in seconds of JWE tokens generated by dashboard, set to 0 to avoid expiration")
+	argAuthenticationMode               = pflag.StringSlice("authentication-mode", []string{authApi.Token.String()}, "enabled authentication options, supports 'token' and 'basic' that should only be used if Kubernetes API server has --authorization-mode=ABAC and --basic-auth-file flags set")
+	argMetricClientCheckPeriod          = pflag.Int("metric-client-check-period", 30, "time interval between separate metric client health checks in seconds")
+	argAutoGenerateCertificates         = pflag.Bool("auto-generate-certificates", false, "enables automatic certificates generation used to serve HTTPS")
+	argEnableInsecureLogin              = pflag.Bool("enable-insecure-login", false, "enables login view when the app is not served over HTTPS")
+	argEnableSkip                       = pflag.Bool("enable-skip-login", false, "enables skip button on the login page")
+	argSystemBanner                     = pflag.String("system-banner", "", "system banner message displayed in the app if non-empty, it accepts simple HTML")
+	argSystemBannerSeverity             = pflag.String("system-banner-severity", "INFO", "severity of system banner, should be one of 'INFO', 'WARNING' or 'ERROR'")
+	argAPILogLevel                      = pflag.String("api-log-level", "INFO", "level of API request logging, should be one of 'NONE', 'INFO' or 'DEBUG'")
+	argDisableSettingsAuthorizer        = pflag.Bool("disable-settings-authorizer", false, "disables settings page user authorizer so anyone can access settings page")
+	argNamespace                        = pflag.String("namespace", getEnv("POD_NAMESPACE", "kube-system"), "if non-default namespace is used encryption key will be created in the specified namespace")
+	localeConfig                        = pflag.String("locale-config", "./locale_conf.json", "path to file containing the locale configuration")
+	argMaxRequestTimeout                = pflag.Int("max-request-timeout", 600, "maximum timeout in seconds of apiserver requests that can be requested with X-Request-Timeout header, set to 0 to disable the limit")
+	argStartupRBACCheck                 = pflag.Bool("startup-rbac-check", false, "checks on startup if dashboard service account has permissions required by dashboard and logs the missing ones")
+	argEgressProxyClientCert            = pflag.String("egress-proxy-client-cert", "", "file containing x509 client certificate used for mutual TLS with HTTPS egress proxy")
+	argEgressProxyClientKey             = pflag.String("egress-proxy-client-key", "", "file containing x509 private key matching --egress-proxy-client-cert")
+	argEgressProxyCA                    = pflag.String("egress-proxy-ca", "", "file containing CA certificate used to verify HTTPS egress proxy")
+	argClusterDomain                    = pflag.String("cluster-domain", client.DefaultClusterDomain, "DNS domain of the cluster used when it could not be detected from CoreDNS or kubeadm configuration")
+	argUsernameExtractionRegex          = pflag.String("username-extraction-regex", client.DefaultUsernameExtractionRegex, "regular expression used to extract user name from the username returned by the apiserver, it has to contain named capture group called name")
+	argAPIProxyAllowedPaths             = pflag.StringSlice("api-proxy-allowed-paths", []string{}, "apiserver path prefixes that can be requested through the API explorer proxy, i.e. /apis/apps, proxy is disabled if empty")
+	argMaxWatchesPerUser                = pflag.Int("max-watches-per-user", 10, "maximum number of concurrent watches that can be opened by a single user, 0 means no limit")
+	argBlockServiceAccountImpersonation = pflag.Bool("block-service-account-impersonation", false, "when enabled, requests authenticated with a service account token are not allowed to use impersonation headers")
+	argMaxResponseSize                  = pflag.Int64("max-response-size", 0, "maximum size in bytes of the apiserver responses, larger responses are rejected and have to be paginated, 0 means no limit")
+	argInClusterQPS                     = pflag.Float32("in-cluster-qps", client.DefaultQPS, "maximum QPS of apiserver requests made when in-cluster config is used")
+	argInClusterBurst                   = pflag.Int("in-cluster-burst", client.DefaultBurst, "maximum burst of apiserver requests made when in-cluster config is used")
+	argOutOfClusterQPS                  = pflag.Float32("out-of-cluster-qps", client.DefaultQPS, "maximum QPS of apiserver requests made when kubeconfig or apiserver-host is used")
+	argOutOfClusterBurst                = pflag.Int("out-of-cluster-burst", client.DefaultBurst, "maximum burst of apiserver requests made when kubeconfig or apiserver-host is used")
+	argTokenClockSkewLeeway             = pflag.Int("token-clock-skew-leeway", authApi.DefaultClockSkewLeeway, "time in seconds for which JWE tokens are still accepted after their expiration to tolerate clock skew between replicas")
+	argImpersonationAssertionSecret     = pflag.String("impersonation-assertion-secret", "", "shared secret used to verify HMAC signed impersonation assertions sent by the trusted proxy, raw impersonation headers are rejected when it is set")
+	argPropagatedRequestHeaders         = pflag.StringSlice("propagated-request-headers", []string{}, "names of the request headers, i.e. X-Tenant-ID or traceparent, that are forwarded to the apiserver requests made on behalf of the request, authentication headers are never forwarded")
+	argBatchGetConcurrency              = pflag.Int("batch-get-concurrency", 5, "maximum number of get operations executed concurrently by a single batch get, 0 means no limit")
+	argDefaultPageSize                  = pflag.Int64("default-page-size", 500, "default number of items requested from the apiserver in a single page of the list, 0 means no limit")
+	argMaxPageSize                      = pflag.Int64("max-page-size", 5000, "maximum number of items that can be requested from the apiserver in a single page of the list, 0 means no limit")
+	argMaxInformerFactories             = pflag.Int("max-informer-factories", 20, "maximum number of namespaced informer factories that can be active at the same time, 0 means no limit")
+	argInClusterConfigAttempts          = pflag.Int("in-cluster-config-attempts", 5, "number of attempts to initialize in-cluster config before giving up")
+	argInClusterConfigRetryInterval     = pflag.Int("in-cluster-config-retry-interval", 1, "initial interval in seconds between attempts to initialize in-cluster config, doubled after every failed attempt")
+	argEnableKubeConfigExport           = pflag.Bool("enable-kubeconfig-export", false, "allow users to download kubeconfig file with the cluster and the credentials they are logged in with")
+	argKubeConfigExportIncludeToken     = pflag.Bool("kubeconfig-export-include-token", false, "include token of the user in the exported kubeconfig file, otherwise it contains only the cluster and the user has to provide credentials")
+	argMaxPortForwardsPerUser           = pflag.Int("max-port-forwards-per-user", 5, "maximum number of concurrent port forwarding sessions of a single user, 0 means no limit")
+	argMinRefreshInterval               = pflag.Int("min-refresh-interval", 5, "shortest UI refresh interval in seconds suggested by the backend")
+	argMaxRefreshInterval               = pflag.Int("max-refresh-interval", 60, "longest UI refresh interval in seconds suggested by the backend for large clusters")
+	argNamespaceDenylist                = pflag.String("namespace-denylist", "", "regular expression matching names of the namespaces hidden from users that are not cluster admins, i.e. ^kube-")
+	argRESTMapperResetWindow            = pflag.Int("rest-mapper-reset-window", 5, "window in seconds within which repeated resets of the RESTMapper caused by unknown kinds are coalesced into a single discovery refresh, 0 disables it")
+	argStuckTerminatingThreshold        = pflag.Int("stuck-terminating-threshold", 300, "time in seconds after which object that is still being deleted because of its finalizers is reported as stuck in terminating state")
+	argEnableForceDelete                = pflag.Bool("enable-force-delete", false, "enables force delete of the objects, which removes their finalizers and deletes them without grace period. Every use is logged")
+	argServiceProxyAllowedServices      = pflag.StringSlice("service-proxy-allowed-services", []string{}, "services that can be requested through the service proxy in the namespace/name format, i.e. monitoring/grafana, namespace/* allows all services in the namespace, service proxy is disabled if empty")
+	argDefaultLogTailLines              = pflag.Int("default-log-tail-lines", 0, "number of last log lines streamed when client does not request specific number, 0 streams whole log")
+	argMaxLogTailLines                  = pflag.Int("max-log-tail-lines", 10000, "maximum number of last log lines client can request, larger requests are capped, 0 means no limit")
+	argApplyManifestConcurrency         = pflag.Int("apply-manifest-concurrency", 5, "maximum number of documents of the manifest applied concurrently, 0 means no limit")
+	argShowTerminalPods                 = pflag.Bool("show-terminal-pods", true, "whether pods in Succeeded and Failed phases are listed when the pod list does not specify phases")
+	argDefaultListTimeoutSeconds        = pflag.Int64("default-list-timeout-seconds", 60, "default timeout of list requests sent to the apiserver in seconds, 0 means no timeout")
+	argMaxListTimeoutSeconds            = pflag.Int64("max-list-timeout-seconds", 300, "maximum timeout of list requests that can be requested in seconds, 0 means no limit")
+	argStampManagedBy                   = pflag.Bool("stamp-managed-by", false, "whether resources created or edited through the dashboard are labeled with dashboard.k8s.io/managed-by label")
+	argSensitiveEnvPatterns             = pflag.StringSlice("sensitive-env-patterns", []string{"*PASSWORD*", "*TOKEN*", "*SECRET*"}, "case-insensitive patterns of environment variable names whose values are masked in the pod details, i.e. *PASSWORD*, where * matches any characters")
+	argEnableTransportStats             = pflag.Bool("enable-transport-stats", false, "whether /api/v1/debug/transportstats endpoint reporting connections to the apiserver is served, it is only accessible to cluster admins")
+	argJWETokenHeader                   = pflag.String("jwe-token-header", client.JWETokenHeader, "name of the request header that contains JWE token used for authorization")
 )
 
 func main() {
@@ -100,6 +144,10 @@ func main() {
 
 	log.Printf("Successful initial request to the apiserver, version: %s", versionInfo.String())
 
+	if args.Holder.GetStartupRBACCheck() {
+		client.CheckRBAC(clientManager.InsecureClient(), args.Holder.GetNamespace())
+	}
+
 	// Init auth manager
 	authManager := initAuthManager(clientManager)
 
@@ -205,6 +253,11 @@ func initAuthManager(clientManager clientapi.ClientManager) authApi.AuthManager
 		tokenManager.SetTokenTTL(tokenTTL)
 	}
 
+	clockSkewLeeway := time.Duration(args.Holder.GetTokenClockSkewLeeway())
+	if clockSkewLeeway != authApi.DefaultClockSkewLeeway {
+		tokenManager.SetClockSkewLeeway(clockSkewLeeway)
+	}
+
 	// Set token manager for client manager.
 	clientManager.SetTokenManager(tokenManager)
 	authModes := authApi.ToAuthenticationModes(args.Holder.GetAuthenticationMode())
@@ -244,6 +297,50 @@ func initArgHolder() {
 	builder.SetEnableSkipLogin(*argEnableSkip)
 	builder.SetNamespace(*argNamespace)
 	builder.SetLocaleConfig(*localeConfig)
+	builder.SetJWETokenHeader(*argJWETokenHeader)
+	builder.SetMaxRequestTimeout(*argMaxRequestTimeout)
+	builder.SetStartupRBACCheck(*argStartupRBACCheck)
+	builder.SetEgressProxyClientCert(*argEgressProxyClientCert)
+	builder.SetEgressProxyClientKey(*argEgressProxyClientKey)
+	builder.SetEgressProxyCA(*argEgressProxyCA)
+	builder.SetClusterDomain(*argClusterDomain)
+	builder.SetUsernameExtractionRegex(*argUsernameExtractionRegex)
+	builder.SetAPIProxyAllowedPaths(*argAPIProxyAllowedPaths)
+	builder.SetMaxWatchesPerUser(*argMaxWatchesPerUser)
+	builder.SetBlockServiceAccountImpersonation(*argBlockServiceAccountImpersonation)
+	builder.SetMaxResponseSize(*argMaxResponseSize)
+	builder.SetInClusterQPS(*argInClusterQPS)
+	builder.SetInClusterBurst(*argInClusterBurst)
+	builder.SetOutOfClusterQPS(*argOutOfClusterQPS)
+	builder.SetOutOfClusterBurst(*argOutOfClusterBurst)
+	builder.SetTokenClockSkewLeeway(*argTokenClockSkewLeeway)
+	builder.SetImpersonationAssertionSecret(*argImpersonationAssertionSecret)
+	builder.SetPropagatedRequestHeaders(*argPropagatedRequestHeaders)
+	builder.SetBatchGetConcurrency(*argBatchGetConcurrency)
+	builder.SetDefaultPageSize(*argDefaultPageSize)
+	builder.SetMaxPageSize(*argMaxPageSize)
+	builder.SetMaxInformerFactories(*argMaxInformerFactories)
+	builder.SetInClusterConfigAttempts(*argInClusterConfigAttempts)
+	builder.SetInClusterConfigRetryInterval(*argInClusterConfigRetryInterval)
+	builder.SetEnableKubeConfigExport(*argEnableKubeConfigExport)
+	builder.SetKubeConfigExportIncludeToken(*argKubeConfigExportIncludeToken)
+	builder.SetMaxPortForwardsPerUser(*argMaxPortForwardsPerUser)
+	builder.SetMinRefreshInterval(*argMinRefreshInterval)
+	builder.SetMaxRefreshInterval(*argMaxRefreshInterval)
+	builder.SetNamespaceDenylist(*argNamespaceDenylist)
+	builder.SetRESTMapperResetWindow(*argRESTMapperResetWindow)
+	builder.SetStuckTerminatingThreshold(*argStuckTerminatingThreshold)
+	builder.SetEnableForceDelete(*argEnableForceDelete)
+	builder.SetServiceProxyAllowedServices(*argServiceProxyAllowedServices)
+	builder.SetDefaultLogTailLines(*argDefaultLogTailLines)
+	builder.SetMaxLogTailLines(*argMaxLogTailLines)
+	builder.SetApplyManifestConcurrency(*argApplyManifestConcurrency)
+	builder.SetShowTerminalPods(*argShowTerminalPods)
+	builder.SetDefaultListTimeoutSeconds(*argDefaultListTimeoutSeconds)
+	builder.SetMaxListTimeoutSeconds(*argMaxListTimeoutSeconds)
+	builder.SetStampManagedBy(*argStampManagedBy)
+	builder.SetSensitiveEnvPatterns(*argSensitiveEnvPatterns)
+	builder.SetEnableTransportStats(*argEnableTransportStats)
 }
 
 /**
@@ -50,6 +50,84 @@ func NewBadRequest(reason string) *errors.StatusError {
 	return errors.NewBadRequest(reason)
 }
 
+// NewForbidden creates an error that indicates that the request is understood but not allowed.
+func NewForbidden(reason string) *errors.StatusError {
+	return &errors.StatusError{
+		ErrStatus: metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    http.StatusForbidden,
+			Reason:  metav1.StatusReasonForbidden,
+			Message: reason,
+		},
+	}
+}
+
+// NewResponseTooLarge creates an error that indicates that the response is larger than the given limit of bytes.
+func NewResponseTooLarge(limit int64) *errors.StatusError {
+	return &errors.StatusError{
+		ErrStatus: metav1.Status{
+			TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+			Status:   metav1.StatusFailure,
+			Code:     http.StatusRequestEntityTooLarge,
+			Reason:   metav1.StatusReasonRequestEntityTooLarge,
+			Message: fmt.Sprintf("response exceeded maximum allowed size of %d bytes, use pagination to limit "+
+				"the number of returned items", limit),
+		},
+	}
+}
+
+// NewTooManyRequests creates an error that indicates that the client has to wait before making more requests.
+func NewTooManyRequests(reason string) *errors.StatusError {
+	return errors.NewTooManyRequests(reason, 0)
+}
+
+// NewThrottled creates an error that indicates that the apiserver is throttling requests and the request should be
+// retried after given number of seconds.
+func NewThrottled(retryAfterSeconds int) *errors.StatusError {
+	return &errors.StatusError{
+		ErrStatus: metav1.Status{
+			TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+			Status:   metav1.StatusFailure,
+			Code:     http.StatusTooManyRequests,
+			Reason:   metav1.StatusReasonTooManyRequests,
+			Message:  "the apiserver is throttling requests, try again later",
+			Details:  &metav1.StatusDetails{RetryAfterSeconds: int32(retryAfterSeconds)},
+		},
+	}
+}
+
+// EvictionBlockedCause is the type of the cause attached to errors of evictions rejected by the disruption budget.
+const EvictionBlockedCause metav1.CauseType = "DisruptionBudget"
+
+// NewEvictionBlocked creates an error that indicates that the pod can not be evicted right now, because it would
+// violate its pod disruption budget.
+func NewEvictionBlocked(reason string) *errors.StatusError {
+	return &errors.StatusError{
+		ErrStatus: metav1.Status{
+			TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+			Status:   metav1.StatusFailure,
+			Code:     http.StatusTooManyRequests,
+			Reason:   metav1.StatusReasonTooManyRequests,
+			Message:  reason,
+			Details:  &metav1.StatusDetails{Causes: []metav1.StatusCause{{Type: EvictionBlockedCause, Message: reason}}},
+		},
+	}
+}
+
+// NewNodeUnreachable creates an error that indicates that the kubelet of the node could not be reached through
+// the apiserver proxy.
+func NewNodeUnreachable(node, reason string) *errors.StatusError {
+	return &errors.StatusError{
+		ErrStatus: metav1.Status{
+			TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+			Status:   metav1.StatusFailure,
+			Code:     http.StatusServiceUnavailable,
+			Reason:   metav1.StatusReasonServiceUnavailable,
+			Message:  fmt.Sprintf("kubelet of node %s is unreachable: %s", node, reason),
+		},
+	}
+}
+
 // NewInvalid return a statusError
 // which is an error intended for consumption by a REST API server; it can also be
 // reconstructed by clients from a REST response. Public to allow easy type switches.
@@ -189,3 +267,30 @@ func IsAlreadyExists(err error) bool {
 func IsUnauthorized(err error) bool {
 	return errors.IsUnauthorized(err)
 }
+
+// IsBadRequest determines if err is an error which indicates that the request is invalid.
+func IsBadRequest(err error) bool {
+	return errors.IsBadRequest(err)
+}
+
+// IsThrottled determines if err is an error which indicates that the request was throttled by the apiserver.
+func IsThrottled(err error) bool {
+	return errors.IsTooManyRequests(err) && !IsEvictionBlocked(err)
+}
+
+// IsEvictionBlocked determines if err is an error which indicates that the eviction was rejected by the disruption
+// budget of the pod.
+func IsEvictionBlocked(err error) bool {
+	_, blocked := errors.StatusCause(err, EvictionBlockedCause)
+	return errors.IsTooManyRequests(err) && blocked
+}
+
+// IsNodeUnreachable determines if err is an error which indicates that the kubelet of the node could not be reached.
+func IsNodeUnreachable(err error) bool {
+	return errors.IsServiceUnavailable(err)
+}
+
+// IsResponseTooLarge determines if err is an error which indicates that the response exceeded maximum allowed size.
+func IsResponseTooLarge(err error) bool {
+	return errors.IsRequestEntityTooLargeError(err)
+}
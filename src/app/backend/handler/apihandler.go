@@ -15,11 +15,13 @@
 package handler
 
 import (
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
 	"strings"
 
+	authorizationv1 "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/core/v1"
 
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/networkpolicy"
@@ -31,13 +33,16 @@ import (
 
 	"golang.org/x/net/xsrftoken"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/remotecommand"
 
 	"github.com/emicklei/go-restful/v3"
 
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/api"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/auth"
 	authApi "github.com/CAPS-Cloud/dashboard/src/app/backend/auth/api"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/client"
 	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/integration"
@@ -137,6 +142,13 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 			To(apiHandler.handleGetCsrfToken).
 			Writes(api.CsrfToken{}))
 
+	if args.Holder.GetEnableTransportStats() {
+		apiV1Ws.Route(
+			apiV1Ws.GET("/debug/transportstats").
+				To(apiHandler.handleGetTransportStats).
+				Writes(map[string]clientapi.ConnectionStats{}))
+	}
+
 	apiV1Ws.Route(
 		apiV1Ws.POST("/appdeployment").
 			To(apiHandler.handleDeploy).
@@ -589,6 +601,13 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.PUT("/_raw/{kind}/name/{name}").
 			To(apiHandler.handlePutResource))
 
+	apiV1Ws.Route(
+		apiV1Ws.GET("/_list/{group}/{version}/{resource}").
+			To(apiHandler.handleListResources))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/_list/{group}/{version}/{resource}/namespace/{namespace}").
+			To(apiHandler.handleListResources))
+
 	apiV1Ws.Route(
 		apiV1Ws.GET("/clusterrole").
 			To(apiHandler.handleGetClusterRoleList).
@@ -858,6 +877,20 @@ func (apiHandler *APIHandler) handleGetCsrfToken(request *restful.Request, respo
 	response.WriteHeaderAndEntity(http.StatusOK, api.CsrfToken{Token: token})
 }
 
+// Transport stats expose apiserver and proxy hosts, so they are only served to cluster admins.
+func (apiHandler *APIHandler) handleGetTransportStats(request *restful.Request, response *restful.Response) {
+	clusterAdmin := &authorizationv1.SelfSubjectAccessReview{Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+		ResourceAttributes: &authorizationv1.ResourceAttributes{Verb: "*", Group: "*", Resource: "*"},
+	}}
+	if !apiHandler.cManager.CanI(request, clusterAdmin) {
+		errors.HandleInternalError(response,
+			errors.NewForbidden("transport stats are only available to cluster admins"))
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, apiHandler.cManager.TransportStats())
+}
+
 func (apiHandler *APIHandler) handleGetStatefulSetList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
@@ -1620,6 +1653,7 @@ func (apiHandler *APIHandler) handleExecShell(request *restful.Request, response
 		id:       sessionID,
 		bound:    make(chan error),
 		sizeChan: make(chan remotecommand.TerminalSize),
+		doneChan: make(chan struct{}),
 	})
 	go WaitForTerminal(k8sClient, cfg, request, sessionID)
 	response.WriteHeaderAndEntity(http.StatusOK, TerminalResponse{ID: sessionID})
@@ -1727,11 +1761,27 @@ func (apiHandler *APIHandler) handleGetPods(request *restful.Request, response *
 	namespace := parseNamespacePathParameter(request)
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	dataSelect.MetricQuery = dataselect.StandardMetrics // download standard metrics - cpu, and memory - by default
-	result, err := pod.GetPodList(k8sClient, apiHandler.iManager.Metric().Client(), namespace, dataSelect)
+	fieldSelector := request.QueryParameter("fieldSelector")
+	phases, err := pod.ParsePhases(request.QueryParameter("phases"))
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
+
+	if phases == nil {
+		phases = pod.DefaultPhases(args.Holder.GetShowTerminalPods())
+	}
+
+	result, err := pod.GetPodList(k8sClient, apiHandler.iManager.Metric().Client(), namespace, dataSelect,
+		fieldSelector, phases)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	if request.QueryParameter("usage") == "true" {
+		pod.MergePodUsage(result.Pods, apiHandler.podUsage(request, namespace.ToRequestParam()))
+	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
@@ -1744,14 +1794,36 @@ func (apiHandler *APIHandler) handleGetPodDetail(request *restful.Request, respo
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("pod")
-	result, err := pod.GetPodDetail(k8sClient, apiHandler.iManager.Metric().Client(), namespace, name)
+	revealSecrets := request.QueryParameter("revealSecrets") == "true"
+	if revealSecrets && !apiHandler.cManager.CanI(request,
+		clientapi.ToSelfSubjectAccessReview(namespace, "", "Secret", "get")) {
+		errors.HandleInternalError(response, errors.NewForbidden("revealing secrets requires access to get secrets"))
+		return
+	}
+
+	result, err := pod.GetPodDetail(k8sClient, apiHandler.iManager.Metric().Client(), namespace, name, revealSecrets)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
+
+	if request.QueryParameter("usage") == "true" {
+		result.Usage = apiHandler.podUsage(request, namespace).Get(namespace, name)
+	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
+// Returns current usage of the pods in the namespace. Errors are only logged, so that pods are shown without
+// usage when it cannot be retrieved.
+func (apiHandler *APIHandler) podUsage(request *restful.Request, namespace string) pod.PodUsage {
+	usage, err := apiHandler.cManager.PodUsage(request, namespace)
+	if err != nil {
+		log.Printf("Skipping pod usage because of error: %s", err.Error())
+	}
+
+	return usage
+}
+
 func (apiHandler *APIHandler) handleGetReplicationControllerDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
@@ -1792,6 +1864,46 @@ func (apiHandler *APIHandler) handleUpdateReplicasCount(request *restful.Request
 	response.WriteHeader(http.StatusAccepted)
 }
 
+// Lists single page of the resources fetched from the apiserver, sorted according to the 'sortBy' and 'sortOrder'
+// query parameters. Core group is requested as 'core'. Only metadata of the objects is listed if 'metadataOnly'
+// query parameter is set.
+func (apiHandler *APIHandler) handleListResources(request *restful.Request, response *restful.Response) {
+	gvr := schema.GroupVersionResource{
+		Group:    request.PathParameter("group"),
+		Version:  request.PathParameter("version"),
+		Resource: request.PathParameter("resource"),
+	}
+	if gvr.Group == "core" {
+		gvr.Group = ""
+	}
+	namespace := request.PathParameter("namespace")
+
+	opts, err := parser.ParseListOptionsParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	listSort, err := parser.ParseListSortParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	var result runtime.Object
+	if request.QueryParameter("metadataOnly") == "true" {
+		result, err = apiHandler.cManager.ListMetadata(request, gvr, namespace, opts, listSort)
+	} else {
+		result, err = apiHandler.cManager.ListCustomResources(request, gvr, namespace, opts, listSort)
+	}
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
 func (apiHandler *APIHandler) handleGetResource(request *restful.Request, response *restful.Response) {
 	config, err := apiHandler.cManager.Config(request)
 	if err != nil {
@@ -1808,7 +1920,9 @@ func (apiHandler *APIHandler) handleGetResource(request *restful.Request, respon
 	kind := request.PathParameter("kind")
 	namespace, ok := request.PathParameters()["namespace"]
 	name := request.PathParameter("name")
-	result, err := verber.Get(kind, ok, namespace, name)
+	// Subresource, e.g. status, can be requested explicitly for resources that expose it.
+	subresource := request.QueryParameter("subresource")
+	result, err := verber.Get(kind, ok, namespace, name, subresource)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -1840,11 +1954,20 @@ func (apiHandler *APIHandler) handlePutResource(
 		return
 	}
 
-	if err := verber.Put(kind, ok, namespace, name, putSpec); err != nil {
+	// Dry-run returns the object that would be stored, so that the changes could be previewed.
+	dryRun := request.QueryParameter("dryRun") == "true"
+	subresource := request.QueryParameter("subresource")
+	result, err := verber.Put(kind, ok, namespace, name, subresource, putSpec, dryRun)
+	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
+	if dryRun {
+		response.WriteHeaderAndEntity(http.StatusOK, result)
+		return
+	}
+
 	response.WriteHeader(http.StatusCreated)
 }
 
@@ -1866,11 +1989,18 @@ func (apiHandler *APIHandler) handleDeleteResource(
 	namespace, ok := request.PathParameters()["namespace"]
 	name := request.PathParameter("name")
 
-	if err := verber.Delete(kind, ok, namespace, name); err != nil {
+	dryRun := request.QueryParameter("dryRun") == "true"
+	result, err := verber.Delete(kind, ok, namespace, name, dryRun)
+	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
+	if dryRun {
+		response.WriteHeaderAndEntity(http.StatusOK, result)
+		return
+	}
+
 	// Try to unpin resource if it was pinned.
 	pinnedResource := &settingsApi.PinnedResource{
 		Name:      name,
@@ -1937,8 +2067,14 @@ func (apiHandler *APIHandler) handleGetNamespaces(request *restful.Request, resp
 		return
 	}
 
+	denylist, err := apiHandler.cManager.NamespaceDenylist(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	dataSelect := parser.ParseDataSelectPathParameter(request)
-	result, err := ns.GetNamespaceList(k8sClient, dataSelect)
+	result, err := ns.GetNamespaceList(k8sClient, dataSelect, denylist)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -2785,11 +2921,22 @@ func (apiHandler *APIHandler) handleLogs(request *restful.Request, response *res
 		}
 	}
 
-	result, err := container.GetLogDetails(k8sClient, namespace, podID, containerID, logSelector, usePreviousLogs)
+	tailLines, capped, err := tailLinesParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := container.GetLogDetails(k8sClient, namespace, podID, containerID, logSelector, usePreviousLogs,
+		tailLines)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
+	// Tail lines are not used when reading the log from the beginning.
+	if capped && logSelector.LogFilePosition != logs.Beginning {
+		response.AddHeader(client.LogTailLinesCappedHeader, "true")
+	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
@@ -2807,14 +2954,42 @@ func (apiHandler *APIHandler) handleLogFile(request *restful.Request, response *
 	opts.Previous = request.QueryParameter("previous") == "true"
 	opts.Timestamps = request.QueryParameter("timestamps") == "true"
 
+	var capped bool
+	opts.TailLines, capped, err = tailLinesParameter(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	logStream, err := container.GetLogFile(k8sClient, namespace, podID, containerID, opts)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
+	if capped {
+		response.AddHeader(client.LogTailLinesCappedHeader, "true")
+	}
 	handleDownload(response, logStream)
 }
 
+// Returns number of tail lines requested with 'tailLines' query parameter, capped by the 'max-log-tail-lines'
+// argument. Returns nil if the parameter is not set, so that the endpoints keep reading the whole log. Returns true as
+// the second value if the requested number was capped, see client.LimitTailLines.
+func tailLinesParameter(request *restful.Request) (*int64, bool, error) {
+	value := request.QueryParameter("tailLines")
+	if len(value) == 0 {
+		return nil, false, nil
+	}
+
+	lines, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || lines < 0 {
+		return nil, false, errors.NewBadRequest(fmt.Sprintf("invalid tailLines parameter: %s", value))
+	}
+
+	tailLines, capped := client.LimitTailLines(&lines)
+	return tailLines, capped, nil
+}
+
 // parseNamespacePathParameter parses namespace selector for list pages in path parameter.
 // The namespace selector is a comma separated list of namespaces that are trimmed.
 // No namespaces means "view all user namespaces", i.e., everything except kube-system.
@@ -15,8 +15,13 @@
 package handler
 
 import (
+	"crypto/tls"
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
 
 	"bytes"
@@ -31,6 +36,9 @@ import (
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/settings"
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/sync"
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/systembanner"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 
 	restful "github.com/emicklei/go-restful/v3"
@@ -54,6 +62,200 @@ func TestCreateHTTPAPIHandler(t *testing.T) {
 	}
 }
 
+// Writes kubeconfig of the test apiserver. Test apiserver has to use TLS, because user credentials are only sent to
+// the apiserver over TLS.
+func writeTestKubeconfig(t *testing.T, server string) string {
+	kubeconfig := filepath.Join(t.TempDir(), "kubeconfig")
+	if err := ioutil.WriteFile(kubeconfig, []byte(fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster: {server: %s, insecure-skip-tls-verify: true}
+contexts:
+- name: test
+  context: {cluster: test, user: test}
+current-context: test
+users:
+- name: test
+`, server)), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return kubeconfig
+}
+
+func newTestAPIHandler(t *testing.T, kubeconfig string) http.Handler {
+	cManager := client.NewClientManager(kubeconfig, "")
+	authManager := auth.NewAuthManager(cManager, getTokenManager(), authApi.AuthenticationModes{}, true)
+	handler, err := CreateHTTPAPIHandler(nil, cManager, authManager, settings.NewSettingsManager(),
+		systembanner.NewSystemBannerManager("", ""))
+	if err != nil {
+		t.Fatalf("CreateHTTPAPIHandler(): unexpected error: %s", err.Error())
+	}
+
+	return handler
+}
+
+func TestTransportStatsEndpoint(t *testing.T) {
+	apiserver := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		review := &authorizationv1.SelfSubjectAccessReview{}
+		review.APIVersion, review.Kind = "authorization.k8s.io/v1", "SelfSubjectAccessReview"
+		review.Status.Allowed = r.Header.Get("Authorization") == "Bearer admin"
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(review)
+	}))
+	defer apiserver.Close()
+
+	kubeconfig := writeTestKubeconfig(t, apiserver.URL)
+
+	cases := []struct {
+		enabled  bool
+		token    string
+		expected int
+	}{
+		{false, "admin", http.StatusNotFound},
+		{true, "user", http.StatusForbidden},
+		{true, "admin", http.StatusOK},
+	}
+
+	defer args.GetHolderBuilder().SetEnableTransportStats(false)
+	for _, c := range cases {
+		args.GetHolderBuilder().SetEnableTransportStats(c.enabled)
+		handler := newTestAPIHandler(t, kubeconfig)
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/debug/transportstats", nil)
+		req.Header.Set("Authorization", "Bearer "+c.token)
+		req.TLS = &tls.ConnectionState{}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		if recorder.Code != c.expected {
+			t.Errorf("GET /api/v1/debug/transportstats (enabled: %v, token: %s) returned %d, expected %d",
+				c.enabled, c.token, recorder.Code, c.expected)
+		}
+	}
+}
+
+func TestLogTailLinesCapped(t *testing.T) {
+	args.GetHolderBuilder().SetDefaultLogTailLines(100).SetMaxLogTailLines(1000)
+	defer func() { args.GetHolderBuilder().SetDefaultLogTailLines(0).SetMaxLogTailLines(0) }()
+
+	var tailLines string
+	apiserver := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/log") {
+			tailLines = r.URL.Query().Get("tailLines")
+			w.Write([]byte("2022-06-01T00:00:00Z started\n"))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(&v1.Pod{
+			TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "app"}}},
+		})
+	}))
+	defer apiserver.Close()
+
+	handler := newTestAPIHandler(t, writeTestKubeconfig(t, apiserver.URL))
+	cases := []struct {
+		path              string
+		expectedCode      int
+		expectedTailLines string
+		expectedCapped    string
+	}{
+		{"/api/v1/log/file/default/web/app?tailLines=5000", http.StatusOK, "1000", "true"},
+		{"/api/v1/log/file/default/web/app?tailLines=10", http.StatusOK, "10", ""},
+		{"/api/v1/log/file/default/web/app", http.StatusOK, "", ""},
+		{"/api/v1/log/default/web/app?tailLines=5000", http.StatusOK, "1000", "true"},
+		{"/api/v1/log/default/web/app", http.StatusOK, "5000", ""},
+		{"/api/v1/log/file/default/web/app?tailLines=all", http.StatusBadRequest, "", ""},
+	}
+
+	for _, c := range cases {
+		tailLines = ""
+		req := httptest.NewRequest(http.MethodGet, c.path, nil)
+		req.Header.Set("Authorization", "Bearer token")
+		req.TLS = &tls.ConnectionState{}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		capped := recorder.Header().Get(client.LogTailLinesCappedHeader)
+		if recorder.Code != c.expectedCode || tailLines != c.expectedTailLines || capped != c.expectedCapped {
+			t.Errorf("GET %s returned %d with %s header %q and requested %q tail lines, expected %d, %q and %q",
+				c.path, recorder.Code, client.LogTailLinesCappedHeader, capped, tailLines, c.expectedCode,
+				c.expectedCapped, c.expectedTailLines)
+		}
+	}
+}
+
+func TestListResourcesSorted(t *testing.T) {
+	var query string
+	apiserver := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Path + "?" + r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(&metaV1.PartialObjectMetadataList{
+			TypeMeta: metaV1.TypeMeta{APIVersion: "meta.k8s.io/v1", Kind: "PartialObjectMetadataList"},
+			Items: []metaV1.PartialObjectMetadata{
+				{ObjectMeta: metaV1.ObjectMeta{Name: "b", Namespace: "default"}},
+				{ObjectMeta: metaV1.ObjectMeta{Name: "c", Namespace: "default"}},
+				{ObjectMeta: metaV1.ObjectMeta{Name: "a", Namespace: "default"}},
+			},
+		})
+	}))
+	defer apiserver.Close()
+
+	handler := newTestAPIHandler(t, writeTestKubeconfig(t, apiserver.URL))
+	cases := []struct {
+		path          string
+		expectedCode  int
+		expectedNames []string
+		expectedQuery string
+	}{
+		{"/api/v1/_list/apps/v1/deployments/namespace/default?metadataOnly=true&sortBy=name&sortOrder=desc&limit=3",
+			http.StatusOK, []string{"c", "b", "a"}, "/apis/apps/v1/namespaces/default/deployments?limit=3"},
+		{"/api/v1/_list/core/v1/pods?metadataOnly=true&sortBy=name", http.StatusOK, []string{"a", "b", "c"},
+			"/api/v1/pods"},
+		{"/api/v1/_list/core/v1/pods?metadataOnly=true", http.StatusOK, []string{"b", "c", "a"}, "/api/v1/pods"},
+		{"/api/v1/_list/core/v1/pods?metadataOnly=true&sortBy=size", http.StatusBadRequest, nil, ""},
+		{"/api/v1/_list/core/v1/pods?metadataOnly=true&sortBy=name&sortOrder=up", http.StatusBadRequest, nil, ""},
+		{"/api/v1/_list/core/v1/pods?metadataOnly=true&limit=all", http.StatusBadRequest, nil, ""},
+	}
+
+	for _, c := range cases {
+		query = ""
+		req := httptest.NewRequest(http.MethodGet, c.path, nil)
+		req.Header.Set("Authorization", "Bearer token")
+		req.TLS = &tls.ConnectionState{}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		if recorder.Code != c.expectedCode {
+			t.Errorf("GET %s returned %d, expected %d: %s", c.path, recorder.Code, c.expectedCode,
+				recorder.Body.String())
+			continue
+		}
+
+		if c.expectedCode != http.StatusOK {
+			continue
+		}
+
+		list := &metaV1.PartialObjectMetadataList{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), list); err != nil {
+			t.Fatalf("GET %s returned invalid list: %v", c.path, err)
+		}
+
+		names := make([]string, 0, len(list.Items))
+		for _, item := range list.Items {
+			names = append(names, item.Name)
+		}
+
+		if !reflect.DeepEqual(names, c.expectedNames) || !strings.HasPrefix(query, c.expectedQuery) {
+			t.Errorf("GET %s returned %v and requested %s, expected %v and %s", c.path, names, query,
+				c.expectedNames, c.expectedQuery)
+		}
+	}
+}
+
 func TestShouldDoCsrfValidation(t *testing.T) {
 	cases := []struct {
 		request  *restful.Request
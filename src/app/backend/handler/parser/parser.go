@@ -15,10 +15,15 @@
 package parser
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
 	metricapi "github.com/CAPS-Cloud/dashboard/src/app/backend/integration/metric/api"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/common"
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/dataselect"
 
 	"github.com/emicklei/go-restful/v3"
@@ -80,3 +85,30 @@ func ParseDataSelectPathParameter(request *restful.Request) *dataselect.DataSele
 	metricQuery := parseMetricPathParameter(request)
 	return dataselect.NewDataSelectQuery(paginationQuery, sortQuery, filterQuery, metricQuery)
 }
+
+// ParseListSortParameter parses 'sortBy' and 'sortOrder' query parameters of the request used by endpoints that
+// sort pages fetched from the apiserver, i.e. '?sortBy=label:app&sortOrder=desc'.
+func ParseListSortParameter(request *restful.Request) (*common.ListSort, error) {
+	return common.ParseListSort(request.QueryParameter("sortBy"), request.QueryParameter("sortOrder"))
+}
+
+// ParseListOptionsParameter parses 'labelSelector', 'fieldSelector', 'limit' and 'continue' query parameters of the
+// request used by endpoints that list pages fetched from the apiserver. Invalid limit is rejected with bad request
+// error.
+func ParseListOptionsParameter(request *restful.Request) (metaV1.ListOptions, error) {
+	options := metaV1.ListOptions{
+		LabelSelector: request.QueryParameter("labelSelector"),
+		FieldSelector: request.QueryParameter("fieldSelector"),
+		Continue:      request.QueryParameter("continue"),
+	}
+
+	if limit := request.QueryParameter("limit"); len(limit) > 0 {
+		parsed, err := strconv.ParseInt(limit, 10, 64)
+		if err != nil {
+			return options, errors.NewBadRequest(fmt.Sprintf("invalid limit parameter: %s", limit))
+		}
+		options.Limit = parsed
+	}
+
+	return options, nil
+}
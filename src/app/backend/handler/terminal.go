@@ -15,6 +15,7 @@
 package handler
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
@@ -22,16 +23,21 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"sync"
 
 	"gopkg.in/igm/sockjs-go.v2/sockjs"
 	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/remotecommand"
 
 	restful "github.com/emicklei/go-restful/v3"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/container"
 )
 
 const END_OF_TRANSMISSION = "\u0004"
@@ -167,6 +173,10 @@ func (sm *SessionMap) Close(sessionId string, status uint32, reason string) {
 		log.Println(err)
 	}
 
+	if doneChan := sm.Sessions[sessionId].doneChan; doneChan != nil {
+		close(doneChan)
+	}
+
 	delete(sm.Sessions, sessionId)
 }
 
@@ -211,45 +221,117 @@ func CreateAttachHandler(path string) http.Handler {
 	return sockjs.NewHandler(path, sockjs.DefaultOptions, handleTerminalSession)
 }
 
+// ExecOptions describes the process started in the container and how its streams are connected.
+type ExecOptions struct {
+	Namespace string
+	Pod       string
+	// Container to execute command in. Default container of the pod is used when it is empty.
+	Container string
+	Command   []string
+	// TTY allocates a terminal for the process. Resize events are forwarded to the apiserver only when it is set.
+	TTY bool
+}
+
+// newExecutor creates remotecommand.Executor streaming to the given URL. It is a variable so tests could
+// capture the streams without a running apiserver.
+var newExecutor = func(cfg *rest.Config, method string, url *url.URL) (remotecommand.Executor, error) {
+	return remotecommand.NewSPDYExecutor(cfg, method, url)
+}
+
 // startProcess is called by handleAttach
 // Executed cmd in the container specified in request and connects it up with the ptyHandler (a session)
 func startProcess(k8sClient kubernetes.Interface, cfg *rest.Config, request *restful.Request, cmd []string, ptyHandler PtyHandler) error {
-	namespace := request.PathParameter("namespace")
-	podName := request.PathParameter("pod")
-	containerName := request.PathParameter("container")
+	opts := ExecOptions{
+		Namespace: request.PathParameter("namespace"),
+		Pod:       request.PathParameter("pod"),
+		Container: request.PathParameter("container"),
+		Command:   cmd,
+		TTY:       request.QueryParameter("tty") != "false",
+	}
+
+	return execInContainer(k8sClient, k8sClient.CoreV1().RESTClient(), cfg, opts, ptyHandler)
+}
+
+// execInContainer establishes SPDY stream to the exec subresource of the pod using the config of the user. Resize
+// events of the ptyHandler are sent to the apiserver when TTY is allocated and discarded otherwise.
+func execInContainer(k8sClient kubernetes.Interface, restClient rest.Interface, cfg *rest.Config, opts ExecOptions,
+	ptyHandler PtyHandler) error {
+	pod, err := k8sClient.CoreV1().Pods(opts.Namespace).Get(context.TODO(), opts.Pod, metaV1.GetOptions{})
+	if err != nil {
+		return err
+	}
 
-	req := k8sClient.CoreV1().RESTClient().Post().
+	container, err := selectContainer(pod, opts.Container)
+	if err != nil {
+		return err
+	}
+
+	req := restClient.Post().
 		Resource("pods").
-		Name(podName).
-		Namespace(namespace).
+		Name(opts.Pod).
+		Namespace(opts.Namespace).
 		SubResource("exec")
 
 	req.VersionedParams(&v1.PodExecOptions{
-		Container: containerName,
-		Command:   cmd,
+		Container: container,
+		Command:   opts.Command,
 		Stdin:     true,
 		Stdout:    true,
-		Stderr:    true,
-		TTY:       true,
+		Stderr:    !opts.TTY,
+		TTY:       opts.TTY,
 	}, scheme.ParameterCodec)
 
-	exec, err := remotecommand.NewSPDYExecutor(cfg, "POST", req.URL())
+	exec, err := newExecutor(cfg, "POST", req.URL())
 	if err != nil {
 		return err
 	}
 
-	err = exec.Stream(remotecommand.StreamOptions{
-		Stdin:             ptyHandler,
-		Stdout:            ptyHandler,
-		Stderr:            ptyHandler,
-		TerminalSizeQueue: ptyHandler,
-		Tty:               true,
-	})
-	if err != nil {
-		return err
+	streamOptions := remotecommand.StreamOptions{
+		Stdin:  ptyHandler,
+		Stdout: ptyHandler,
+		Tty:    opts.TTY,
+	}
+	if opts.TTY {
+		streamOptions.TerminalSizeQueue = ptyHandler
+	} else {
+		// Stderr is merged into stdout by the terminal when TTY is allocated, so it is only streamed without it.
+		streamOptions.Stderr = ptyHandler
+		go discardResizes(ptyHandler)
 	}
 
-	return nil
+	return exec.Stream(streamOptions)
+}
+
+// selectContainer returns name of the container to execute command in. Ephemeral containers are accepted as well,
+// as they are often used for debugging.
+func selectContainer(pod *v1.Pod, name string) (string, error) {
+	if len(name) == 0 {
+		if len(pod.Spec.Containers) == 0 {
+			return "", errors.NewBadRequest(fmt.Sprintf("pod %s has no containers", pod.Name))
+		}
+		return container.DefaultContainerName(pod), nil
+	}
+
+	for _, container := range pod.Spec.Containers {
+		if container.Name == name {
+			return name, nil
+		}
+	}
+
+	for _, container := range pod.Spec.EphemeralContainers {
+		if container.Name == name {
+			return name, nil
+		}
+	}
+
+	return "", errors.NewBadRequest(fmt.Sprintf("container %s is not valid for pod %s", name, pod.Name))
+}
+
+// discardResizes consumes resize events which can not be sent to a process without TTY, so that they do not block
+// reading from the session.
+func discardResizes(queue remotecommand.TerminalSizeQueue) {
+	for queue.Next() != nil {
+	}
 }
 
 // genTerminalSessionId generates a random session ID string. The format is not really interesting.
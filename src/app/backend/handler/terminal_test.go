@@ -0,0 +1,173 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+type fakePtyHandler struct {
+	bytes.Buffer
+	sizes chan remotecommand.TerminalSize
+}
+
+func (self *fakePtyHandler) Next() *remotecommand.TerminalSize {
+	size, ok := <-self.sizes
+	if !ok {
+		return nil
+	}
+	return &size
+}
+
+type fakeExecutor struct {
+	url     *url.URL
+	options remotecommand.StreamOptions
+	sizes   []remotecommand.TerminalSize
+}
+
+func (self *fakeExecutor) Stream(options remotecommand.StreamOptions) error {
+	self.options = options
+	if options.TerminalSizeQueue == nil {
+		return nil
+	}
+
+	for size := options.TerminalSizeQueue.Next(); size != nil; size = options.TerminalSizeQueue.Next() {
+		self.sizes = append(self.sizes, *size)
+	}
+	return nil
+}
+
+func newExecTest(t *testing.T) (*fake.Clientset, rest.Interface, *fakeExecutor) {
+	pod := &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "app"}, {Name: "sidecar"}},
+			EphemeralContainers: []v1.EphemeralContainer{
+				{EphemeralContainerCommon: v1.EphemeralContainerCommon{Name: "debugger"}}},
+		},
+	}
+	restClient, err := rest.RESTClientFor(&rest.Config{
+		Host:    "https://apiserver",
+		APIPath: "/api",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion:         &v1.SchemeGroupVersion,
+			NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	executor := &fakeExecutor{}
+	original := newExecutor
+	newExecutor = func(cfg *rest.Config, method string, url *url.URL) (remotecommand.Executor, error) {
+		executor.url = url
+		return executor, nil
+	}
+	t.Cleanup(func() { newExecutor = original })
+
+	return fake.NewSimpleClientset(pod), restClient, executor
+}
+
+func TestExecInContainerForwardsResizes(t *testing.T) {
+	client, restClient, executor := newExecTest(t)
+	pty := &fakePtyHandler{sizes: make(chan remotecommand.TerminalSize, 2)}
+	pty.sizes <- remotecommand.TerminalSize{Width: 80, Height: 24}
+	pty.sizes <- remotecommand.TerminalSize{Width: 120, Height: 40}
+	close(pty.sizes)
+
+	opts := ExecOptions{Namespace: "default", Pod: "web", Container: "sidecar", Command: []string{"sh"}, TTY: true}
+	if err := execInContainer(client, restClient, &rest.Config{}, opts, pty); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []remotecommand.TerminalSize{{Width: 80, Height: 24}, {Width: 120, Height: 40}}
+	if !reflect.DeepEqual(executor.sizes, expected) {
+		t.Errorf("Expected resize events %v, got %v", expected, executor.sizes)
+	}
+
+	query := executor.url.Query()
+	if executor.url.Path != "/api/v1/namespaces/default/pods/web/exec" || query.Get("container") != "sidecar" ||
+		query.Get("tty") != "true" || query.Get("stderr") != "" {
+		t.Errorf("Unexpected exec URL: %s", executor.url)
+	}
+
+	if !executor.options.Tty || executor.options.Stderr != nil {
+		t.Errorf("Expected TTY stream without separate stderr, got %+v", executor.options)
+	}
+}
+
+func TestExecInContainerWithoutTTY(t *testing.T) {
+	client, restClient, executor := newExecTest(t)
+	pty := &fakePtyHandler{sizes: make(chan remotecommand.TerminalSize)}
+	defer close(pty.sizes)
+
+	opts := ExecOptions{Namespace: "default", Pod: "web", Command: []string{"ls"}}
+	if err := execInContainer(client, restClient, &rest.Config{}, opts, pty); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Resize sent by the terminal must not block even though there is no TTY to resize.
+	pty.sizes <- remotecommand.TerminalSize{Width: 80, Height: 24}
+
+	if executor.options.TerminalSizeQueue != nil || executor.options.Tty || executor.options.Stderr == nil {
+		t.Errorf("Expected stream without TTY, got %+v", executor.options)
+	}
+
+	query := executor.url.Query()
+	if query.Get("container") != "app" || query.Get("tty") != "" || query.Get("stderr") != "true" {
+		t.Errorf("Expected first container to be used without TTY, got %s", executor.url)
+	}
+}
+
+func TestSelectContainer(t *testing.T) {
+	client, _, _ := newExecTest(t)
+	pod, _ := client.CoreV1().Pods("default").Get(context.TODO(), "web", metaV1.GetOptions{})
+
+	cases := []struct {
+		name     string
+		expected string
+		wantErr  bool
+	}{
+		{"", "app", false},
+		{"sidecar", "sidecar", false},
+		{"debugger", "debugger", false},
+		{"missing", "", true},
+	}
+
+	for _, c := range cases {
+		container, err := selectContainer(pod, c.name)
+		if c.wantErr != (err != nil) || (err != nil && !errors.IsBadRequest(err)) {
+			t.Errorf("selectContainer(%q): unexpected error: %v", c.name, err)
+		}
+
+		if container != c.expected {
+			t.Errorf("selectContainer(%q): expected %q, got %q", c.name, c.expected, container)
+		}
+	}
+}
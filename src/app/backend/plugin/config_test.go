@@ -16,9 +16,12 @@ package plugin
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/plugin/apis/v1alpha1"
 
@@ -26,16 +29,34 @@ import (
 	clientapi "github.com/CAPS-Cloud/dashboard/src/app/backend/client/api"
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/plugin/client/clientset/versioned"
 	fakePluginClientset "github.com/CAPS-Cloud/dashboard/src/app/backend/plugin/client/clientset/versioned/fake"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/common"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/container"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/event"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/horizontalpodautoscaler"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/ingress"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/namespace"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/networkpolicy"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/node"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/persistentvolumeclaim"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/pod"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/resourcequota"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/service"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/storageclass"
 	v1 "k8s.io/api/authorization/v1"
 	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	fakeK8sClient "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
 
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+
 	"github.com/emicklei/go-restful/v3"
 )
 
@@ -131,3 +152,274 @@ func (cm *fakeClientManager) VerberClient(req *restful.Request, config *rest.Con
 func (cm *fakeClientManager) SetTokenManager(manager authApi.TokenManager) {
 	panic("implement me")
 }
+
+func (cm *fakeClientManager) AccessibleNamespaces(req *restful.Request) ([]string, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) OpenAPISchema(req *restful.Request) (*openapi_v2.Document, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) ClusterDomain() string {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) BuildInfo() clientapi.BuildInfo {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) ProxyRequest(req *restful.Request, path string) (io.ReadCloser, int, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) Watch(req *restful.Request, gvr schema.GroupVersionResource, namespace string,
+	opts metaV1.ListOptions) (watch.Interface, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) TransportStats() map[string]clientapi.ConnectionStats {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) APIServiceHealth(req *restful.Request) (map[string]bool, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) SetUsernameResolver(resolver clientapi.UsernameResolver) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) StreamPodLogs(req *restful.Request, namespace, pod, container string,
+	opts clientapi.LogStreamOptions) (io.ReadCloser, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) AuthMode() clientapi.AuthMode {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) ResourceEvents(req *restful.Request, namespace, kind, name string) (*coreV1.EventList, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) InformerFactory(req *restful.Request, namespace string) (clientapi.InformerFactory, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) PodSecurityLevels(req *restful.Request) (map[string]namespace.PSALevels, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) ResolveOwners(req *restful.Request, obj metaV1.Object, namespace string) ([]clientapi.OwnerRef, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) RequestToken(req *restful.Request, serviceAccount, namespace string,
+	audiences []string, ttl time.Duration) (string, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) NamespaceAccessSummary(req *restful.Request, namespace string) (map[string]bool, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) ExportKubeConfig(req *restful.Request) ([]byte, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) ListCustomResources(req *restful.Request, gvr schema.GroupVersionResource,
+	namespace string, opts metaV1.ListOptions, listSort *common.ListSort) (*unstructured.UnstructuredList, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) ListMetadata(req *restful.Request, gvr schema.GroupVersionResource, namespace string,
+	opts metaV1.ListOptions, listSort *common.ListSort) (*metaV1.PartialObjectMetadataList, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) EvictPod(req *restful.Request, namespace, name string, gracePeriod *int64) error {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) ScaleResource(req *restful.Request, gvr schema.GroupVersionResource, namespace,
+	name string, replicas int32) (int32, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) NamespaceQuota(req *restful.Request, namespace string) (*resourcequota.QuotaSummary, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) SetNodeSchedulable(req *restful.Request, nodeName string, schedulable bool) error {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) NodeDrainReadiness(req *restful.Request, nodeName string) (*node.DrainReadiness, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) WorkloadImages(req *restful.Request, namespace string) ([]container.ImageRef, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) RolloutStatus(req *restful.Request, gvr schema.GroupVersionResource, namespace,
+	name string) (clientapi.RolloutStatus, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) RestartWorkload(req *restful.Request, gvr schema.GroupVersionResource, namespace,
+	name string) error {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) GetSecretDecoded(req *restful.Request, namespace, name string) (map[string]string, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) AttachToContainer(req *restful.Request, namespace, pod, container string,
+	opts clientapi.AttachOptions) error {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) PortForward(req *restful.Request, namespace, pod string, port int32) (io.ReadWriteCloser, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) ContainerRestartInfo(req *restful.Request, namespace, pod string) ([]container.ContainerRestart, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) RefreshHint(req *restful.Request) (time.Duration, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) DiffResource(req *restful.Request, gvr schema.GroupVersionResource, namespace string,
+	proposed *unstructured.Unstructured) (string, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) InsecureClientMetrics() map[string]clientapi.EndpointMetrics {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) NamespaceDenylist(req *restful.Request) (*regexp.Regexp, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) NodeStatsSummary(req *restful.Request, nodeName string) (*node.StatsSummary, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) FilterPresets(req *restful.Request) ([]clientapi.FilterPreset, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) ServiceBackends(req *restful.Request, namespace, name string) ([]service.PodRef, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) WorkloadHPA(req *restful.Request, namespace, targetKind,
+	targetName string) (*horizontalpodautoscaler.HPASummary, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) StreamPodLogsAllContainers(req *restful.Request, namespace, pod string,
+	opts clientapi.LogStreamOptions) (io.ReadCloser, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) ValidateImagePullSecrets(req *restful.Request, namespace string,
+	secretNames []string) ([]clientapi.PullSecretStatus, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) ListTable(req *restful.Request, gvr schema.GroupVersionResource, namespace string,
+	opts metaV1.ListOptions) (*metaV1.Table, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) GroupedEvents(req *restful.Request, namespace string,
+	opts event.GroupedEventsOptions) ([]event.EventGroup, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) StuckTerminating(req *restful.Request, namespace string) ([]clientapi.StuckResource, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) ForceDelete(req *restful.Request, gvr schema.GroupVersionResource, namespace, name string) error {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) PVCDetails(req *restful.Request, namespace, name string) (*persistentvolumeclaim.PVCDetail, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) EffectivePermissions(req *restful.Request, subject clientapi.Subject) ([]clientapi.PolicyRule, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) NodeAllocationSummary(req *restful.Request, nodeName string) (*node.AllocationSummary, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) ProxyService(req *restful.Request, namespace, service, path string) (io.ReadCloser, int, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) JobStatuses(req *restful.Request, namespace string) ([]clientapi.JobStatus, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) IngressRoutes(req *restful.Request, namespace, name string) ([]ingress.IngressRoute, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) ExportResourceYAML(req *restful.Request, gvr schema.GroupVersionResource, namespace, name string) ([]byte, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) PoliciesForPod(req *restful.Request, namespace, pod string) ([]networkpolicy.NetworkPolicySummary, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) ResourcesInCategory(req *restful.Request, category string) ([]schema.GroupVersionResource, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) ValidateManifest(req *restful.Request, manifest []byte) ([]clientapi.ValidationError, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) ApplyManifest(req *restful.Request, manifest []byte, opts clientapi.ApplyOptions) ([]clientapi.ApplyResult, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) ContainerProbes(req *restful.Request, namespace, pod, container string) (*container.ProbeSummary, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) PodConfigReferences(req *restful.Request, namespace, podName string) (*pod.ConfigReferences, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) StorageClasses(req *restful.Request) ([]storageclass.StorageClassSummary, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) PodUsage(req *restful.Request, namespace string) (pod.PodUsage, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) AdmissionWebhooks(req *restful.Request) ([]clientapi.WebhookSummary, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) PodSchedulingStatus(req *restful.Request, namespace, podName string) (*pod.SchedulingStatus, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) CronJobSchedules(req *restful.Request, namespace string) ([]clientapi.CronJobSummary, error) {
+	panic("implement me")
+}
@@ -0,0 +1,67 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+// WithListTimeout returns copy of the list options with the timeout requested by the options, or the
+// 'default-list-timeout-seconds' argument if timeout was not requested. Requests exceeding the
+// 'max-list-timeout-seconds' argument are rejected with bad request error.
+func WithListTimeout(options metaV1.ListOptions) (metaV1.ListOptions, error) {
+	return withListTimeout(options, args.Holder.GetDefaultListTimeoutSeconds(), args.Holder.GetMaxListTimeoutSeconds())
+}
+
+// WithDefaultListTimeout returns copy of the list options with the timeout set to the 'default-list-timeout-seconds'
+// argument, unless timeout is already set. It is used by the list helpers whose options do not come from the request.
+func WithDefaultListTimeout(options metaV1.ListOptions) metaV1.ListOptions {
+	if options.TimeoutSeconds != nil {
+		return options
+	}
+
+	options, _ = withListTimeout(options, args.Holder.GetDefaultListTimeoutSeconds(), 0)
+	return options
+}
+
+func withListTimeout(options metaV1.ListOptions, defaultTimeout, maxTimeout int64) (metaV1.ListOptions, error) {
+	if options.TimeoutSeconds == nil {
+		if defaultTimeout > 0 {
+			timeout := defaultTimeout
+			if maxTimeout > 0 && timeout > maxTimeout {
+				timeout = maxTimeout
+			}
+			options.TimeoutSeconds = &timeout
+		}
+		return options, nil
+	}
+
+	requested := *options.TimeoutSeconds
+	if requested <= 0 {
+		return options, errors.NewBadRequest(fmt.Sprintf("invalid list timeout %d", requested))
+	}
+
+	if maxTimeout > 0 && requested > maxTimeout {
+		return options, errors.NewBadRequest(fmt.Sprintf("list timeout %d exceeds maximum list timeout %d", requested,
+			maxTimeout))
+	}
+
+	return options, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+func int64Ptr(value int64) *int64 {
+	return &value
+}
+
+func TestWithListTimeout(t *testing.T) {
+	cases := []struct {
+		requested      *int64
+		defaultTimeout int64
+		maxTimeout     int64
+		expected       *int64
+		badRequest     bool
+	}{
+		{nil, 60, 300, int64Ptr(60), false},
+		{int64Ptr(120), 60, 300, int64Ptr(120), false},
+		{int64Ptr(300), 60, 300, int64Ptr(300), false},
+		{int64Ptr(301), 60, 300, nil, true},
+		{int64Ptr(0), 60, 300, nil, true},
+		{int64Ptr(-1), 60, 300, nil, true},
+		{nil, 0, 300, nil, false},
+		{int64Ptr(1000), 60, 0, int64Ptr(1000), false},
+		{nil, 600, 300, int64Ptr(300), false},
+	}
+
+	for _, c := range cases {
+		options, err := withListTimeout(metaV1.ListOptions{TimeoutSeconds: c.requested, Limit: 10}, c.defaultTimeout,
+			c.maxTimeout)
+		if c.badRequest {
+			if !errors.IsBadRequest(err) {
+				t.Errorf("Expected bad request error for timeout %v, got %v", c.requested, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Unexpected error for timeout %v: %v", c.requested, err)
+		}
+
+		if (options.TimeoutSeconds == nil) != (c.expected == nil) ||
+			(c.expected != nil && *options.TimeoutSeconds != *c.expected) || options.Limit != 10 {
+			t.Errorf("Expected timeout %v for requested timeout %v, got %+v", c.expected, c.requested, options)
+		}
+	}
+}
+
+func TestListChannelsSetDefaultTimeout(t *testing.T) {
+	args.GetHolderBuilder().SetDefaultListTimeoutSeconds(45)
+	defer args.GetHolderBuilder().SetDefaultListTimeoutSeconds(0)
+
+	timeouts := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timeouts <- r.URL.Query().Get("timeoutSeconds")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"kind":"List","apiVersion":"v1","items":[]}`))
+	}))
+	defer server.Close()
+
+	client, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	services := GetServiceListChannel(client, NewNamespaceQuery(nil), 1)
+	<-services.List
+	if err := <-services.Error; err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if timeout := <-timeouts; timeout != "45" {
+		t.Errorf("Expected default timeout to be set on the list, got %q", timeout)
+	}
+
+	pods := GetPodListChannelWithOptions(client, NewNamespaceQuery(nil),
+		metaV1.ListOptions{TimeoutSeconds: int64Ptr(10)}, 1)
+	<-pods.List
+	<-pods.Error
+	if timeout := <-timeouts; timeout != "10" {
+		t.Errorf("Expected timeout of the options to be kept, got %q", timeout)
+	}
+}
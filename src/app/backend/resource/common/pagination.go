@@ -0,0 +1,123 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+// WithPageLimit returns copy of the list options with the limit set to the requested page size, or the
+// 'default-page-size' argument if page size was not requested. Requests exceeding the 'max-page-size' argument
+// are rejected with bad request error.
+func WithPageLimit(options metaV1.ListOptions, requested int64) (metaV1.ListOptions, error) {
+	return withPageLimit(options, requested, args.Holder.GetDefaultPageSize(), args.Holder.GetMaxPageSize())
+}
+
+func withPageLimit(options metaV1.ListOptions, requested, defaultSize, maxSize int64) (metaV1.ListOptions, error) {
+	if requested < 0 {
+		return options, errors.NewBadRequest(fmt.Sprintf("invalid page size %d", requested))
+	}
+
+	if maxSize > 0 && requested > maxSize {
+		return options, errors.NewBadRequest(fmt.Sprintf("page size %d exceeds maximum page size %d", requested, maxSize))
+	}
+
+	options.Limit = requested
+	if requested == 0 {
+		options.Limit = defaultSize
+	}
+
+	if maxSize > 0 && options.Limit > maxSize {
+		options.Limit = maxSize
+	}
+
+	return options, nil
+}
+
+// ListFunc lists single page of the resources using provided options.
+type ListFunc func(options metaV1.ListOptions) (runtime.Object, error)
+
+// ListAllPages lists resources page by page with the limit set by WithPageLimit, until all of them are listed, and
+// returns them as a single list of the same type as the pages. It is used by the list helpers that need all
+// resources, so that they do not have to be fetched from the apiserver in a single request. In case continue token
+// expires before all pages are listed, resources are listed again without the limit.
+func ListAllPages(list ListFunc, options metaV1.ListOptions) (runtime.Object, error) {
+	options, err := WithPageLimit(options, options.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return listAllPages(list, options)
+}
+
+func listAllPages(list ListFunc, options metaV1.ListOptions) (runtime.Object, error) {
+	result, err := list(options)
+	if err != nil {
+		return result, err
+	}
+
+	resultMeta, err := meta.ListAccessor(result)
+	if err != nil || len(resultMeta.GetContinue()) == 0 {
+		return result, err
+	}
+
+	items, err := meta.ExtractList(result)
+	if err != nil {
+		return result, err
+	}
+
+	for token := resultMeta.GetContinue(); len(token) > 0; {
+		options.Continue = token
+		page, err := list(options)
+		if k8serrors.IsResourceExpired(err) {
+			options.Limit = 0
+			options.Continue = ""
+			return list(options)
+		}
+
+		if err != nil {
+			return page, err
+		}
+
+		pageMeta, err := meta.ListAccessor(page)
+		if err != nil {
+			return page, err
+		}
+
+		pageItems, err := meta.ExtractList(page)
+		if err != nil {
+			return page, err
+		}
+
+		items = append(items, pageItems...)
+		token = pageMeta.GetContinue()
+	}
+
+	if err := meta.SetList(result, items); err != nil {
+		return result, err
+	}
+
+	resultMeta.SetContinue("")
+	resultMeta.SetRemainingItemCount(nil)
+	return result, nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+func TestWithPageLimit(t *testing.T) {
+	cases := []struct {
+		requested   int64
+		defaultSize int64
+		maxSize     int64
+		expected    int64
+		badRequest  bool
+	}{
+		{0, 500, 5000, 500, false},
+		{100, 500, 5000, 100, false},
+		{5000, 500, 5000, 5000, false},
+		{5001, 500, 5000, 0, true},
+		{-1, 500, 5000, 0, true},
+		{0, 0, 0, 0, false},
+		{100000, 500, 0, 100000, false},
+		{0, 1000, 100, 100, false},
+	}
+
+	for _, c := range cases {
+		options, err := withPageLimit(metaV1.ListOptions{Continue: "token"}, c.requested, c.defaultSize, c.maxSize)
+		if c.badRequest {
+			if !errors.IsBadRequest(err) {
+				t.Errorf("Expected bad request error for page size %d, got %v", c.requested, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Unexpected error for page size %d: %v", c.requested, err)
+		}
+
+		if options.Limit != c.expected || options.Continue != "token" {
+			t.Errorf("Expected limit %d for page size %d, got %+v", c.expected, c.requested, options)
+		}
+	}
+}
+
+// Returns list function that serves given pods in pages of the requested limit. Continue token is the index of the
+// next pod. Function fails with expired error once token is used expiredAfter times.
+func newPagedPodList(names []string, expiredAfter int) (ListFunc, *[]metaV1.ListOptions) {
+	var requests []metaV1.ListOptions
+	continued := 0
+	return func(options metaV1.ListOptions) (runtime.Object, error) {
+		requests = append(requests, options)
+		start := 0
+		if len(options.Continue) > 0 {
+			if continued++; continued > expiredAfter {
+				return &v1.PodList{}, k8serrors.NewResourceExpired("continue token expired")
+			}
+			fmt.Sscanf(options.Continue, "%d", &start)
+		}
+
+		end := len(names)
+		if options.Limit > 0 && start+int(options.Limit) < end {
+			end = start + int(options.Limit)
+		}
+
+		list := &v1.PodList{ListMeta: metaV1.ListMeta{ResourceVersion: "7"}}
+		if end < len(names) {
+			list.Continue = fmt.Sprint(end)
+		}
+		for _, name := range names[start:end] {
+			list.Items = append(list.Items, v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: name}})
+		}
+		return list, nil
+	}, &requests
+}
+
+func TestListAllPages(t *testing.T) {
+	names := []string{"a", "b", "c", "d", "e"}
+	cases := []struct {
+		info             string
+		limit            int64
+		expiredAfter     int
+		expectedRequests []metaV1.ListOptions
+	}{
+		{"single page", 0, 10, []metaV1.ListOptions{{}}},
+		{"multiple pages", 2, 10, []metaV1.ListOptions{{Limit: 2}, {Limit: 2, Continue: "2"}, {Limit: 2, Continue: "4"}}},
+		{"expired continue token", 2, 1,
+			[]metaV1.ListOptions{{Limit: 2}, {Limit: 2, Continue: "2"}, {Limit: 2, Continue: "4"}, {}}},
+	}
+
+	for _, c := range cases {
+		list, requests := newPagedPodList(names, c.expiredAfter)
+		result, err := listAllPages(list, metaV1.ListOptions{Limit: c.limit})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.info, err)
+		}
+
+		pods := result.(*v1.PodList)
+		actual := make([]string, 0, len(pods.Items))
+		for _, pod := range pods.Items {
+			actual = append(actual, pod.Name)
+		}
+
+		if !reflect.DeepEqual(actual, names) || len(pods.Continue) > 0 || pods.ResourceVersion != "7" {
+			t.Errorf("%s: expected all pods in a single list, got %v (%+v)", c.info, actual, pods.ListMeta)
+		}
+
+		if !reflect.DeepEqual(*requests, c.expectedRequests) {
+			t.Errorf("%s: expected requests %+v, got %+v", c.info, c.expectedRequests, *requests)
+		}
+	}
+}
+
+func TestListChannelsListAllPages(t *testing.T) {
+	args.GetHolderBuilder().SetDefaultPageSize(1)
+	defer args.GetHolderBuilder().SetDefaultPageSize(0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("limit") != "1" {
+			w.Write([]byte(`{"kind":"ServiceList","apiVersion":"v1","items":[]}`))
+			return
+		}
+
+		if len(r.URL.Query().Get("continue")) == 0 {
+			w.Write([]byte(`{"kind":"ServiceList","apiVersion":"v1","metadata":{"continue":"next"},` +
+				`"items":[{"metadata":{"name":"first","namespace":"default"}}]}`))
+			return
+		}
+
+		w.Write([]byte(`{"kind":"ServiceList","apiVersion":"v1",` +
+			`"items":[{"metadata":{"name":"second","namespace":"default"}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	services := GetServiceListChannel(client, NewNamespaceQuery(nil), 1)
+	list := <-services.List
+	if err := <-services.Error; err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(list.Items) != 2 || list.Items[0].Name != "first" || list.Items[1].Name != "second" {
+		t.Errorf("Expected services of all pages to be listed, got %v", list.Items)
+	}
+}
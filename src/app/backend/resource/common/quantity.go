@@ -0,0 +1,108 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// QuantityDisplay is a resource quantity normalized to the display unit of its resource, so that i.e. '1G', '1000M'
+// and '1e9' memory is displayed the same way.
+type QuantityDisplay struct {
+	// Raw is the canonical form of the quantity, i.e. '1536Mi' or '500m' for '0.5'.
+	Raw string `json:"raw"`
+	// Base is the quantity in base units of the resource: cores for CPU and bytes for memory and storage.
+	Base float64 `json:"base"`
+	// Value is the quantity in Unit, rounded to two decimal places.
+	Value float64 `json:"value"`
+	// Unit is 'm' or empty (cores) for CPU, one of binary suffixes ('Ki', 'Mi', 'Gi', ...) or empty (bytes) for memory
+	// and storage, and empty for other resources.
+	Unit string `json:"unit"`
+	// Formatted is the value followed by unit, i.e. '1.5Gi' or '250m'.
+	Formatted string `json:"formatted"`
+}
+
+// Binary suffixes used to display byte quantities. Decimal quantities, i.e. '1G', are converted to them, since mixing
+// both on one page makes the values hard to compare.
+var binaryUnits = []string{"", "Ki", "Mi", "Gi", "Ti", "Pi", "Ei"}
+
+// FormatQuantity normalizes the quantity of the given resource to its display unit. CPU is displayed in millicores
+// below one core and in cores otherwise. Memory, storage and huge pages are displayed in the largest binary unit in
+// which the value is at least one. Quantities of other resources are displayed as they are.
+func FormatQuantity(name v1.ResourceName, quantity resource.Quantity) QuantityDisplay {
+	display := QuantityDisplay{Raw: quantity.String()}
+
+	switch {
+	case name == v1.ResourceCPU:
+		display.Base = float64(quantity.MilliValue()) / 1000
+		display.Value, display.Unit = display.Base, ""
+		if display.Base != 0 && math.Abs(display.Base) < 1 {
+			display.Value, display.Unit = float64(quantity.MilliValue()), "m"
+		}
+	case isByteResource(name):
+		display.Base = quantity.AsApproximateFloat64()
+		display.Value, display.Unit = scaleBinary(display.Base)
+	default:
+		display.Base = quantity.AsApproximateFloat64()
+		display.Value = display.Base
+	}
+
+	display.Value = roundQuantity(display.Value)
+	display.Formatted = strconv.FormatFloat(display.Value, 'f', -1, 64) + display.Unit
+	return display
+}
+
+// FormatResourceList normalizes all quantities of the resource list. See FormatQuantity for more information.
+func FormatResourceList(list v1.ResourceList) map[v1.ResourceName]QuantityDisplay {
+	result := make(map[v1.ResourceName]QuantityDisplay, len(list))
+	for name, quantity := range list {
+		result[name] = FormatQuantity(name, quantity)
+	}
+
+	return result
+}
+
+func isByteResource(name v1.ResourceName) bool {
+	switch name {
+	case v1.ResourceMemory, v1.ResourceStorage, v1.ResourceEphemeralStorage,
+		v1.ResourceRequestsMemory, v1.ResourceLimitsMemory, v1.ResourceRequestsStorage,
+		v1.ResourceRequestsEphemeralStorage, v1.ResourceLimitsEphemeralStorage:
+		return true
+	}
+
+	return strings.HasPrefix(string(name), v1.ResourceHugePagesPrefix)
+}
+
+// Returns bytes in the largest binary unit in which the rounded value is at least one.
+func scaleBinary(bytes float64) (float64, string) {
+	value := bytes
+	for i := range binaryUnits {
+		if i == len(binaryUnits)-1 || math.Abs(roundQuantity(value)) < 1024 {
+			return value, binaryUnits[i]
+		}
+		value /= 1024
+	}
+
+	return bytes, ""
+}
+
+func roundQuantity(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,101 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestFormatQuantity(t *testing.T) {
+	cases := []struct {
+		name      v1.ResourceName
+		quantity  string
+		raw       string
+		value     float64
+		unit      string
+		formatted string
+	}{
+		{v1.ResourceCPU, "250m", "250m", 250, "m", "250m"},
+		{v1.ResourceCPU, "0.5", "500m", 500, "m", "500m"},
+		{v1.ResourceCPU, "1", "1", 1, "", "1"},
+		{v1.ResourceCPU, "1500m", "1500m", 1.5, "", "1.5"},
+		{v1.ResourceCPU, "2.25", "2250m", 2.25, "", "2.25"},
+		{v1.ResourceCPU, "0", "0", 0, "", "0"},
+		{v1.ResourceMemory, "512", "512", 512, "", "512"},
+		{v1.ResourceMemory, "1Ki", "1Ki", 1, "Ki", "1Ki"},
+		{v1.ResourceMemory, "1536Mi", "1536Mi", 1.5, "Gi", "1.5Gi"},
+		{v1.ResourceMemory, "2Gi", "2Gi", 2, "Gi", "2Gi"},
+		{v1.ResourceMemory, "1G", "1G", 953.67, "Mi", "953.67Mi"},
+		{v1.ResourceMemory, "1000M", "1G", 953.67, "Mi", "953.67Mi"},
+		{v1.ResourceMemory, "1e9", "1e9", 953.67, "Mi", "953.67Mi"},
+		{v1.ResourceMemory, "128k", "128k", 125, "Ki", "125Ki"},
+		{v1.ResourceMemory, "1048575", "1048575", 1, "Mi", "1Mi"},
+		{v1.ResourceStorage, "10Ti", "10Ti", 10, "Ti", "10Ti"},
+		{v1.ResourceEphemeralStorage, "5Gi", "5Gi", 5, "Gi", "5Gi"},
+		{v1.ResourceRequestsMemory, "64Mi", "64Mi", 64, "Mi", "64Mi"},
+		{"hugepages-2Mi", "4Mi", "4Mi", 4, "Mi", "4Mi"},
+		{v1.ResourcePods, "110", "110", 110, "", "110"},
+		{"nvidia.com/gpu", "2", "2", 2, "", "2"},
+	}
+
+	for _, c := range cases {
+		actual := FormatQuantity(c.name, resource.MustParse(c.quantity))
+		if actual.Raw != c.raw || actual.Value != c.value || actual.Unit != c.unit ||
+			actual.Formatted != c.formatted {
+			t.Errorf("FormatQuantity(%s, %s) == %#v, expected raw %q, value %v, unit %q, formatted %q",
+				c.name, c.quantity, actual, c.raw, c.value, c.unit, c.formatted)
+		}
+	}
+}
+
+func TestFormatQuantityBase(t *testing.T) {
+	cases := []struct {
+		name     v1.ResourceName
+		quantity string
+		expected float64
+	}{
+		{v1.ResourceCPU, "250m", 0.25},
+		{v1.ResourceCPU, "2", 2},
+		{v1.ResourceMemory, "1Mi", 1048576},
+		{v1.ResourceMemory, "1M", 1000000},
+	}
+
+	for _, c := range cases {
+		actual := FormatQuantity(c.name, resource.MustParse(c.quantity))
+		if actual.Base != c.expected {
+			t.Errorf("FormatQuantity(%s, %s).Base == %v, expected %v", c.name, c.quantity, actual.Base, c.expected)
+		}
+	}
+}
+
+func TestFormatResourceList(t *testing.T) {
+	list := v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("100m"),
+		v1.ResourceMemory: resource.MustParse("256Mi"),
+	}
+	expected := map[v1.ResourceName]QuantityDisplay{
+		v1.ResourceCPU:    {Raw: "100m", Base: 0.1, Value: 100, Unit: "m", Formatted: "100m"},
+		v1.ResourceMemory: {Raw: "256Mi", Base: 268435456, Value: 256, Unit: "Mi", Formatted: "256Mi"},
+	}
+
+	actual := FormatResourceList(list)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("FormatResourceList(%#v) == \ngot %#v, \nexpected %#v", list, actual, expected)
+	}
+}
@@ -29,6 +29,7 @@ import (
 
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	client "k8s.io/client-go/kubernetes"
 
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/api"
@@ -144,7 +145,10 @@ func GetServiceListChannel(client client.Interface, nsQuery *NamespaceQuery,
 		Error: make(chan error, numReads),
 	}
 	go func() {
-		list, err := client.CoreV1().Services(nsQuery.ToRequestParam()).List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.CoreV1().Services(nsQuery.ToRequestParam()).List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*v1.ServiceList)
 		var filteredItems []v1.Service
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -177,7 +181,10 @@ func GetIngressListChannel(client client.Interface, nsQuery *NamespaceQuery,
 		Error: make(chan error, numReads),
 	}
 	go func() {
-		list, err := client.NetworkingV1().Ingresses(nsQuery.ToRequestParam()).List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.NetworkingV1().Ingresses(nsQuery.ToRequestParam()).List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*networkingv1.IngressList)
 		var filteredItems []networkingv1.Ingress
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -211,7 +218,10 @@ func GetLimitRangeListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	}
 
 	go func() {
-		list, err := client.CoreV1().LimitRanges(nsQuery.ToRequestParam()).List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.CoreV1().LimitRanges(nsQuery.ToRequestParam()).List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*v1.LimitRangeList)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -236,7 +246,10 @@ func GetNodeListChannel(client client.Interface, numReads int) NodeListChannel {
 	}
 
 	go func() {
-		list, err := client.CoreV1().Nodes().List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.CoreV1().Nodes().List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*v1.NodeList)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -262,7 +275,10 @@ func GetNamespaceListChannel(client client.Interface, numReads int) NamespaceLis
 	}
 
 	go func() {
-		list, err := client.CoreV1().Namespaces().List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.CoreV1().Namespaces().List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*v1.NamespaceList)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -294,7 +310,10 @@ func GetEventListChannelWithOptions(client client.Interface,
 	}
 
 	go func() {
-		list, err := client.CoreV1().Events(nsQuery.ToRequestParam()).List(context.TODO(), options)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.CoreV1().Events(nsQuery.ToRequestParam()).List(context.TODO(), options)
+		}, WithDefaultListTimeout(options))
+		list := result.(*v1.EventList)
 		var filteredItems []v1.Event
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -330,7 +349,10 @@ func GetEndpointListChannelWithOptions(client client.Interface,
 	}
 
 	go func() {
-		list, err := client.CoreV1().Endpoints(nsQuery.ToRequestParam()).List(context.TODO(), opt)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.CoreV1().Endpoints(nsQuery.ToRequestParam()).List(context.TODO(), options)
+		}, WithDefaultListTimeout(opt))
+		list := result.(*v1.EndpointsList)
 
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
@@ -364,7 +386,10 @@ func GetPodListChannelWithOptions(client client.Interface, nsQuery *NamespaceQue
 	}
 
 	go func() {
-		list, err := client.CoreV1().Pods(nsQuery.ToRequestParam()).List(context.TODO(), options)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.CoreV1().Pods(nsQuery.ToRequestParam()).List(context.TODO(), options)
+		}, WithDefaultListTimeout(options))
+		list := result.(*v1.PodList)
 		var filteredItems []v1.Pod
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -399,8 +424,10 @@ func GetReplicationControllerListChannel(client client.Interface,
 	}
 
 	go func() {
-		list, err := client.CoreV1().ReplicationControllers(nsQuery.ToRequestParam()).
-			List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.CoreV1().ReplicationControllers(nsQuery.ToRequestParam()).List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*v1.ReplicationControllerList)
 		var filteredItems []v1.ReplicationController
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -434,8 +461,10 @@ func GetDeploymentListChannel(client client.Interface,
 	}
 
 	go func() {
-		list, err := client.AppsV1().Deployments(nsQuery.ToRequestParam()).
-			List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.AppsV1().Deployments(nsQuery.ToRequestParam()).List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*apps.DeploymentList)
 		var filteredItems []apps.Deployment
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -475,8 +504,10 @@ func GetReplicaSetListChannelWithOptions(client client.Interface, nsQuery *Names
 	}
 
 	go func() {
-		list, err := client.AppsV1().ReplicaSets(nsQuery.ToRequestParam()).
-			List(context.TODO(), options)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.AppsV1().ReplicaSets(nsQuery.ToRequestParam()).List(context.TODO(), options)
+		}, WithDefaultListTimeout(options))
+		list := result.(*apps.ReplicaSetList)
 		var filteredItems []apps.ReplicaSet
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -508,7 +539,10 @@ func GetDaemonSetListChannel(client client.Interface, nsQuery *NamespaceQuery, n
 	}
 
 	go func() {
-		list, err := client.AppsV1().DaemonSets(nsQuery.ToRequestParam()).List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.AppsV1().DaemonSets(nsQuery.ToRequestParam()).List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*apps.DaemonSetList)
 		var filteredItems []apps.DaemonSet
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -540,7 +574,10 @@ func GetJobListChannel(client client.Interface,
 	}
 
 	go func() {
-		list, err := client.BatchV1().Jobs(nsQuery.ToRequestParam()).List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.BatchV1().Jobs(nsQuery.ToRequestParam()).List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*batch.JobList)
 		var filteredItems []batch.Job
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -571,7 +608,10 @@ func GetCronJobListChannel(client client.Interface, nsQuery *NamespaceQuery, num
 	}
 
 	go func() {
-		list, err := client.BatchV1beta1().CronJobs(nsQuery.ToRequestParam()).List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.BatchV1beta1().CronJobs(nsQuery.ToRequestParam()).List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*batch2.CronJobList)
 		var filteredItems []batch2.CronJob
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -604,7 +644,10 @@ func GetStatefulSetListChannel(client client.Interface,
 	}
 
 	go func() {
-		statefulSets, err := client.AppsV1().StatefulSets(nsQuery.ToRequestParam()).List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.AppsV1().StatefulSets(nsQuery.ToRequestParam()).List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		statefulSets := result.(*apps.StatefulSetList)
 		var filteredItems []apps.StatefulSet
 		for _, item := range statefulSets.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -638,7 +681,10 @@ func GetConfigMapListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	}
 
 	go func() {
-		list, err := client.CoreV1().ConfigMaps(nsQuery.ToRequestParam()).List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.CoreV1().ConfigMaps(nsQuery.ToRequestParam()).List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*v1.ConfigMapList)
 		var filteredItems []v1.ConfigMap
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -672,7 +718,10 @@ func GetSecretListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	}
 
 	go func() {
-		list, err := client.CoreV1().Secrets(nsQuery.ToRequestParam()).List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.CoreV1().Secrets(nsQuery.ToRequestParam()).List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*v1.SecretList)
 		var filteredItems []v1.Secret
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -704,7 +753,10 @@ func GetRoleListChannel(client client.Interface, nsQuery *NamespaceQuery, numRea
 	}
 
 	go func() {
-		list, err := client.RbacV1().Roles(nsQuery.ToRequestParam()).List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.RbacV1().Roles(nsQuery.ToRequestParam()).List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*rbac.RoleList)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -729,7 +781,10 @@ func GetClusterRoleListChannel(client client.Interface, numReads int) ClusterRol
 	}
 
 	go func() {
-		list, err := client.RbacV1().ClusterRoles().List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.RbacV1().ClusterRoles().List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*rbac.ClusterRoleList)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -754,7 +809,10 @@ func GetRoleBindingListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	}
 
 	go func() {
-		list, err := client.RbacV1().RoleBindings(nsQuery.ToRequestParam()).List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.RbacV1().RoleBindings(nsQuery.ToRequestParam()).List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*rbac.RoleBindingList)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -780,7 +838,10 @@ func GetClusterRoleBindingListChannel(client client.Interface,
 	}
 
 	go func() {
-		list, err := client.RbacV1().ClusterRoleBindings().List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.RbacV1().ClusterRoleBindings().List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*rbac.ClusterRoleBindingList)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -806,7 +867,10 @@ func GetPersistentVolumeListChannel(client client.Interface,
 	}
 
 	go func() {
-		list, err := client.CoreV1().PersistentVolumes().List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.CoreV1().PersistentVolumes().List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*v1.PersistentVolumeList)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -833,7 +897,10 @@ func GetPersistentVolumeClaimListChannel(client client.Interface, nsQuery *Names
 	}
 
 	go func() {
-		list, err := client.CoreV1().PersistentVolumeClaims(nsQuery.ToRequestParam()).List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.CoreV1().PersistentVolumeClaims(nsQuery.ToRequestParam()).List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*v1.PersistentVolumeClaimList)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -858,7 +925,10 @@ func GetCustomResourceDefinitionChannelV1(client apiextensionsclientset.Interfac
 	}
 
 	go func() {
-		list, err := client.ApiextensionsV1().CustomResourceDefinitions().List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.ApiextensionsV1().CustomResourceDefinitions().List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*apiextensions.CustomResourceDefinitionList)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -885,7 +955,10 @@ func GetResourceQuotaListChannel(client client.Interface, nsQuery *NamespaceQuer
 	}
 
 	go func() {
-		list, err := client.CoreV1().ResourceQuotas(nsQuery.ToRequestParam()).List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.CoreV1().ResourceQuotas(nsQuery.ToRequestParam()).List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*v1.ResourceQuotaList)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -911,8 +984,11 @@ func GetHorizontalPodAutoscalerListChannel(client client.Interface, nsQuery *Nam
 	}
 
 	go func() {
-		list, err := client.AutoscalingV1().HorizontalPodAutoscalers(nsQuery.ToRequestParam()).
-			List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.AutoscalingV1().HorizontalPodAutoscalers(nsQuery.ToRequestParam()).
+				List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*autoscaling.HorizontalPodAutoscalerList)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -937,7 +1013,10 @@ func GetStorageClassListChannel(client client.Interface, numReads int) StorageCl
 	}
 
 	go func() {
-		list, err := client.StorageV1().StorageClasses().List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.StorageV1().StorageClasses().List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*storage.StorageClassList)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -962,7 +1041,10 @@ func GetIngressClassListChannel(client client.Interface, numReads int) IngressCl
 	}
 
 	go func() {
-		list, err := client.NetworkingV1().IngressClasses().List(context.TODO(), api.ListEverything)
+		result, err := ListAllPages(func(options metaV1.ListOptions) (runtime.Object, error) {
+			return client.NetworkingV1().IngressClasses().List(context.TODO(), options)
+		}, WithDefaultListTimeout(api.ListEverything))
+		list := result.(*networkingv1.IngressClassList)
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
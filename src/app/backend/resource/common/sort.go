@@ -0,0 +1,174 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+const (
+	// SortByName sorts objects by their name.
+	SortByName = "name"
+	// SortByNamespace sorts objects by their namespace.
+	SortByNamespace = "namespace"
+	// SortByCreationTimestamp sorts objects by their creation time.
+	SortByCreationTimestamp = "creationTimestamp"
+	// SortByLabelPrefix followed by the label key sorts objects by value of the label, i.e. 'label:app'.
+	SortByLabelPrefix = "label:"
+
+	// SortOrderAscending is the default sort order.
+	SortOrderAscending = "asc"
+	// SortOrderDescending reverses the sort order.
+	SortOrderDescending = "desc"
+)
+
+// ListSort describes how objects of the fetched list page should be sorted. The apiserver returns objects ordered
+// by namespace and name only, so sorting is applied to the objects of the page once they are fetched.
+type ListSort struct {
+	// By is one of SortByName, SortByNamespace, SortByCreationTimestamp or SortByLabelPrefix.
+	By string
+	// Label is the key of the label when sorting by label value.
+	Label      string
+	Descending bool
+}
+
+// ParseListSort parses 'sortBy' and 'sortOrder' parameters, i.e. 'creationTimestamp' and 'desc'. Nil is returned
+// when sorting was not requested. Unknown sort keys, invalid label keys and sort orders are rejected with bad
+// request error.
+func ParseListSort(sortBy, sortOrder string) (*ListSort, error) {
+	if len(sortBy) == 0 {
+		if len(sortOrder) > 0 {
+			return nil, errors.NewBadRequest("sort order requires sort key to be provided")
+		}
+		return nil, nil
+	}
+
+	result := &ListSort{By: sortBy}
+	switch {
+	case sortBy == SortByName, sortBy == SortByNamespace, sortBy == SortByCreationTimestamp:
+	case strings.HasPrefix(sortBy, SortByLabelPrefix):
+		result.By = SortByLabelPrefix
+		result.Label = strings.TrimPrefix(sortBy, SortByLabelPrefix)
+		if errs := validation.IsQualifiedName(result.Label); len(errs) > 0 {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid label key %q: %s", result.Label,
+				strings.Join(errs, "; ")))
+		}
+	default:
+		return nil, errors.NewBadRequest(fmt.Sprintf("unsupported sort key %q, expected one of %s, %s, %s or %s<key>",
+			sortBy, SortByName, SortByNamespace, SortByCreationTimestamp, SortByLabelPrefix))
+	}
+
+	switch sortOrder {
+	case "", SortOrderAscending:
+	case SortOrderDescending:
+		result.Descending = true
+	default:
+		return nil, errors.NewBadRequest(fmt.Sprintf("unsupported sort order %q, expected %s or %s", sortOrder,
+			SortOrderAscending, SortOrderDescending))
+	}
+
+	return result, nil
+}
+
+// SortList sorts items of the list in place, i.e. of UnstructuredList or PartialObjectMetadataList. Objects with
+// equal sort values are ordered by namespace and name, so that the order is stable between requests. When sorting
+// by label, objects without the label are always listed last. Nil sort leaves the list untouched.
+func SortList(list runtime.Object, listSort *ListSort) error {
+	if listSort == nil {
+		return nil
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+
+	accessors := make([]metaV1.Object, len(items))
+	for i, item := range items {
+		if accessors[i], err = meta.Accessor(item); err != nil {
+			return err
+		}
+	}
+
+	indexes := make([]int, len(items))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return listSort.less(accessors[indexes[i]], accessors[indexes[j]])
+	})
+
+	sorted := make([]runtime.Object, len(items))
+	for i, index := range indexes {
+		sorted[i] = items[index]
+	}
+
+	return meta.SetList(list, sorted)
+}
+
+func (self *ListSort) less(a, b metaV1.Object) bool {
+	if cmp := self.compare(a, b); cmp != 0 {
+		return cmp < 0
+	}
+
+	if a.GetNamespace() != b.GetNamespace() {
+		return a.GetNamespace() < b.GetNamespace()
+	}
+	return a.GetName() < b.GetName()
+}
+
+// Compares sort values of the objects taking the order into account. Missing labels are compared separately, so
+// that they are not reversed by the descending order.
+func (self *ListSort) compare(a, b metaV1.Object) int {
+	var cmp int
+	switch self.By {
+	case SortByName:
+		cmp = strings.Compare(a.GetName(), b.GetName())
+	case SortByNamespace:
+		cmp = strings.Compare(a.GetNamespace(), b.GetNamespace())
+	case SortByCreationTimestamp:
+		aTime, bTime := a.GetCreationTimestamp(), b.GetCreationTimestamp()
+		if aTime.Before(&bTime) {
+			cmp = -1
+		} else if bTime.Before(&aTime) {
+			cmp = 1
+		}
+	case SortByLabelPrefix:
+		aValue, aOk := a.GetLabels()[self.Label]
+		bValue, bOk := b.GetLabels()[self.Label]
+		if aOk != bOk {
+			if aOk {
+				return -1
+			}
+			return 1
+		}
+		cmp = strings.Compare(aValue, bValue)
+	}
+
+	if self.Descending {
+		return -cmp
+	}
+	return cmp
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+func TestParseListSort(t *testing.T) {
+	cases := []struct {
+		sortBy, sortOrder string
+		expected          *ListSort
+		badRequest        bool
+	}{
+		{"", "", nil, false},
+		{"name", "", &ListSort{By: SortByName}, false},
+		{"namespace", "asc", &ListSort{By: SortByNamespace}, false},
+		{"creationTimestamp", "desc", &ListSort{By: SortByCreationTimestamp, Descending: true}, false},
+		{"label:app.kubernetes.io/name", "desc",
+			&ListSort{By: SortByLabelPrefix, Label: "app.kubernetes.io/name", Descending: true}, false},
+		{"", "desc", nil, true},
+		{"status", "", nil, true},
+		{"label:", "", nil, true},
+		{"label:not a key", "", nil, true},
+		{"name", "descending", nil, true},
+	}
+
+	for _, c := range cases {
+		actual, err := ParseListSort(c.sortBy, c.sortOrder)
+		if c.badRequest {
+			if !errors.IsBadRequest(err) {
+				t.Errorf("Expected bad request error for %q %q, got %v", c.sortBy, c.sortOrder, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Unexpected error for %q %q: %v", c.sortBy, c.sortOrder, err)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("ParseListSort(%q, %q) == %+v, expected %+v", c.sortBy, c.sortOrder, actual, c.expected)
+		}
+	}
+}
+
+func TestSortList(t *testing.T) {
+	now := time.Now()
+	newItem := func(namespace, name string, age time.Duration, labels map[string]string) metaV1.PartialObjectMetadata {
+		return metaV1.PartialObjectMetadata{ObjectMeta: metaV1.ObjectMeta{Namespace: namespace, Name: name,
+			CreationTimestamp: metaV1.NewTime(now.Add(-age)), Labels: labels}}
+	}
+
+	cases := []struct {
+		listSort *ListSort
+		expected []string
+	}{
+		{nil, []string{"b/web", "a/db", "b/api", "a/cache"}},
+		{&ListSort{By: SortByName}, []string{"b/api", "a/cache", "a/db", "b/web"}},
+		{&ListSort{By: SortByName, Descending: true}, []string{"b/web", "a/db", "a/cache", "b/api"}},
+		{&ListSort{By: SortByNamespace}, []string{"a/cache", "a/db", "b/api", "b/web"}},
+		{&ListSort{By: SortByNamespace, Descending: true}, []string{"b/api", "b/web", "a/cache", "a/db"}},
+		{&ListSort{By: SortByCreationTimestamp}, []string{"a/db", "b/api", "b/web", "a/cache"}},
+		{&ListSort{By: SortByCreationTimestamp, Descending: true}, []string{"a/cache", "b/api", "b/web", "a/db"}},
+		{&ListSort{By: SortByLabelPrefix, Label: "tier"}, []string{"b/api", "b/web", "a/db", "a/cache"}},
+		{&ListSort{By: SortByLabelPrefix, Label: "tier", Descending: true},
+			[]string{"a/db", "b/api", "b/web", "a/cache"}},
+	}
+
+	for _, c := range cases {
+		// Creation timestamps of 'b/web' and 'b/api' are equal to check that ties are broken by namespace and name.
+		list := &metaV1.PartialObjectMetadataList{Items: []metaV1.PartialObjectMetadata{
+			newItem("b", "web", 2*time.Hour, map[string]string{"tier": "frontend"}),
+			newItem("a", "db", 3*time.Hour, map[string]string{"tier": "storage"}),
+			newItem("b", "api", 2*time.Hour, map[string]string{"tier": "frontend"}),
+			newItem("a", "cache", time.Hour, nil),
+		}}
+
+		if err := SortList(list, c.listSort); err != nil {
+			t.Errorf("Unexpected error for %+v: %v", c.listSort, err)
+			continue
+		}
+
+		actual := make([]string, 0, len(list.Items))
+		for _, item := range list.Items {
+			actual = append(actual, item.Namespace+"/"+item.Name)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("SortList(%+v) == %v, expected %v", c.listSort, actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// DefaultContainerAnnotation names the container used by kubectl when no container is specified.
+const DefaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+// DefaultContainerName returns the container that should be used when no container is specified. Container named by
+// the default container annotation is preferred over the first one. Returns empty string if pod has no containers.
+func DefaultContainerName(pod *v1.Pod) string {
+	if name, ok := pod.Annotations[DefaultContainerAnnotation]; ok {
+		for _, container := range pod.Spec.Containers {
+			if container.Name == name {
+				return name
+			}
+		}
+	}
+
+	if len(pod.Spec.Containers) == 0 {
+		return ""
+	}
+
+	return pod.Spec.Containers[0].Name
+}
@@ -0,0 +1,48 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDefaultContainerName(t *testing.T) {
+	containers := []v1.Container{{Name: "app"}, {Name: "sidecar"}}
+	cases := []struct {
+		annotations map[string]string
+		containers  []v1.Container
+		expected    string
+	}{
+		{map[string]string{DefaultContainerAnnotation: "sidecar"}, containers, "sidecar"},
+		{map[string]string{DefaultContainerAnnotation: "missing"}, containers, "app"},
+		{nil, containers, "app"},
+		{map[string]string{DefaultContainerAnnotation: "sidecar"}, nil, ""},
+	}
+
+	for _, c := range cases {
+		pod := &v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "pod", Annotations: c.annotations},
+			Spec:       v1.PodSpec{Containers: c.containers},
+		}
+
+		if actual := DefaultContainerName(pod); actual != c.expected {
+			t.Errorf("Expected default container %q for annotations %v, but got %q", c.expected, c.annotations,
+				actual)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"sort"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/api"
+	"github.com/docker/distribution/reference"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ImageRef describes container image used by the workloads.
+type ImageRef struct {
+	// Image is the reference as specified in the container spec.
+	Image      string `json:"image"`
+	Registry   string `json:"registry"`
+	Repository string `json:"repository"`
+	Tag        string `json:"tag,omitempty"`
+	Digest     string `json:"digest,omitempty"`
+	// Pinned is true if image is referenced by digest, so it can not change without updating the workload.
+	Pinned bool `json:"pinned"`
+	// Valid is false if the reference could not be parsed.
+	Valid bool `json:"valid"`
+	// Workloads using the image in format 'Kind/name'.
+	Workloads []string `json:"workloads"`
+}
+
+// ParseImageReference parses image reference in format '[registry[:port]/]repository[:tag][@digest]'. Registry
+// defaults to docker.io and tag to latest if neither tag nor digest is specified, in the same way as container
+// runtimes do.
+func ParseImageReference(image string) ImageRef {
+	result := ImageRef{Image: image, Workloads: make([]string, 0)}
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return result
+	}
+
+	named = reference.TagNameOnly(named)
+	result.Valid = true
+	result.Registry = reference.Domain(named)
+	result.Repository = reference.Path(named)
+	if tagged, ok := named.(reference.Tagged); ok {
+		result.Tag = tagged.Tag()
+	}
+
+	if digested, ok := named.(reference.Digested); ok {
+		result.Digest = digested.Digest().String()
+		result.Pinned = true
+	}
+
+	return result
+}
+
+// GetWorkloadImages returns images used by the pods and deployments in the namespace, sorted by the reference. Pods
+// are reported as their controllers, so that images are attributed to the workloads managing them.
+func GetWorkloadImages(client kubernetes.Interface, namespace string) ([]ImageRef, error) {
+	pods, err := client.CoreV1().Pods(namespace).List(context.TODO(), api.ListEverything)
+	if err != nil {
+		return nil, err
+	}
+
+	deployments, err := client.AppsV1().Deployments(namespace).List(context.TODO(), api.ListEverything)
+	if err != nil {
+		return nil, err
+	}
+
+	images := make(map[string]*ImageRef)
+	add := func(spec v1.PodSpec, workload string) {
+		for _, container := range append(append([]v1.Container{}, spec.InitContainers...), spec.Containers...) {
+			ref, exists := images[container.Image]
+			if !exists {
+				parsed := ParseImageReference(container.Image)
+				ref = &parsed
+				images[container.Image] = ref
+			}
+
+			if !containsString(ref.Workloads, workload) {
+				ref.Workloads = append(ref.Workloads, workload)
+			}
+		}
+	}
+
+	for _, pod := range pods.Items {
+		workload := "Pod/" + pod.Name
+		if controller := metaV1.GetControllerOf(&pod); controller != nil {
+			workload = controller.Kind + "/" + controller.Name
+		}
+		add(pod.Spec, workload)
+	}
+
+	for _, deployment := range deployments.Items {
+		add(deployment.Spec.Template.Spec, "Deployment/"+deployment.Name)
+	}
+
+	result := make([]ImageRef, 0, len(images))
+	for _, ref := range images {
+		sort.Strings(ref.Workloads)
+		result = append(result, *ref)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Image < result[j].Image })
+	return result, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
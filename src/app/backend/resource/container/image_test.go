@@ -0,0 +1,98 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"reflect"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestParseImageReference(t *testing.T) {
+	cases := []struct {
+		image    string
+		expected ImageRef
+	}{
+		{"nginx", ImageRef{Registry: "docker.io", Repository: "library/nginx", Tag: "latest", Valid: true}},
+		{"nginx:1.21", ImageRef{Registry: "docker.io", Repository: "library/nginx", Tag: "1.21", Valid: true}},
+		{"nginx@" + testDigest,
+			ImageRef{Registry: "docker.io", Repository: "library/nginx", Digest: testDigest, Pinned: true, Valid: true}},
+		{"gcr.io/project/app:v1@" + testDigest,
+			ImageRef{Registry: "gcr.io", Repository: "project/app", Tag: "v1", Digest: testDigest, Pinned: true,
+				Valid: true}},
+		{"localhost:5000/team/app:dev",
+			ImageRef{Registry: "localhost:5000", Repository: "team/app", Tag: "dev", Valid: true}},
+		{"registry.example.com:8443/app",
+			ImageRef{Registry: "registry.example.com:8443", Repository: "app", Tag: "latest", Valid: true}},
+		{"Invalid:Image", ImageRef{}},
+	}
+
+	for _, c := range cases {
+		c.expected.Image = c.image
+		c.expected.Workloads = []string{}
+		if actual := ParseImageReference(c.image); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("ParseImageReference(%s): expected %+v, got %+v", c.image, c.expected, actual)
+		}
+	}
+}
+
+func TestGetWorkloadImages(t *testing.T) {
+	controller := true
+	client := fake.NewSimpleClientset(
+		&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "web-abc", Namespace: "default", OwnerReferences: []metaV1.OwnerReference{
+				{Kind: "ReplicaSet", Name: "web-123", Controller: &controller}}},
+			Spec: v1.PodSpec{
+				InitContainers: []v1.Container{{Name: "init", Image: "busybox@" + testDigest}},
+				Containers:     []v1.Container{{Name: "web", Image: "nginx:1.21"}},
+			},
+		},
+		&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "debug", Namespace: "default"},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "debug", Image: "nginx:1.21"}}},
+		},
+		&apps.Deployment{
+			ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: apps.DeploymentSpec{Template: v1.PodTemplateSpec{Spec: v1.PodSpec{
+				Containers: []v1.Container{{Name: "web", Image: "nginx:1.21"}},
+			}}},
+		},
+	)
+
+	images, err := GetWorkloadImages(client, "default")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(images) != 2 {
+		t.Fatalf("Expected 2 images, got %+v", images)
+	}
+
+	if images[0].Image != "busybox@"+testDigest || !images[0].Pinned ||
+		!reflect.DeepEqual(images[0].Workloads, []string{"ReplicaSet/web-123"}) {
+		t.Errorf("Expected pinned busybox image used by replica set, got %+v", images[0])
+	}
+
+	if images[1].Image != "nginx:1.21" || images[1].Pinned ||
+		!reflect.DeepEqual(images[1].Workloads, []string{"Deployment/web", "Pod/debug", "ReplicaSet/web-123"}) {
+		t.Errorf("Expected nginx image referenced by tag used by all workloads, got %+v", images[1])
+	}
+}
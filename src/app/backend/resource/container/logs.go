@@ -52,31 +52,36 @@ func GetPodContainers(client kubernetes.Interface, namespace, podID string) (*Po
 	return containers, nil
 }
 
-// GetLogDetails returns logs for particular pod and container. When container is null, logs for the first one
-// are returned. Previous indicates to read archived logs created by log rotation or container crash
+// GetLogDetails returns logs for particular pod and container. When container is null, logs for the default one
+// are returned, see DefaultContainerName. Previous indicates to read archived logs created by log rotation or container crash
+// Tail lines limit the number of lines read from the end of the log, nil means lineReadLimit.
 func GetLogDetails(client kubernetes.Interface, namespace, podID string, container string,
-	logSelector *logs.Selection, usePreviousLogs bool) (*logs.LogDetails, error) {
+	logSelector *logs.Selection, usePreviousLogs bool, tailLines *int64) (*logs.LogDetails, error) {
 	pod, err := client.CoreV1().Pods(namespace).Get(context.TODO(), podID, metaV1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
 
 	if len(container) == 0 {
-		container = pod.Spec.Containers[0].Name
+		container = DefaultContainerName(pod)
 	}
 
-	logOptions := mapToLogOptions(container, logSelector, usePreviousLogs)
+	logOptions := mapToLogOptions(container, logSelector, usePreviousLogs, tailLines)
 	rawLogs, err := readRawLogs(client, namespace, podID, logOptions)
 	if err != nil {
 		return nil, err
 	}
-	details := ConstructLogDetails(podID, rawLogs, container, logSelector)
+	lineLimit := lineReadLimit
+	if tailLines != nil {
+		lineLimit = *tailLines
+	}
+	details := constructLogDetails(podID, rawLogs, container, logSelector, lineLimit)
 	return details, nil
 }
 
 // Maps the log selection to the corresponding api object
-// Read limits are set to avoid out of memory issues
-func mapToLogOptions(container string, logSelector *logs.Selection, previous bool) *v1.PodLogOptions {
+// Read limits are set to avoid out of memory issues. Line limit defaults to lineReadLimit if tail lines are nil.
+func mapToLogOptions(container string, logSelector *logs.Selection, previous bool, tailLines *int64) *v1.PodLogOptions {
 	logOptions := &v1.PodLogOptions{
 		Container:  container,
 		Follow:     false,
@@ -86,6 +91,8 @@ func mapToLogOptions(container string, logSelector *logs.Selection, previous boo
 
 	if logSelector.LogFilePosition == logs.Beginning {
 		logOptions.LimitBytes = &byteReadLimit
+	} else if tailLines != nil {
+		logOptions.TailLines = tailLines
 	} else {
 		logOptions.TailLines = &lineReadLimit
 	}
@@ -119,6 +126,7 @@ func GetLogFile(client kubernetes.Interface, namespace, podID string, container
 		Follow:     false,
 		Previous:   opts.Previous,
 		Timestamps: opts.Timestamps,
+		TailLines:  opts.TailLines,
 	}
 	logStream, err := openStream(client, namespace, podID, logOptions)
 	return logStream, err
@@ -135,10 +143,16 @@ func openStream(client kubernetes.Interface, namespace, podID string, logOptions
 
 // ConstructLogDetails creates a new log details structure for given parameters.
 func ConstructLogDetails(podID string, rawLogs string, container string, logSelector *logs.Selection) *logs.LogDetails {
+	return constructLogDetails(podID, rawLogs, container, logSelector, lineReadLimit)
+}
+
+func constructLogDetails(podID string, rawLogs string, container string, logSelector *logs.Selection,
+	lineLimit int64) *logs.LogDetails {
 	parsedLines := logs.ToLogLines(rawLogs)
 	logLines, fromDate, toDate, logSelection, lastPage := parsedLines.SelectLogs(logSelector)
 
-	readLimitReached := isReadLimitReached(int64(len(rawLogs)), int64(len(parsedLines)), logSelector.LogFilePosition)
+	readLimitReached := isReadLimitReached(int64(len(rawLogs)), int64(len(parsedLines)), logSelector.LogFilePosition,
+		lineLimit)
 	truncated := readLimitReached && lastPage
 
 	info := logs.LogInfo{
@@ -156,7 +170,7 @@ func ConstructLogDetails(podID string, rawLogs string, container string, logSele
 }
 
 // Checks if the amount of log file returned from the apiserver is equal to the read limits
-func isReadLimitReached(bytesLoaded int64, linesLoaded int64, logFilePosition string) bool {
+func isReadLimitReached(bytesLoaded int64, linesLoaded int64, logFilePosition string, lineLimit int64) bool {
 	return (logFilePosition == logs.Beginning && bytesLoaded >= byteReadLimit) ||
-		(logFilePosition == logs.End && linesLoaded >= lineReadLimit)
+		(logFilePosition == logs.End && linesLoaded >= lineLimit)
 }
@@ -417,7 +417,7 @@ func TestMapToLogOptions(t *testing.T) {
 		},
 	}
 	for _, c := range cases {
-		actual := mapToLogOptions(c.container, c.logSelector, false)
+		actual := mapToLogOptions(c.container, c.logSelector, false, nil)
 		if !reflect.DeepEqual(actual, c.expected) {
 			t.Errorf("Test Case: %s.\nReceived: %#v \nExpected: %#v\n\n", c.info, actual, c.expected)
 		}
@@ -0,0 +1,167 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+// Probe types reported in the probe summary.
+const (
+	ProbeTypeExec      = "exec"
+	ProbeTypeHTTPGet   = "httpGet"
+	ProbeTypeTCPSocket = "tcpSocket"
+	ProbeTypeGRPC      = "grpc"
+)
+
+// Default values of the probe settings applied by the apiserver when they are not set.
+const (
+	defaultProbeTimeoutSeconds   = 1
+	defaultProbePeriodSeconds    = 10
+	defaultProbeSuccessThreshold = 1
+	defaultProbeFailureThreshold = 3
+)
+
+// ProbeSummary contains probes configured for the container. Missing probe is not configured.
+type ProbeSummary struct {
+	Container string `json:"container"`
+	Liveness  *Probe `json:"liveness,omitempty"`
+	Readiness *Probe `json:"readiness,omitempty"`
+	Startup   *Probe `json:"startup,omitempty"`
+}
+
+// Probe describes the check done by the probe and its timing.
+type Probe struct {
+	// Type is one of exec, httpGet, tcpSocket or grpc.
+	Type    string   `json:"type"`
+	Command []string `json:"command,omitempty"`
+	Scheme  string   `json:"scheme,omitempty"`
+	Host    string   `json:"host,omitempty"`
+	Path    string   `json:"path,omitempty"`
+	// Port as specified by the probe, which can be a number or a name of the container port.
+	Port string `json:"port,omitempty"`
+	// ResolvedPort is the number of the port, set when named port could be resolved from the container ports.
+	ResolvedPort int32 `json:"resolvedPort,omitempty"`
+	// Service is the name of the gRPC health service.
+	Service             string `json:"service,omitempty"`
+	InitialDelaySeconds int32  `json:"initialDelaySeconds"`
+	TimeoutSeconds      int32  `json:"timeoutSeconds"`
+	PeriodSeconds       int32  `json:"periodSeconds"`
+	SuccessThreshold    int32  `json:"successThreshold"`
+	FailureThreshold    int32  `json:"failureThreshold"`
+}
+
+// GetContainerProbes returns probes of the container of the pod. Default container of the pod is used when container
+// name is empty.
+func GetContainerProbes(client kubernetes.Interface, namespace, podName, containerName string) (*ProbeSummary,
+	error) {
+	pod, err := client.CoreV1().Pods(namespace).Get(context.TODO(), podName, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(containerName) == 0 {
+		containerName = DefaultContainerName(pod)
+	}
+
+	containers := append(append([]v1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, container := range containers {
+		if container.Name == containerName {
+			return &ProbeSummary{
+				Container: container.Name,
+				Liveness:  toProbe(container.LivenessProbe, container.Ports),
+				Readiness: toProbe(container.ReadinessProbe, container.Ports),
+				Startup:   toProbe(container.StartupProbe, container.Ports),
+			}, nil
+		}
+	}
+
+	return nil, errors.NewNotFound(fmt.Sprintf("container %s not found in pod %s", containerName, podName))
+}
+
+func toProbe(probe *v1.Probe, ports []v1.ContainerPort) *Probe {
+	if probe == nil {
+		return nil
+	}
+
+	result := &Probe{
+		InitialDelaySeconds: probe.InitialDelaySeconds,
+		TimeoutSeconds:      valueOrDefault(probe.TimeoutSeconds, defaultProbeTimeoutSeconds),
+		PeriodSeconds:       valueOrDefault(probe.PeriodSeconds, defaultProbePeriodSeconds),
+		SuccessThreshold:    valueOrDefault(probe.SuccessThreshold, defaultProbeSuccessThreshold),
+		FailureThreshold:    valueOrDefault(probe.FailureThreshold, defaultProbeFailureThreshold),
+	}
+
+	handler := probe.ProbeHandler
+	switch {
+	case handler.Exec != nil:
+		result.Type = ProbeTypeExec
+		result.Command = handler.Exec.Command
+	case handler.HTTPGet != nil:
+		result.Type = ProbeTypeHTTPGet
+		result.Scheme = string(handler.HTTPGet.Scheme)
+		if len(result.Scheme) == 0 {
+			result.Scheme = string(v1.URISchemeHTTP)
+		}
+		result.Host = handler.HTTPGet.Host
+		result.Path = handler.HTTPGet.Path
+		result.Port, result.ResolvedPort = resolvePort(handler.HTTPGet.Port, ports)
+	case handler.TCPSocket != nil:
+		result.Type = ProbeTypeTCPSocket
+		result.Host = handler.TCPSocket.Host
+		result.Port, result.ResolvedPort = resolvePort(handler.TCPSocket.Port, ports)
+	case handler.GRPC != nil:
+		result.Type = ProbeTypeGRPC
+		result.Port, result.ResolvedPort = strconv.Itoa(int(handler.GRPC.Port)), handler.GRPC.Port
+		if handler.GRPC.Service != nil {
+			result.Service = *handler.GRPC.Service
+		}
+	}
+
+	return result
+}
+
+// Returns port as specified by the probe and its number. Named port is looked up in the container ports, number is
+// zero if it is not found.
+func resolvePort(port intstr.IntOrString, ports []v1.ContainerPort) (string, int32) {
+	if port.Type == intstr.Int {
+		return port.String(), port.IntVal
+	}
+
+	for _, p := range ports {
+		if p.Name == port.StrVal {
+			return port.StrVal, p.ContainerPort
+		}
+	}
+
+	return port.StrVal, 0
+}
+
+func valueOrDefault(value, def int32) int32 {
+	if value == 0 {
+		return def
+	}
+
+	return value
+}
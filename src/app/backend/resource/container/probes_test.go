@@ -0,0 +1,119 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+func TestGetContainerProbes(t *testing.T) {
+	service := "health"
+	pod := &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{
+				Name: "migrate",
+				StartupProbe: &v1.Probe{ProbeHandler: v1.ProbeHandler{
+					Exec: &v1.ExecAction{Command: []string{"cat", "/tmp/ready"}}}},
+			}},
+			Containers: []v1.Container{
+				{
+					Name:  "app",
+					Ports: []v1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+					LivenessProbe: &v1.Probe{
+						ProbeHandler: v1.ProbeHandler{HTTPGet: &v1.HTTPGetAction{
+							Path: "/healthz", Port: intstr.FromString("http")}},
+						InitialDelaySeconds: 5,
+						PeriodSeconds:       20,
+					},
+					ReadinessProbe: &v1.Probe{
+						ProbeHandler:     v1.ProbeHandler{TCPSocket: &v1.TCPSocketAction{Port: intstr.FromInt(5432)}},
+						TimeoutSeconds:   2,
+						SuccessThreshold: 2,
+						FailureThreshold: 5,
+					},
+					StartupProbe: &v1.Probe{
+						ProbeHandler: v1.ProbeHandler{HTTPGet: &v1.HTTPGetAction{
+							Scheme: v1.URISchemeHTTPS, Path: "/started", Port: intstr.FromString("metrics")}},
+					},
+				},
+				{
+					Name: "sidecar",
+					LivenessProbe: &v1.Probe{ProbeHandler: v1.ProbeHandler{
+						GRPC: &v1.GRPCAction{Port: 9090, Service: &service}}},
+				},
+			},
+		},
+	}
+	client := fake.NewSimpleClientset(pod)
+
+	cases := []struct {
+		container string
+		expected  *ProbeSummary
+	}{
+		{
+			"",
+			&ProbeSummary{
+				Container: "app",
+				Liveness: &Probe{Type: ProbeTypeHTTPGet, Scheme: "HTTP", Path: "/healthz", Port: "http",
+					ResolvedPort: 8080, InitialDelaySeconds: 5, TimeoutSeconds: 1, PeriodSeconds: 20,
+					SuccessThreshold: 1, FailureThreshold: 3},
+				Readiness: &Probe{Type: ProbeTypeTCPSocket, Port: "5432", ResolvedPort: 5432, TimeoutSeconds: 2,
+					PeriodSeconds: 10, SuccessThreshold: 2, FailureThreshold: 5},
+				Startup: &Probe{Type: ProbeTypeHTTPGet, Scheme: "HTTPS", Path: "/started", Port: "metrics",
+					TimeoutSeconds: 1, PeriodSeconds: 10, SuccessThreshold: 1, FailureThreshold: 3},
+			},
+		},
+		{
+			"sidecar",
+			&ProbeSummary{
+				Container: "sidecar",
+				Liveness: &Probe{Type: ProbeTypeGRPC, Port: "9090", ResolvedPort: 9090, Service: "health",
+					TimeoutSeconds: 1, PeriodSeconds: 10, SuccessThreshold: 1, FailureThreshold: 3},
+			},
+		},
+		{
+			"migrate",
+			&ProbeSummary{
+				Container: "migrate",
+				Startup: &Probe{Type: ProbeTypeExec, Command: []string{"cat", "/tmp/ready"}, TimeoutSeconds: 1,
+					PeriodSeconds: 10, SuccessThreshold: 1, FailureThreshold: 3},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := GetContainerProbes(client, "default", "web", c.container)
+		if err != nil {
+			t.Fatalf("GetContainerProbes(%q) returned error: %v", c.container, err)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("GetContainerProbes(%q) == %+v, expected %+v", c.container, actual, c.expected)
+		}
+	}
+
+	if _, err := GetContainerProbes(client, "default", "web", "missing"); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected not found error for missing container, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ContainerRestart describes how many times the container was restarted and why it was terminated last time.
+type ContainerRestart struct {
+	Name string `json:"name"`
+	// InitContainer is true for init containers, which are restarted only until they complete successfully.
+	InitContainer   bool                  `json:"initContainer"`
+	RestartCount    int32                 `json:"restartCount"`
+	LastTermination *ContainerTermination `json:"lastTermination,omitempty"`
+}
+
+// ContainerTermination is the last termination state of the container, i.e. OOMKilled or Error.
+type ContainerTermination struct {
+	Reason     string      `json:"reason"`
+	Message    string      `json:"message,omitempty"`
+	ExitCode   int32       `json:"exitCode"`
+	Signal     int32       `json:"signal,omitempty"`
+	FinishedAt metaV1.Time `json:"finishedAt"`
+}
+
+// GetContainerRestartInfo returns restart counts and last termination states of init and regular containers of
+// the pod. Init containers are listed first.
+func GetContainerRestartInfo(client kubernetes.Interface, namespace, podName string) ([]ContainerRestart, error) {
+	pod, err := client.CoreV1().Pods(namespace).Get(context.TODO(), podName, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return ToContainerRestarts(pod), nil
+}
+
+// ToContainerRestarts extracts restart info from the container statuses of the pod.
+func ToContainerRestarts(pod *v1.Pod) []ContainerRestart {
+	result := make([]ContainerRestart, 0, len(pod.Status.InitContainerStatuses)+len(pod.Status.ContainerStatuses))
+	for _, status := range pod.Status.InitContainerStatuses {
+		result = append(result, toContainerRestart(status, true))
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		result = append(result, toContainerRestart(status, false))
+	}
+
+	return result
+}
+
+func toContainerRestart(status v1.ContainerStatus, initContainer bool) ContainerRestart {
+	restart := ContainerRestart{
+		Name:          status.Name,
+		InitContainer: initContainer,
+		RestartCount:  status.RestartCount,
+	}
+
+	if terminated := status.LastTerminationState.Terminated; terminated != nil {
+		restart.LastTermination = &ContainerTermination{
+			Reason:     terminated.Reason,
+			Message:    terminated.Message,
+			ExitCode:   terminated.ExitCode,
+			Signal:     terminated.Signal,
+			FinishedAt: terminated.FinishedAt,
+		}
+	}
+
+	return restart
+}
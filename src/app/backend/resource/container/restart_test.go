@@ -0,0 +1,71 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetContainerRestartInfo(t *testing.T) {
+	finishedAt := metaV1.NewTime(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC))
+	pod := &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+		Status: v1.PodStatus{
+			InitContainerStatuses: []v1.ContainerStatus{{
+				Name:         "migrate",
+				RestartCount: 2,
+				LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+					Reason: "Error", Message: "connection refused", ExitCode: 1, FinishedAt: finishedAt}},
+			}},
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:         "app",
+					RestartCount: 5,
+					LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+						Reason: "OOMKilled", ExitCode: 137, Signal: 9, FinishedAt: finishedAt}},
+				},
+				{Name: "sidecar"},
+			},
+		},
+	}
+
+	actual, err := GetContainerRestartInfo(fake.NewSimpleClientset(pod), "default", "web")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []ContainerRestart{
+		{Name: "migrate", InitContainer: true, RestartCount: 2, LastTermination: &ContainerTermination{
+			Reason: "Error", Message: "connection refused", ExitCode: 1, FinishedAt: finishedAt}},
+		{Name: "app", RestartCount: 5, LastTermination: &ContainerTermination{
+			Reason: "OOMKilled", ExitCode: 137, Signal: 9, FinishedAt: finishedAt}},
+		{Name: "sidecar"},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetContainerRestartInfo() == %+v, expected %+v", actual, expected)
+	}
+}
+
+func TestGetContainerRestartInfoPodNotFound(t *testing.T) {
+	if _, err := GetContainerRestartInfo(fake.NewSimpleClientset(), "default", "web"); err == nil {
+		t.Error("Expected error for missing pod")
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// GroupedEventsOptions contains options of the grouped events list.
+type GroupedEventsOptions struct {
+	// WarningsOnly drops groups of Normal events.
+	WarningsOnly bool `json:"warningsOnly"`
+}
+
+// EventGroup is a set of events with the same type, reason, involved object and message.
+type EventGroup struct {
+	// Type is either Normal or Warning.
+	Type           string             `json:"type"`
+	Reason         string             `json:"reason"`
+	Message        string             `json:"message"`
+	InvolvedObject v1.ObjectReference `json:"involvedObject"`
+	// Count is the number of occurrences of all the events of the group.
+	Count          int32       `json:"count"`
+	FirstTimestamp metaV1.Time `json:"firstTimestamp"`
+	LastTimestamp  metaV1.Time `json:"lastTimestamp"`
+}
+
+// GetGroupedEvents lists events in the namespace and groups duplicates. Events without type are classified by their
+// reason, see FillEventsType. Warning groups are returned first, groups of the same type are sorted from the most
+// recent one.
+func GetGroupedEvents(client kubernetes.Interface, namespace string, opts GroupedEventsOptions) ([]EventGroup,
+	error) {
+	list, err := client.CoreV1().Events(namespace).List(context.TODO(), metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return groupEvents(FillEventsType(list.Items), opts), nil
+}
+
+type eventGroupKey struct {
+	eventType, reason, message string
+	kind, namespace, name      string
+	uid                        string
+}
+
+func groupEvents(events []v1.Event, opts GroupedEventsOptions) []EventGroup {
+	groups := make([]EventGroup, 0)
+	indexes := make(map[eventGroupKey]int)
+	for _, event := range events {
+		if opts.WarningsOnly && event.Type != v1.EventTypeWarning {
+			continue
+		}
+
+		first, last, count := eventOccurrences(event)
+		key := eventGroupKey{event.Type, event.Reason, event.Message, event.InvolvedObject.Kind,
+			event.InvolvedObject.Namespace, event.InvolvedObject.Name, string(event.InvolvedObject.UID)}
+		index, ok := indexes[key]
+		if !ok {
+			indexes[key] = len(groups)
+			groups = append(groups, EventGroup{
+				Type:           event.Type,
+				Reason:         event.Reason,
+				Message:        event.Message,
+				InvolvedObject: event.InvolvedObject,
+				Count:          count,
+				FirstTimestamp: first,
+				LastTimestamp:  last,
+			})
+			continue
+		}
+
+		group := &groups[index]
+		group.Count += count
+		if first.Before(&group.FirstTimestamp) {
+			group.FirstTimestamp = first
+		}
+		if group.LastTimestamp.Before(&last) {
+			group.LastTimestamp = last
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Type != groups[j].Type {
+			return groups[i].Type == v1.EventTypeWarning
+		}
+		return groups[j].LastTimestamp.Before(&groups[i].LastTimestamp)
+	})
+	return groups
+}
+
+// Returns first and last occurrence of the event and the number of occurrences. Events created with
+// events.k8s.io API have only event time and optionally a series set.
+func eventOccurrences(event v1.Event) (metaV1.Time, metaV1.Time, int32) {
+	first, last, count := event.FirstTimestamp, event.LastTimestamp, event.Count
+	if first.IsZero() {
+		first = metaV1.NewTime(event.EventTime.Time)
+	}
+	if first.IsZero() {
+		first = event.CreationTimestamp
+	}
+
+	if event.Series != nil {
+		if count < event.Series.Count {
+			count = event.Series.Count
+		}
+		if last.Time.Before(event.Series.LastObservedTime.Time) {
+			last = metaV1.NewTime(event.Series.LastObservedTime.Time)
+		}
+	}
+	if last.IsZero() {
+		last = first
+	}
+
+	if count < 1 {
+		count = 1
+	}
+
+	return first, last, count
+}
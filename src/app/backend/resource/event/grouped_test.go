@@ -0,0 +1,85 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetGroupedEvents(t *testing.T) {
+	base := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) metaV1.Time { return metaV1.NewTime(base.Add(time.Duration(minutes) * time.Minute)) }
+	pod := v1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "web", UID: "uid-1"}
+	newEvent := func(name, eventType, reason, message string, first, last int, count int32) *v1.Event {
+		return &v1.Event{
+			ObjectMeta:     metaV1.ObjectMeta{Name: name, Namespace: "default"},
+			InvolvedObject: pod,
+			Type:           eventType,
+			Reason:         reason,
+			Message:        message,
+			FirstTimestamp: at(first),
+			LastTimestamp:  at(last),
+			Count:          count,
+		}
+	}
+
+	series := newEvent("backoff-series", v1.EventTypeWarning, "BackOff", "Back-off restarting", 0, 0, 0)
+	series.FirstTimestamp, series.LastTimestamp = metaV1.Time{}, metaV1.Time{}
+	series.EventTime = metaV1.NewMicroTime(at(1).Time)
+	series.Series = &v1.EventSeries{Count: 4, LastObservedTime: metaV1.NewMicroTime(at(30).Time)}
+
+	client := fake.NewSimpleClientset(
+		newEvent("pulled-1", v1.EventTypeNormal, "Pulled", "Image pulled", 0, 0, 1),
+		newEvent("pulled-2", v1.EventTypeNormal, "Pulled", "Image pulled", 5, 10, 2),
+		newEvent("backoff-1", v1.EventTypeWarning, "BackOff", "Back-off restarting", 2, 20, 3),
+		series,
+		newEvent("untyped", "", "FailedMount", "Unable to mount", 3, 3, 1),
+		newEvent("other-message", v1.EventTypeNormal, "Pulled", "Another image pulled", 15, 15, 1),
+	)
+
+	groups, err := GetGroupedEvents(client, "default", GroupedEventsOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []EventGroup{
+		{Type: v1.EventTypeWarning, Reason: "BackOff", Message: "Back-off restarting", InvolvedObject: pod, Count: 7,
+			FirstTimestamp: at(1), LastTimestamp: at(30)},
+		{Type: v1.EventTypeWarning, Reason: "FailedMount", Message: "Unable to mount", InvolvedObject: pod,
+			Count: 1, FirstTimestamp: at(3), LastTimestamp: at(3)},
+		{Type: v1.EventTypeNormal, Reason: "Pulled", Message: "Another image pulled", InvolvedObject: pod,
+			Count: 1, FirstTimestamp: at(15), LastTimestamp: at(15)},
+		{Type: v1.EventTypeNormal, Reason: "Pulled", Message: "Image pulled", InvolvedObject: pod, Count: 3,
+			FirstTimestamp: at(0), LastTimestamp: at(10)},
+	}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, groups)
+	}
+
+	warnings, err := GetGroupedEvents(client, "default", GroupedEventsOptions{WarningsOnly: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(warnings, expected[:2]) {
+		t.Errorf("Expected only warnings %+v, got %+v", expected[:2], warnings)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// GetInvolvedObjectEvents returns events of the object with given kind, i.e. 'Deployment', and name. Events are listed
+// from both core/v1 and events.k8s.io/v1 APIs and merged. Events served by both APIs are returned only once.
+func GetInvolvedObjectEvents(client kubernetes.Interface, namespace, kind, name string) (*v1.EventList, error) {
+	coreEvents, err := client.CoreV1().Events(namespace).List(context.TODO(), metaV1.ListOptions{
+		FieldSelector: InvolvedObjectFieldSelector("involvedObject", namespace, kind, name),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &v1.EventList{Items: coreEvents.Items}
+	seen := make(map[types.UID]bool, len(coreEvents.Items))
+	for _, event := range coreEvents.Items {
+		seen[event.UID] = true
+	}
+
+	newEvents, err := client.EventsV1().Events(namespace).List(context.TODO(), metaV1.ListOptions{
+		FieldSelector: InvolvedObjectFieldSelector("regarding", namespace, kind, name),
+	})
+	if err != nil {
+		// events.k8s.io API might be disabled, core events are enough in such case.
+		return result, nil
+	}
+
+	for _, event := range newEvents.Items {
+		if len(event.UID) > 0 && seen[event.UID] {
+			continue
+		}
+
+		result.Items = append(result.Items, FromEventsV1(event))
+	}
+
+	return result, nil
+}
+
+// InvolvedObjectFieldSelector returns field selector matching events of the given object. Prefix is the name of the
+// field referencing the object, 'involvedObject' for core/v1 and 'regarding' for events.k8s.io/v1 events.
+func InvolvedObjectFieldSelector(prefix, namespace, kind, name string) string {
+	selectors := []fields.Selector{
+		fields.OneTermEqualSelector(prefix+".kind", kind),
+		fields.OneTermEqualSelector(prefix+".name", name),
+	}
+
+	if len(namespace) > 0 {
+		selectors = append(selectors, fields.OneTermEqualSelector(prefix+".namespace", namespace))
+	}
+
+	return fields.AndSelectors(selectors...).String()
+}
+
+// FromEventsV1 converts events.k8s.io/v1 event to core/v1 event.
+func FromEventsV1(event eventsv1.Event) v1.Event {
+	result := v1.Event{
+		TypeMeta:            metaV1.TypeMeta{Kind: "Event", APIVersion: "v1"},
+		ObjectMeta:          event.ObjectMeta,
+		InvolvedObject:      event.Regarding,
+		Reason:              event.Reason,
+		Message:             event.Note,
+		Type:                event.Type,
+		Action:              event.Action,
+		Related:             event.Related,
+		EventTime:           event.EventTime,
+		FirstTimestamp:      event.DeprecatedFirstTimestamp,
+		LastTimestamp:       event.DeprecatedLastTimestamp,
+		Count:               event.DeprecatedCount,
+		ReportingController: event.ReportingController,
+		ReportingInstance:   event.ReportingInstance,
+		Source: v1.EventSource{
+			Component: event.DeprecatedSource.Component,
+			Host:      event.DeprecatedSource.Host,
+		},
+	}
+
+	if event.Series != nil {
+		result.Series = &v1.EventSeries{Count: event.Series.Count, LastObservedTime: event.Series.LastObservedTime}
+		result.Count = event.Series.Count
+	}
+
+	return result
+}
@@ -0,0 +1,85 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientTesting "k8s.io/client-go/testing"
+)
+
+func TestInvolvedObjectFieldSelector(t *testing.T) {
+	cases := []struct {
+		prefix, namespace, kind, name string
+		expected                      string
+	}{
+		{"involvedObject", "ns-1", "Deployment", "dep-1",
+			"involvedObject.kind=Deployment,involvedObject.name=dep-1,involvedObject.namespace=ns-1"},
+		{"regarding", "ns-1", "Pod", "pod-1", "regarding.kind=Pod,regarding.name=pod-1,regarding.namespace=ns-1"},
+		{"involvedObject", "", "Node", "node-1", "involvedObject.kind=Node,involvedObject.name=node-1"},
+	}
+
+	for _, c := range cases {
+		actual := InvolvedObjectFieldSelector(c.prefix, c.namespace, c.kind, c.name)
+		if actual != c.expected {
+			t.Errorf("InvolvedObjectFieldSelector(%s, %s, %s, %s) == %s, expected %s", c.prefix, c.namespace,
+				c.kind, c.name, actual, c.expected)
+		}
+	}
+}
+
+func TestGetInvolvedObjectEvents(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&v1.Event{ObjectMeta: metaV1.ObjectMeta{Name: "ev-1", Namespace: "ns-1", UID: "uid-1"}, Message: "core"})
+	// Events API serves the same events as core API, make sure they are not duplicated.
+	client.PrependReactor("list", "events", func(action clientTesting.Action) (bool, runtime.Object, error) {
+		if action.GetResource().Group != "events.k8s.io" {
+			return false, nil, nil
+		}
+
+		return true, &eventsv1.EventList{Items: []eventsv1.Event{
+			{ObjectMeta: metaV1.ObjectMeta{Name: "ev-1", Namespace: "ns-1", UID: "uid-1"}, Note: "core"},
+			{ObjectMeta: metaV1.ObjectMeta{Name: "ev-2", Namespace: "ns-1", UID: "uid-2"}, Note: "new",
+				Regarding: v1.ObjectReference{Kind: "Pod", Name: "pod-1"}},
+		}}, nil
+	})
+
+	actual, err := GetInvolvedObjectEvents(client, "ns-1", "Pod", "pod-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(actual.Items) != 2 || actual.Items[0].Message != "core" || actual.Items[1].Message != "new" ||
+		actual.Items[1].InvolvedObject.Name != "pod-1" {
+		t.Fatalf("Expected core and converted events to be merged, but got %#v", actual.Items)
+	}
+
+	selectors := map[string]string{}
+	for _, action := range client.Actions() {
+		if list, ok := action.(clientTesting.ListAction); ok {
+			selectors[list.GetResource().Group] = list.GetListRestrictions().Fields.String()
+		}
+	}
+
+	if selectors[""] != "involvedObject.kind=Pod,involvedObject.name=pod-1,involvedObject.namespace=ns-1" ||
+		selectors["events.k8s.io"] != "regarding.kind=Pod,regarding.name=pod-1,regarding.namespace=ns-1" {
+		t.Fatalf("Unexpected field selectors: %v", selectors)
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package horizontalpodautoscaler
+
+import (
+	"context"
+	"fmt"
+
+	autoscaling "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+)
+
+// HPASummary describes horizontal pod autoscalers targeting a single workload.
+type HPASummary struct {
+	Autoscalers []WorkloadAutoscaler `json:"autoscalers"`
+	// Ambiguous is set when more than one autoscaler targets the workload. Controller refuses to scale such
+	// workloads, so the UI should warn about it.
+	Ambiguous bool `json:"ambiguous"`
+}
+
+// WorkloadAutoscaler summarizes replicas and metrics of the horizontal pod autoscaler.
+type WorkloadAutoscaler struct {
+	Name            string         `json:"name"`
+	MinReplicas     *int32         `json:"minReplicas,omitempty"`
+	MaxReplicas     int32          `json:"maxReplicas"`
+	CurrentReplicas int32          `json:"currentReplicas"`
+	DesiredReplicas int32          `json:"desiredReplicas"`
+	LastScaleTime   *v1.Time       `json:"lastScaleTime,omitempty"`
+	Metrics         []MetricStatus `json:"metrics"`
+}
+
+// MetricStatus describes target and current value of a single metric of the autoscaler, i.e. 80% and 65% of cpu.
+type MetricStatus struct {
+	// Type is one of Resource, ContainerResource, Pods, Object or External.
+	Type string `json:"type"`
+	Name string `json:"name"`
+	// Target and Current are formatted values, i.e. '80%' or '500m'. Current is empty until the metric is read.
+	Target  string `json:"target"`
+	Current string `json:"current,omitempty"`
+}
+
+// GetWorkloadHPA returns autoscalers in the namespace whose scale target reference matches given workload kind
+// and name. Autoscalers are read from autoscaling/v2, which exposes all metrics. Clusters that do not serve it
+// yet fall back to autoscaling/v1, which only supports the CPU utilization target.
+func GetWorkloadHPA(client client.Interface, namespace, targetKind, targetName string) (*HPASummary, error) {
+	autoscalers, err := getWorkloadHPAv2(client, namespace, targetKind, targetName)
+	if errors.IsNotFound(err) {
+		autoscalers, err = getWorkloadHPAv1(client, namespace, targetKind, targetName)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &HPASummary{Autoscalers: autoscalers, Ambiguous: len(autoscalers) > 1}, nil
+}
+
+func getWorkloadHPAv2(client client.Interface, namespace, targetKind, targetName string) ([]WorkloadAutoscaler,
+	error) {
+	list, err := client.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(context.TODO(), v1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]WorkloadAutoscaler, 0)
+	for _, hpa := range list.Items {
+		ref := hpa.Spec.ScaleTargetRef
+		if ref.Kind != targetKind || ref.Name != targetName {
+			continue
+		}
+
+		result = append(result, WorkloadAutoscaler{
+			Name:            hpa.Name,
+			MinReplicas:     hpa.Spec.MinReplicas,
+			MaxReplicas:     hpa.Spec.MaxReplicas,
+			CurrentReplicas: hpa.Status.CurrentReplicas,
+			DesiredReplicas: hpa.Status.DesiredReplicas,
+			LastScaleTime:   hpa.Status.LastScaleTime,
+			Metrics:         toMetricStatuses(hpa.Spec.Metrics, hpa.Status.CurrentMetrics),
+		})
+	}
+
+	return result, nil
+}
+
+func getWorkloadHPAv1(client client.Interface, namespace, targetKind, targetName string) ([]WorkloadAutoscaler,
+	error) {
+	list, err := client.AutoscalingV1().HorizontalPodAutoscalers(namespace).List(context.TODO(), v1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]WorkloadAutoscaler, 0)
+	for _, hpa := range list.Items {
+		ref := hpa.Spec.ScaleTargetRef
+		if ref.Kind != targetKind || ref.Name != targetName {
+			continue
+		}
+
+		result = append(result, WorkloadAutoscaler{
+			Name:            hpa.Name,
+			MinReplicas:     hpa.Spec.MinReplicas,
+			MaxReplicas:     hpa.Spec.MaxReplicas,
+			CurrentReplicas: hpa.Status.CurrentReplicas,
+			DesiredReplicas: hpa.Status.DesiredReplicas,
+			LastScaleTime:   hpa.Status.LastScaleTime,
+			Metrics:         toCPUMetricStatuses(hpa),
+		})
+	}
+
+	return result, nil
+}
+
+func toCPUMetricStatuses(hpa autoscaling.HorizontalPodAutoscaler) []MetricStatus {
+	if hpa.Spec.TargetCPUUtilizationPercentage == nil {
+		return []MetricStatus{}
+	}
+
+	metric := MetricStatus{
+		Type:   string(autoscalingv2.ResourceMetricSourceType),
+		Name:   "cpu",
+		Target: fmt.Sprintf("%d%%", *hpa.Spec.TargetCPUUtilizationPercentage),
+	}
+	if hpa.Status.CurrentCPUUtilizationPercentage != nil {
+		metric.Current = fmt.Sprintf("%d%%", *hpa.Status.CurrentCPUUtilizationPercentage)
+	}
+
+	return []MetricStatus{metric}
+}
+
+// Pairs metric specs with their current values by type and name of the metric.
+func toMetricStatuses(specs []autoscalingv2.MetricSpec, statuses []autoscalingv2.MetricStatus) []MetricStatus {
+	current := make(map[string]string, len(statuses))
+	for _, status := range statuses {
+		name, value := metricStatusValue(status)
+		current[string(status.Type)+"/"+name] = value
+	}
+
+	result := make([]MetricStatus, 0, len(specs))
+	for _, spec := range specs {
+		name, target := metricSpecTarget(spec)
+		result = append(result, MetricStatus{
+			Type:    string(spec.Type),
+			Name:    name,
+			Target:  target,
+			Current: current[string(spec.Type)+"/"+name],
+		})
+	}
+
+	return result
+}
+
+func metricSpecTarget(spec autoscalingv2.MetricSpec) (string, string) {
+	switch spec.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if spec.Resource != nil {
+			return string(spec.Resource.Name), formatMetricTarget(spec.Resource.Target)
+		}
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		if spec.ContainerResource != nil {
+			return spec.ContainerResource.Container + "/" + string(spec.ContainerResource.Name),
+				formatMetricTarget(spec.ContainerResource.Target)
+		}
+	case autoscalingv2.PodsMetricSourceType:
+		if spec.Pods != nil {
+			return spec.Pods.Metric.Name, formatMetricTarget(spec.Pods.Target)
+		}
+	case autoscalingv2.ObjectMetricSourceType:
+		if spec.Object != nil {
+			return spec.Object.Metric.Name, formatMetricTarget(spec.Object.Target)
+		}
+	case autoscalingv2.ExternalMetricSourceType:
+		if spec.External != nil {
+			return spec.External.Metric.Name, formatMetricTarget(spec.External.Target)
+		}
+	}
+
+	return "", ""
+}
+
+func metricStatusValue(status autoscalingv2.MetricStatus) (string, string) {
+	switch status.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if status.Resource != nil {
+			return string(status.Resource.Name), formatMetricValue(status.Resource.Current)
+		}
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		if status.ContainerResource != nil {
+			return status.ContainerResource.Container + "/" + string(status.ContainerResource.Name),
+				formatMetricValue(status.ContainerResource.Current)
+		}
+	case autoscalingv2.PodsMetricSourceType:
+		if status.Pods != nil {
+			return status.Pods.Metric.Name, formatMetricValue(status.Pods.Current)
+		}
+	case autoscalingv2.ObjectMetricSourceType:
+		if status.Object != nil {
+			return status.Object.Metric.Name, formatMetricValue(status.Object.Current)
+		}
+	case autoscalingv2.ExternalMetricSourceType:
+		if status.External != nil {
+			return status.External.Metric.Name, formatMetricValue(status.External.Current)
+		}
+	}
+
+	return "", ""
+}
+
+func formatMetricTarget(target autoscalingv2.MetricTarget) string {
+	switch {
+	case target.Type == autoscalingv2.UtilizationMetricType && target.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *target.AverageUtilization)
+	case target.Type == autoscalingv2.AverageValueMetricType && target.AverageValue != nil:
+		return target.AverageValue.String()
+	case target.Value != nil:
+		return target.Value.String()
+	}
+	return ""
+}
+
+func formatMetricValue(value autoscalingv2.MetricValueStatus) string {
+	switch {
+	case value.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *value.AverageUtilization)
+	case value.AverageValue != nil:
+		return value.AverageValue.String()
+	case value.Value != nil:
+		return value.Value.String()
+	}
+	return ""
+}
@@ -0,0 +1,147 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package horizontalpodautoscaler
+
+import (
+	"reflect"
+	"testing"
+
+	autoscaling "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func int32Ptr(value int32) *int32 {
+	return &value
+}
+
+func TestGetWorkloadHPAv2(t *testing.T) {
+	averageValue := resource.MustParse("500m")
+	newHPA := func(name, kind, target string) *autoscalingv2.HorizontalPodAutoscaler {
+		return &autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: kind, Name: target},
+				MinReplicas:    int32Ptr(2),
+				MaxReplicas:    10,
+				Metrics: []autoscalingv2.MetricSpec{
+					{Type: autoscalingv2.ResourceMetricSourceType, Resource: &autoscalingv2.ResourceMetricSource{
+						Name:   v1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: int32Ptr(80)},
+					}},
+					{Type: autoscalingv2.PodsMetricSourceType, Pods: &autoscalingv2.PodsMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{Name: "requests_per_second"},
+						Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: &averageValue},
+					}},
+				},
+			},
+			Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+				CurrentReplicas: 3,
+				DesiredReplicas: 4,
+				CurrentMetrics: []autoscalingv2.MetricStatus{
+					{Type: autoscalingv2.ResourceMetricSourceType, Resource: &autoscalingv2.ResourceMetricStatus{
+						Name:    v1.ResourceCPU,
+						Current: autoscalingv2.MetricValueStatus{AverageUtilization: int32Ptr(95)},
+					}},
+				},
+			},
+		}
+	}
+
+	client := fake.NewSimpleClientset(
+		newHPA("web", "Deployment", "web"),
+		newHPA("web-extra", "Deployment", "web"),
+		newHPA("other", "Deployment", "other"),
+		newHPA("web-sts", "StatefulSet", "web"),
+	)
+
+	summary, err := GetWorkloadHPA(client, "default", "Deployment", "web")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(summary.Autoscalers) != 2 || !summary.Ambiguous {
+		t.Fatalf("Expected two ambiguous autoscalers, got %+v", summary)
+	}
+
+	autoscaler := summary.Autoscalers[0]
+	if autoscaler.Name != "web" || *autoscaler.MinReplicas != 2 || autoscaler.MaxReplicas != 10 ||
+		autoscaler.CurrentReplicas != 3 || autoscaler.DesiredReplicas != 4 {
+		t.Errorf("Unexpected autoscaler summary: %+v", autoscaler)
+	}
+
+	expected := []MetricStatus{
+		{Type: "Resource", Name: "cpu", Target: "80%", Current: "95%"},
+		{Type: "Pods", Name: "requests_per_second", Target: "500m"},
+	}
+	if !reflect.DeepEqual(autoscaler.Metrics, expected) {
+		t.Errorf("Expected metrics %+v, got %+v", expected, autoscaler.Metrics)
+	}
+}
+
+func TestGetWorkloadHPAv1(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&autoscaling.HorizontalPodAutoscaler{
+			ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: autoscaling.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef:                 autoscaling.CrossVersionObjectReference{Kind: "Deployment", Name: "web"},
+				MaxReplicas:                    5,
+				TargetCPUUtilizationPercentage: int32Ptr(70),
+			},
+			Status: autoscaling.HorizontalPodAutoscalerStatus{
+				CurrentReplicas:                 2,
+				DesiredReplicas:                 2,
+				CurrentCPUUtilizationPercentage: int32Ptr(40),
+			},
+		},
+	)
+	// Simulates cluster that does not serve autoscaling/v2 yet.
+	client.PrependReactor("list", "horizontalpodautoscalers", func(action k8stesting.Action) (bool, runtime.Object,
+		error) {
+		if action.GetResource().Version != "v2" {
+			return false, nil, nil
+		}
+		return true, nil, errors.NewNotFound(schema.GroupResource{Group: "autoscaling",
+			Resource: "horizontalpodautoscalers"}, "")
+	})
+
+	summary, err := GetWorkloadHPA(client, "default", "Deployment", "web")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := &HPASummary{Autoscalers: []WorkloadAutoscaler{{
+		Name:            "web",
+		MaxReplicas:     5,
+		CurrentReplicas: 2,
+		DesiredReplicas: 2,
+		Metrics:         []MetricStatus{{Type: "Resource", Name: "cpu", Target: "70%", Current: "40%"}},
+	}}}
+	if !reflect.DeepEqual(summary, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, summary)
+	}
+
+	summary, err = GetWorkloadHPA(client, "default", "Deployment", "missing")
+	if err != nil || len(summary.Autoscalers) != 0 || summary.Ambiguous {
+		t.Errorf("Expected no autoscalers, got %+v, %v", summary, err)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	coreV1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+)
+
+// IngressRoute maps a host and path of the ingress to its backend.
+type IngressRoute struct {
+	// Host is empty when the rule matches all hosts.
+	Host     string `json:"host,omitempty"`
+	Path     string `json:"path,omitempty"`
+	PathType string `json:"pathType,omitempty"`
+	// Default is set for the default backend, which serves requests that do not match any rule.
+	Default bool `json:"default,omitempty"`
+	// TLS is set when the host is covered by one of the TLS entries of the ingress.
+	TLS           bool         `json:"tls"`
+	TLSSecretName string       `json:"tlsSecretName,omitempty"`
+	Backend       RouteBackend `json:"backend"`
+}
+
+// RouteBackend is the backend of the route. Only service backends are resolved, resource backends are reported as
+// they are.
+type RouteBackend struct {
+	ServiceName string `json:"serviceName,omitempty"`
+	// ServicePort is the name or number of the service port.
+	ServicePort  string `json:"servicePort,omitempty"`
+	ResourceKind string `json:"resourceKind,omitempty"`
+	ResourceName string `json:"resourceName,omitempty"`
+	// ServiceExists and PortExists are false when the ingress references missing service or port.
+	ServiceExists     bool `json:"serviceExists"`
+	PortExists        bool `json:"portExists"`
+	ReadyEndpoints    int  `json:"readyEndpoints"`
+	NotReadyEndpoints int  `json:"notReadyEndpoints"`
+}
+
+// GetIngressRoutes returns routes of the ingress in order of its rules, followed by the default backend, if any.
+// Every backend service is resolved to check that it exists and how many endpoints it has.
+func GetIngressRoutes(client client.Interface, namespace, name string) ([]IngressRoute, error) {
+	ingress, err := client.NetworkingV1().Ingresses(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := &backendResolver{client: client, namespace: namespace, services: make(map[string]*serviceInfo)}
+	routes := make([]IngressRoute, 0)
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+
+		tls, secretName := findIngressTLS(ingress.Spec.TLS, rule.Host)
+		for _, path := range rule.HTTP.Paths {
+			backend, err := resolver.resolve(path.Backend)
+			if err != nil {
+				return nil, err
+			}
+
+			route := IngressRoute{Host: rule.Host, Path: path.Path, TLS: tls, TLSSecretName: secretName,
+				Backend: backend}
+			if path.PathType != nil {
+				route.PathType = string(*path.PathType)
+			}
+			routes = append(routes, route)
+		}
+	}
+
+	if ingress.Spec.DefaultBackend != nil {
+		backend, err := resolver.resolve(*ingress.Spec.DefaultBackend)
+		if err != nil {
+			return nil, err
+		}
+
+		routes = append(routes, IngressRoute{Default: true, Backend: backend})
+	}
+
+	return routes, nil
+}
+
+// Returns whether the host is covered by one of the TLS entries and the name of its secret. Wildcard hosts match
+// exactly one DNS label.
+func findIngressTLS(entries []v1.IngressTLS, host string) (bool, string) {
+	for _, entry := range entries {
+		for _, tlsHost := range entry.Hosts {
+			if matchesTLSHost(tlsHost, host) {
+				return true, entry.SecretName
+			}
+		}
+	}
+
+	return false, ""
+}
+
+func matchesTLSHost(tlsHost, host string) bool {
+	if tlsHost == host {
+		return true
+	}
+
+	i := strings.Index(host, ".")
+	return strings.HasPrefix(tlsHost, "*.") && i > 0 && tlsHost[1:] == host[i:]
+}
+
+type serviceInfo struct {
+	service   *coreV1.Service
+	endpoints *coreV1.Endpoints
+}
+
+// backendResolver resolves backend services and caches them, as multiple paths usually share the same service.
+type backendResolver struct {
+	client    client.Interface
+	namespace string
+	services  map[string]*serviceInfo
+}
+
+func (self *backendResolver) resolve(backend v1.IngressBackend) (RouteBackend, error) {
+	result := RouteBackend{}
+	if backend.Resource != nil {
+		result.ResourceKind = backend.Resource.Kind
+		result.ResourceName = backend.Resource.Name
+	}
+
+	if backend.Service == nil {
+		return result, nil
+	}
+
+	result.ServiceName = backend.Service.Name
+	result.ServicePort = backend.Service.Port.Name
+	if len(result.ServicePort) == 0 {
+		result.ServicePort = strconv.Itoa(int(backend.Service.Port.Number))
+	}
+
+	info, err := self.service(backend.Service.Name)
+	if err != nil || info.service == nil {
+		return result, err
+	}
+
+	result.ServiceExists = true
+	for _, port := range info.service.Spec.Ports {
+		if (len(backend.Service.Port.Name) > 0 && port.Name == backend.Service.Port.Name) ||
+			(backend.Service.Port.Number > 0 && port.Port == backend.Service.Port.Number) {
+			result.PortExists = true
+		}
+	}
+
+	if info.endpoints != nil {
+		for _, subset := range info.endpoints.Subsets {
+			result.ReadyEndpoints += len(subset.Addresses)
+			result.NotReadyEndpoints += len(subset.NotReadyAddresses)
+		}
+	}
+
+	return result, nil
+}
+
+// Returns the service and its endpoints. Missing objects are returned as nil.
+func (self *backendResolver) service(name string) (*serviceInfo, error) {
+	if info, ok := self.services[name]; ok {
+		return info, nil
+	}
+
+	info := &serviceInfo{}
+	service, err := self.client.CoreV1().Services(self.namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if err == nil {
+		info.service = service
+		endpoints, err := self.client.CoreV1().Endpoints(self.namespace).Get(context.TODO(), name,
+			metaV1.GetOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+
+		if err == nil {
+			info.endpoints = endpoints
+		}
+	}
+
+	self.services[name] = info
+	return info, nil
+}
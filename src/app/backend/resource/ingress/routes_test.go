@@ -0,0 +1,107 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"reflect"
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/networking/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetIngressRoutes(t *testing.T) {
+	prefix := v1.PathTypePrefix
+	apiGroup := "storage.k8s.io"
+	ingress := &v1.Ingress{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1.IngressSpec{
+			TLS: []v1.IngressTLS{{Hosts: []string{"*.example.com"}, SecretName: "wildcard"}},
+			DefaultBackend: &v1.IngressBackend{Resource: &coreV1.TypedLocalObjectReference{
+				APIGroup: &apiGroup, Kind: "StorageBucket", Name: "static"}},
+			Rules: []v1.IngressRule{
+				{Host: "shop.example.com", IngressRuleValue: v1.IngressRuleValue{HTTP: &v1.HTTPIngressRuleValue{
+					Paths: []v1.HTTPIngressPath{
+						{Path: "/", PathType: &prefix, Backend: v1.IngressBackend{Service: &v1.IngressServiceBackend{
+							Name: "frontend", Port: v1.ServiceBackendPort{Name: "http"}}}},
+						{Path: "/api", PathType: &prefix, Backend: v1.IngressBackend{Service: &v1.IngressServiceBackend{
+							Name: "frontend", Port: v1.ServiceBackendPort{Number: 9000}}}},
+					},
+				}}},
+				{Host: "example.org", IngressRuleValue: v1.IngressRuleValue{HTTP: &v1.HTTPIngressRuleValue{
+					Paths: []v1.HTTPIngressPath{
+						{Path: "/", Backend: v1.IngressBackend{Service: &v1.IngressServiceBackend{
+							Name: "missing", Port: v1.ServiceBackendPort{Number: 80}}}},
+					},
+				}}},
+				{Host: "nohttp.example.com"},
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset(ingress,
+		&coreV1.Service{
+			ObjectMeta: metaV1.ObjectMeta{Name: "frontend", Namespace: "default"},
+			Spec:       coreV1.ServiceSpec{Ports: []coreV1.ServicePort{{Name: "http", Port: 80}}},
+		},
+		&coreV1.Endpoints{
+			ObjectMeta: metaV1.ObjectMeta{Name: "frontend", Namespace: "default"},
+			Subsets: []coreV1.EndpointSubset{{
+				Addresses:         []coreV1.EndpointAddress{{IP: "10.0.0.1"}, {IP: "10.0.0.2"}},
+				NotReadyAddresses: []coreV1.EndpointAddress{{IP: "10.0.0.3"}},
+			}},
+		})
+
+	actual, err := GetIngressRoutes(client, "default", "web")
+	if err != nil {
+		t.Fatalf("Expected ingress routes, but got %v", err)
+	}
+
+	expected := []IngressRoute{
+		{Host: "shop.example.com", Path: "/", PathType: "Prefix", TLS: true, TLSSecretName: "wildcard",
+			Backend: RouteBackend{ServiceName: "frontend", ServicePort: "http", ServiceExists: true, PortExists: true,
+				ReadyEndpoints: 2, NotReadyEndpoints: 1}},
+		{Host: "shop.example.com", Path: "/api", PathType: "Prefix", TLS: true, TLSSecretName: "wildcard",
+			Backend: RouteBackend{ServiceName: "frontend", ServicePort: "9000", ServiceExists: true,
+				ReadyEndpoints: 2, NotReadyEndpoints: 1}},
+		{Host: "example.org", Path: "/", Backend: RouteBackend{ServiceName: "missing", ServicePort: "80"}},
+		{Default: true, Backend: RouteBackend{ResourceKind: "StorageBucket", ResourceName: "static"}},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected routes %#v, but got %#v", expected, actual)
+	}
+}
+
+func TestMatchesTLSHost(t *testing.T) {
+	cases := []struct {
+		tlsHost, host string
+		expected      bool
+	}{
+		{"example.com", "example.com", true},
+		{"*.example.com", "shop.example.com", true},
+		{"*.example.com", "a.shop.example.com", false},
+		{"*.example.com", "example.com", false},
+		{"*.example.com", "localhost", false},
+	}
+
+	for _, c := range cases {
+		if actual := matchesTLSHost(c.tlsHost, c.host); actual != c.expected {
+			t.Errorf("Expected %s matching %s to be %t, but got %t", c.tlsHost, c.host, c.expected, actual)
+		}
+	}
+}
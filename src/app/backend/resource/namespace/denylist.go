@@ -0,0 +1,53 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"regexp"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// FilterDeniedNamespaces returns names of the namespaces that do not match the denylist. All namespaces are returned
+// if denylist is nil.
+func FilterDeniedNamespaces(names []string, denylist *regexp.Regexp) []string {
+	if denylist == nil {
+		return names
+	}
+
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		if !denylist.MatchString(name) {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
+
+func filterDeniedNamespaceObjects(namespaces []v1.Namespace, denylist *regexp.Regexp) []v1.Namespace {
+	if denylist == nil {
+		return namespaces
+	}
+
+	result := make([]v1.Namespace, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		if !denylist.MatchString(namespace.Name) {
+			result = append(result, namespace)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,56 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/dataselect"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestFilterDeniedNamespaces(t *testing.T) {
+	names := []string{"default", "kube-system", "kube-public", "team-a"}
+	denylist := regexp.MustCompile("^kube-")
+
+	if actual := FilterDeniedNamespaces(names, denylist); !reflect.DeepEqual(actual,
+		[]string{"default", "team-a"}) {
+		t.Errorf("Expected kube- namespaces to be hidden, got %v", actual)
+	}
+
+	if actual := FilterDeniedNamespaces(names, nil); !reflect.DeepEqual(actual, names) {
+		t.Errorf("Expected all namespaces without denylist, got %v", actual)
+	}
+}
+
+func TestGetNamespaceListWithDenylist(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "default"}},
+		&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "kube-system"}},
+	)
+
+	list, err := GetNamespaceList(client, dataselect.NoDataSelect, regexp.MustCompile("^kube-"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if list.ListMeta.TotalItems != 1 || len(list.Namespaces) != 1 || list.Namespaces[0].ObjectMeta.Name != "default" {
+		t.Errorf("Expected only default namespace, got %+v", list)
+	}
+}
@@ -17,6 +17,7 @@ package namespace
 import (
 	"context"
 	"log"
+	"regexp"
 
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/api"
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
@@ -60,8 +61,10 @@ func GetNamespaceListFromChannels(channels *common.ResourceChannels, dsQuery *da
 	return toNamespaceList(namespaces.Items, nonCriticalErrors, dsQuery), nil
 }
 
-// GetNamespaceList returns a list of all namespaces in the cluster.
-func GetNamespaceList(client kubernetes.Interface, dsQuery *dataselect.DataSelectQuery) (*NamespaceList, error) {
+// GetNamespaceList returns a list of all namespaces in the cluster. Namespaces matching the denylist are left out,
+// nil denylist returns all of them.
+func GetNamespaceList(client kubernetes.Interface, dsQuery *dataselect.DataSelectQuery,
+	denylist *regexp.Regexp) (*NamespaceList, error) {
 	log.Println("Getting list of namespaces")
 	namespaces, err := client.CoreV1().Namespaces().List(context.TODO(), api.ListEverything)
 
@@ -70,7 +73,7 @@ func GetNamespaceList(client kubernetes.Interface, dsQuery *dataselect.DataSelec
 		return nil, criticalError
 	}
 
-	return toNamespaceList(namespaces.Items, nonCriticalErrors, dsQuery), nil
+	return toNamespaceList(filterDeniedNamespaceObjects(namespaces.Items, denylist), nonCriticalErrors, dsQuery), nil
 }
 
 func toNamespaceList(namespaces []v1.Namespace, nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *NamespaceList {
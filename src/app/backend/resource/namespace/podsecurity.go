@@ -0,0 +1,69 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/api"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// PodSecurityLabelPrefix is a prefix of the namespace labels used to configure pod security admission.
+	PodSecurityLabelPrefix = "pod-security.kubernetes.io/"
+	// PodSecurityLevelUnset is reported for modes that are not configured on the namespace.
+	PodSecurityLevelUnset = "unset"
+)
+
+// PSALevels contains pod security admission levels configured on the namespace for every admission mode.
+type PSALevels struct {
+	Enforce string `json:"enforce"`
+	Warn    string `json:"warn"`
+	Audit   string `json:"audit"`
+}
+
+// GetPodSecurityLevels returns pod security admission levels of all namespaces keyed by the namespace name.
+func GetPodSecurityLevels(client kubernetes.Interface) (map[string]PSALevels, error) {
+	namespaces, err := client.CoreV1().Namespaces().List(context.TODO(), api.ListEverything)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]PSALevels, len(namespaces.Items))
+	for _, namespace := range namespaces.Items {
+		result[namespace.Name] = ToPSALevels(namespace)
+	}
+
+	return result, nil
+}
+
+// ToPSALevels extracts pod security admission levels from the namespace labels.
+func ToPSALevels(namespace v1.Namespace) PSALevels {
+	return PSALevels{
+		Enforce: podSecurityLevel(namespace.Labels, "enforce"),
+		Warn:    podSecurityLevel(namespace.Labels, "warn"),
+		Audit:   podSecurityLevel(namespace.Labels, "audit"),
+	}
+}
+
+func podSecurityLevel(labels map[string]string, mode string) string {
+	if level, ok := labels[PodSecurityLabelPrefix+mode]; ok && len(level) > 0 {
+		return level
+	}
+
+	return PodSecurityLevelUnset
+}
@@ -0,0 +1,56 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetPodSecurityLevels(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "restricted", Labels: map[string]string{
+			"pod-security.kubernetes.io/enforce":         "restricted",
+			"pod-security.kubernetes.io/enforce-version": "latest",
+			"pod-security.kubernetes.io/warn":            "restricted",
+			"pod-security.kubernetes.io/audit":           "restricted",
+		}}},
+		&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "partial", Labels: map[string]string{
+			"pod-security.kubernetes.io/warn":  "baseline",
+			"pod-security.kubernetes.io/audit": "",
+			"app":                              "test",
+		}}},
+		&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "default"}},
+	)
+
+	expected := map[string]PSALevels{
+		"restricted": {Enforce: "restricted", Warn: "restricted", Audit: "restricted"},
+		"partial":    {Enforce: PodSecurityLevelUnset, Warn: "baseline", Audit: PodSecurityLevelUnset},
+		"default":    {Enforce: PodSecurityLevelUnset, Warn: PodSecurityLevelUnset, Audit: PodSecurityLevelUnset},
+	}
+
+	actual, err := GetPodSecurityLevels(client)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	coreV1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/networking/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	client "k8s.io/client-go/kubernetes"
+)
+
+// NetworkPolicySummary describes a network policy that selects the pod. When no policy selects the pod, a single
+// summary with NoIsolation set is returned, as all traffic to and from the pod is allowed.
+type NetworkPolicySummary struct {
+	Name        string `json:"name,omitempty"`
+	NoIsolation bool   `json:"noIsolation,omitempty"`
+	// PolicyTypes are the directions of the traffic isolated by the policy.
+	PolicyTypes []v1.PolicyType `json:"policyTypes,omitempty"`
+	// Ingress and Egress rules allowed by the policy. Isolated direction without rules denies all traffic.
+	Ingress []RuleSummary `json:"ingress,omitempty"`
+	Egress  []RuleSummary `json:"egress,omitempty"`
+}
+
+// RuleSummary is a human-readable description of the network policy rule.
+type RuleSummary struct {
+	// Peers allowed by the rule, i.e. 'pods app=web in namespaces team=a' or 'ipBlock 10.0.0.0/8'.
+	Peers []string `json:"peers"`
+	// Ports allowed by the rule, i.e. 'TCP/80'.
+	Ports []string `json:"ports"`
+}
+
+// GetPodNetworkPolicies returns summaries of the network policies in the namespace of the pod whose pod selector
+// matches labels of the pod, sorted by name.
+func GetPodNetworkPolicies(client client.Interface, namespace, podName string) ([]NetworkPolicySummary, error) {
+	pod, err := client.CoreV1().Pods(namespace).Get(context.TODO(), podName, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	policies, err := client.NetworkingV1().NetworkPolicies(namespace).List(context.TODO(), metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]NetworkPolicySummary, 0)
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		selector, err := metaV1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+		if err != nil || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+
+		result = append(result, toNetworkPolicySummary(policy))
+	}
+
+	if len(result) == 0 {
+		return []NetworkPolicySummary{{NoIsolation: true}}, nil
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+func toNetworkPolicySummary(policy *v1.NetworkPolicy) NetworkPolicySummary {
+	summary := NetworkPolicySummary{Name: policy.Name, PolicyTypes: policy.Spec.PolicyTypes}
+	// Policies without types isolate ingress and, if they have any egress rules, egress as well.
+	if len(summary.PolicyTypes) == 0 {
+		summary.PolicyTypes = []v1.PolicyType{v1.PolicyTypeIngress}
+		if len(policy.Spec.Egress) > 0 {
+			summary.PolicyTypes = append(summary.PolicyTypes, v1.PolicyTypeEgress)
+		}
+	}
+
+	for _, rule := range policy.Spec.Ingress {
+		summary.Ingress = append(summary.Ingress, RuleSummary{
+			Peers: describePeers(rule.From, "all sources"),
+			Ports: describePorts(rule.Ports),
+		})
+	}
+
+	for _, rule := range policy.Spec.Egress {
+		summary.Egress = append(summary.Egress, RuleSummary{
+			Peers: describePeers(rule.To, "all destinations"),
+			Ports: describePorts(rule.Ports),
+		})
+	}
+
+	return summary
+}
+
+func describePeers(peers []v1.NetworkPolicyPeer, all string) []string {
+	if len(peers) == 0 {
+		return []string{all}
+	}
+
+	result := make([]string, 0, len(peers))
+	for _, peer := range peers {
+		switch {
+		case peer.IPBlock != nil:
+			description := "ipBlock " + peer.IPBlock.CIDR
+			if len(peer.IPBlock.Except) > 0 {
+				description += " except " + strings.Join(peer.IPBlock.Except, ", ")
+			}
+			result = append(result, description)
+		case peer.PodSelector != nil && peer.NamespaceSelector != nil:
+			result = append(result, fmt.Sprintf("pods %s in namespaces %s", describeSelector(peer.PodSelector),
+				describeSelector(peer.NamespaceSelector)))
+		case peer.PodSelector != nil:
+			result = append(result, "pods "+describeSelector(peer.PodSelector))
+		case peer.NamespaceSelector != nil:
+			result = append(result, "namespaces "+describeSelector(peer.NamespaceSelector))
+		}
+	}
+
+	return result
+}
+
+func describeSelector(selector *metaV1.LabelSelector) string {
+	if len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0 {
+		return "(all)"
+	}
+
+	return metaV1.FormatLabelSelector(selector)
+}
+
+func describePorts(ports []v1.NetworkPolicyPort) []string {
+	if len(ports) == 0 {
+		return []string{"all ports"}
+	}
+
+	result := make([]string, 0, len(ports))
+	for _, port := range ports {
+		protocol := coreV1.ProtocolTCP
+		if port.Protocol != nil {
+			protocol = *port.Protocol
+		}
+
+		description := string(protocol)
+		if port.Port != nil {
+			description += "/" + port.Port.String()
+			if port.EndPort != nil {
+				description += fmt.Sprintf("-%d", *port.EndPort)
+			}
+		}
+		result = append(result, description)
+	}
+
+	return result
+}
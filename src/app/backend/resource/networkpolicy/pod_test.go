@@ -0,0 +1,112 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"reflect"
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/networking/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetPodNetworkPolicies(t *testing.T) {
+	udp := coreV1.ProtocolUDP
+	port := intstr.FromInt(8000)
+	endPort := int32(9000)
+	dns := intstr.FromInt(53)
+
+	pod := &coreV1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default",
+		Labels: map[string]string{"app": "web"}}}
+	client := fake.NewSimpleClientset(pod,
+		&v1.NetworkPolicy{
+			ObjectMeta: metaV1.ObjectMeta{Name: "web-ingress", Namespace: "default"},
+			Spec: v1.NetworkPolicySpec{
+				PodSelector: metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				Ingress: []v1.NetworkPolicyIngressRule{{
+					From: []v1.NetworkPolicyPeer{
+						{PodSelector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "frontend"}}},
+						{IPBlock: &v1.IPBlock{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}}},
+					},
+					Ports: []v1.NetworkPolicyPort{{Port: &port, EndPort: &endPort}},
+				}},
+			},
+		},
+		&v1.NetworkPolicy{
+			ObjectMeta: metaV1.ObjectMeta{Name: "default-deny-egress", Namespace: "default"},
+			Spec: v1.NetworkPolicySpec{
+				PolicyTypes: []v1.PolicyType{v1.PolicyTypeEgress},
+				Egress: []v1.NetworkPolicyEgressRule{{
+					To:    []v1.NetworkPolicyPeer{{NamespaceSelector: &metaV1.LabelSelector{}}},
+					Ports: []v1.NetworkPolicyPort{{Protocol: &udp, Port: &dns}},
+				}},
+			},
+		},
+		&v1.NetworkPolicy{
+			ObjectMeta: metaV1.ObjectMeta{Name: "db", Namespace: "default"},
+			Spec: v1.NetworkPolicySpec{
+				PodSelector: metaV1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			},
+		},
+	)
+
+	actual, err := GetPodNetworkPolicies(client, "default", "web")
+	if err != nil {
+		t.Fatalf("Expected network policies, but got %v", err)
+	}
+
+	expected := []NetworkPolicySummary{
+		{
+			Name:        "default-deny-egress",
+			PolicyTypes: []v1.PolicyType{v1.PolicyTypeEgress},
+			Egress:      []RuleSummary{{Peers: []string{"namespaces (all)"}, Ports: []string{"UDP/53"}}},
+		},
+		{
+			Name:        "web-ingress",
+			PolicyTypes: []v1.PolicyType{v1.PolicyTypeIngress},
+			Ingress: []RuleSummary{{
+				Peers: []string{"pods app=frontend", "ipBlock 10.0.0.0/8 except 10.1.0.0/16"},
+				Ports: []string{"TCP/8000-9000"},
+			}},
+		},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected policies %#v, but got %#v", expected, actual)
+	}
+}
+
+func TestGetPodNetworkPoliciesNoIsolation(t *testing.T) {
+	pod := &coreV1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default",
+		Labels: map[string]string{"app": "web"}}}
+	client := fake.NewSimpleClientset(pod, &v1.NetworkPolicy{
+		ObjectMeta: metaV1.ObjectMeta{Name: "db", Namespace: "default"},
+		Spec: v1.NetworkPolicySpec{
+			PodSelector: metaV1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+		},
+	})
+
+	actual, err := GetPodNetworkPolicies(client, "default", "web")
+	if err != nil {
+		t.Fatalf("Expected network policies, but got %v", err)
+	}
+
+	if !reflect.DeepEqual(actual, []NetworkPolicySummary{{NoIsolation: true}}) {
+		t.Errorf("Expected pod not to be isolated, but got %#v", actual)
+	}
+}
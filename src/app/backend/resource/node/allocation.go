@@ -0,0 +1,125 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"context"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sClient "k8s.io/client-go/kubernetes"
+)
+
+// AllocationSummary compares resources requested by pods scheduled on the node with its allocatable resources.
+type AllocationSummary struct {
+	NodeName string `json:"nodeName"`
+	// Resources contains every resource that is allocatable on the node or requested by its pods, sorted by name.
+	Resources []ResourceAllocation `json:"resources"`
+	// Pods is the number of non-terminated pods scheduled on the node.
+	Pods           int     `json:"pods"`
+	PodCapacity    int64   `json:"podCapacity"`
+	PodsPercentage float64 `json:"podsPercentage"`
+}
+
+// ResourceAllocation describes allocation of a single resource. Percentages are relative to the allocatable amount
+// and can be over 100 when the node is overcommitted. They are 0 when nothing is allocatable.
+type ResourceAllocation struct {
+	Name               v1.ResourceName   `json:"name"`
+	Requests           resource.Quantity `json:"requests"`
+	Limits             resource.Quantity `json:"limits"`
+	Allocatable        resource.Quantity `json:"allocatable"`
+	RequestsPercentage float64           `json:"requestsPercentage"`
+	LimitsPercentage   float64           `json:"limitsPercentage"`
+}
+
+// GetNodeAllocationSummary sums requests and limits of the pods scheduled on the node. Pods that succeeded or
+// failed do not hold their resources anymore, so they are excluded.
+func GetNodeAllocationSummary(client k8sClient.Interface, nodeName string) (*AllocationSummary, error) {
+	node, err := client.CoreV1().Nodes().Get(context.TODO(), nodeName, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := getNodePods(client, *node)
+	if err != nil {
+		return nil, err
+	}
+
+	reqs, limits := v1.ResourceList{}, v1.ResourceList{}
+	scheduled := 0
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		// Field selector might not be supported by every backend, so terminated pods are checked once again.
+		if pod.Spec.NodeName != node.Name || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+
+		podReqs, podLimits, err := PodRequestsAndLimits(pod)
+		if err != nil {
+			return nil, err
+		}
+
+		addResourceList(reqs, podReqs)
+		addResourceList(limits, podLimits)
+		scheduled++
+	}
+
+	summary := &AllocationSummary{
+		NodeName:    node.Name,
+		Resources:   toResourceAllocations(node.Status.Allocatable, reqs, limits),
+		Pods:        scheduled,
+		PodCapacity: node.Status.Allocatable.Pods().Value(),
+	}
+
+	if summary.PodCapacity > 0 {
+		summary.PodsPercentage = float64(scheduled) / float64(summary.PodCapacity) * 100
+	}
+
+	return summary, nil
+}
+
+func toResourceAllocations(allocatable, reqs, limits v1.ResourceList) []ResourceAllocation {
+	names := make(map[v1.ResourceName]bool)
+	for _, list := range []v1.ResourceList{allocatable, reqs, limits} {
+		for name := range list {
+			// Pods are not requested by containers, they are reported separately.
+			if name != v1.ResourcePods {
+				names[name] = true
+			}
+		}
+	}
+
+	result := make([]ResourceAllocation, 0, len(names))
+	for name := range names {
+		allocation := ResourceAllocation{
+			Name:        name,
+			Requests:    reqs[name],
+			Limits:      limits[name],
+			Allocatable: allocatable[name],
+		}
+
+		if capacity := float64(allocation.Allocatable.MilliValue()); capacity > 0 {
+			allocation.RequestsPercentage = float64(allocation.Requests.MilliValue()) / capacity * 100
+			allocation.LimitsPercentage = float64(allocation.Limits.MilliValue()) / capacity * 100
+		}
+
+		result = append(result, allocation)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
@@ -0,0 +1,99 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newAllocationPod(name, nodeName string, phase v1.PodPhase, cpu, memory string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1.PodSpec{
+			NodeName: nodeName,
+			Containers: []v1.Container{{
+				Name: "app",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse(cpu),
+						v1.ResourceMemory: resource.MustParse(memory),
+					},
+					Limits: v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu)},
+				},
+			}},
+		},
+		Status: v1.PodStatus{Phase: phase},
+	}
+}
+
+func TestGetNodeAllocationSummary(t *testing.T) {
+	node := &v1.Node{
+		ObjectMeta: metaV1.ObjectMeta{Name: "worker"},
+		Status: v1.NodeStatus{Allocatable: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("2"),
+			v1.ResourceMemory: resource.MustParse("4Gi"),
+			v1.ResourcePods:   resource.MustParse("10"),
+		}},
+	}
+
+	client := fake.NewSimpleClientset(node,
+		newAllocationPod("web", "worker", v1.PodRunning, "500m", "1Gi"),
+		newAllocationPod("worker", "worker", v1.PodPending, "1", "1Gi"),
+		newAllocationPod("done", "worker", v1.PodSucceeded, "1", "1Gi"),
+		newAllocationPod("crashed", "worker", v1.PodFailed, "1", "1Gi"),
+		newAllocationPod("elsewhere", "other", v1.PodRunning, "1", "1Gi"))
+
+	summary, err := GetNodeAllocationSummary(client, "worker")
+	if err != nil {
+		t.Fatalf("Expected allocation summary, but got %v", err)
+	}
+
+	if summary.Pods != 2 || summary.PodCapacity != 10 || summary.PodsPercentage != 20 {
+		t.Errorf("Expected 2 of 10 pods to be allocated, but got %#v", summary)
+	}
+
+	expected := []struct {
+		name                                 v1.ResourceName
+		requests, limits                     string
+		requestsPercentage, limitsPercentage float64
+	}{
+		{v1.ResourceCPU, "1500m", "1500m", 75, 75},
+		{v1.ResourceMemory, "2Gi", "0", 50, 0},
+	}
+
+	if len(summary.Resources) != len(expected) {
+		t.Fatalf("Expected %d resources, but got %#v", len(expected), summary.Resources)
+	}
+
+	for i, e := range expected {
+		actual := summary.Resources[i]
+		if actual.Name != e.name || actual.Requests.Cmp(resource.MustParse(e.requests)) != 0 ||
+			actual.Limits.Cmp(resource.MustParse(e.limits)) != 0 ||
+			actual.RequestsPercentage != e.requestsPercentage || actual.LimitsPercentage != e.limitsPercentage {
+			t.Errorf("Expected %s allocation %#v, but got %#v", e.name, e, actual)
+		}
+	}
+}
+
+func TestGetNodeAllocationSummaryNotFound(t *testing.T) {
+	if _, err := GetNodeAllocationSummary(fake.NewSimpleClientset(), "missing"); err == nil {
+		t.Fatal("Expected error for missing node")
+	}
+}
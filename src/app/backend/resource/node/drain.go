@@ -0,0 +1,109 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sClient "k8s.io/client-go/kubernetes"
+)
+
+// DrainBlockReason describes why the pod blocks drain of the node.
+type DrainBlockReason string
+
+const (
+	// DrainBlockUnmanaged is reported for pods that are not managed by any controller and would not be recreated.
+	DrainBlockUnmanaged DrainBlockReason = "Unmanaged"
+	// DrainBlockLocalStorage is reported for pods using emptyDir volumes, which data would be lost.
+	DrainBlockLocalStorage DrainBlockReason = "LocalStorage"
+	// DrainBlockDaemonSet is reported for pods managed by daemon sets, which would be recreated on the same node.
+	DrainBlockDaemonSet DrainBlockReason = "DaemonSet"
+)
+
+// DrainBlockingPod describes pod that blocks drain of the node.
+type DrainBlockingPod struct {
+	Name      string             `json:"name"`
+	Namespace string             `json:"namespace"`
+	Reasons   []DrainBlockReason `json:"reasons"`
+}
+
+// DrainReadiness describes whether the node can be drained without forcing eviction of any of its pods.
+type DrainReadiness struct {
+	Ready        bool               `json:"ready"`
+	BlockingPods []DrainBlockingPod `json:"blockingPods"`
+}
+
+// SetNodeSchedulable cordons or uncordons the node by patching its spec.unschedulable field.
+func SetNodeSchedulable(client k8sClient.Interface, name string, schedulable bool) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, !schedulable))
+	_, err := client.CoreV1().Nodes().Patch(context.TODO(), name, types.StrategicMergePatchType, patch,
+		metaV1.PatchOptions{})
+	return err
+}
+
+// GetNodeDrainReadiness returns pods running on the node that would block its drain. Mirror pods are skipped as
+// they can not be evicted through the apiserver.
+func GetNodeDrainReadiness(client k8sClient.Interface, name string) (*DrainReadiness, error) {
+	node, err := client.CoreV1().Nodes().Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := getNodePods(client, *node)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DrainReadiness{BlockingPods: make([]DrainBlockingPod, 0)}
+	for _, pod := range pods.Items {
+		if reasons := drainBlockReasons(pod); len(reasons) > 0 {
+			result.BlockingPods = append(result.BlockingPods, DrainBlockingPod{
+				Name:      pod.Name,
+				Namespace: pod.Namespace,
+				Reasons:   reasons,
+			})
+		}
+	}
+
+	result.Ready = len(result.BlockingPods) == 0
+	return result, nil
+}
+
+func drainBlockReasons(pod v1.Pod) []DrainBlockReason {
+	if _, mirror := pod.Annotations[v1.MirrorPodAnnotationKey]; mirror {
+		return nil
+	}
+
+	reasons := make([]DrainBlockReason, 0)
+	controller := metaV1.GetControllerOf(&pod)
+	if controller == nil {
+		reasons = append(reasons, DrainBlockUnmanaged)
+	} else if controller.Kind == "DaemonSet" {
+		reasons = append(reasons, DrainBlockDaemonSet)
+	}
+
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil {
+			reasons = append(reasons, DrainBlockLocalStorage)
+			break
+		}
+	}
+
+	return reasons
+}
@@ -0,0 +1,106 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newDrainTestPod(name string, controllerKind string, volumes ...v1.Volume) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       v1.PodSpec{NodeName: "node-1", Volumes: volumes},
+	}
+
+	if len(controllerKind) > 0 {
+		controller := true
+		pod.OwnerReferences = []metaV1.OwnerReference{{Kind: controllerKind, Name: "owner", Controller: &controller}}
+	}
+
+	return pod
+}
+
+func TestSetNodeSchedulable(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.Node{ObjectMeta: metaV1.ObjectMeta{Name: "node-1"}})
+
+	for _, schedulable := range []bool{false, true} {
+		if err := SetNodeSchedulable(client, "node-1", schedulable); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		node, err := client.CoreV1().Nodes().Get(context.TODO(), "node-1", metaV1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if node.Spec.Unschedulable == schedulable {
+			t.Errorf("Expected node unschedulable to be %v, got %v", !schedulable, node.Spec.Unschedulable)
+		}
+	}
+}
+
+func TestGetNodeDrainReadiness(t *testing.T) {
+	emptyDir := v1.Volume{Name: "cache", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}
+	configMap := v1.Volume{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{}}}
+	mirror := newDrainTestPod("static", "")
+	mirror.Annotations = map[string]string{v1.MirrorPodAnnotationKey: "hash"}
+
+	cases := []struct {
+		info     string
+		pods     []*v1.Pod
+		expected *DrainReadiness
+	}{
+		{
+			"should be ready when pods are managed by controllers",
+			[]*v1.Pod{newDrainTestPod("web", "ReplicaSet", configMap), mirror},
+			&DrainReadiness{Ready: true, BlockingPods: []DrainBlockingPod{}},
+		},
+		{
+			"should report blocking pods",
+			[]*v1.Pod{
+				newDrainTestPod("bare", ""),
+				newDrainTestPod("cache", "StatefulSet", emptyDir),
+				newDrainTestPod("agent", "DaemonSet", emptyDir),
+			},
+			&DrainReadiness{Ready: false, BlockingPods: []DrainBlockingPod{
+				{Name: "agent", Namespace: "default", Reasons: []DrainBlockReason{DrainBlockDaemonSet, DrainBlockLocalStorage}},
+				{Name: "bare", Namespace: "default", Reasons: []DrainBlockReason{DrainBlockUnmanaged}},
+				{Name: "cache", Namespace: "default", Reasons: []DrainBlockReason{DrainBlockLocalStorage}},
+			}},
+		},
+	}
+
+	for _, c := range cases {
+		client := fake.NewSimpleClientset(&v1.Node{ObjectMeta: metaV1.ObjectMeta{Name: "node-1"}})
+		for _, pod := range c.pods {
+			client.Tracker().Add(pod)
+		}
+
+		actual, err := GetNodeDrainReadiness(client, "node-1")
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.info, err)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: expected %+v, got %+v", c.info, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sClient "k8s.io/client-go/kubernetes"
+)
+
+// StatsSummary is a subset of the summary returned by the '/stats/summary' endpoint of the kubelet. Field names
+// follow the kubelet stats API.
+type StatsSummary struct {
+	Node NodeStats  `json:"node"`
+	Pods []PodStats `json:"pods"`
+}
+
+// NodeStats contains resource usage of the whole node.
+type NodeStats struct {
+	NodeName  string        `json:"nodeName"`
+	StartTime metaV1.Time   `json:"startTime"`
+	CPU       *CPUStats     `json:"cpu,omitempty"`
+	Memory    *MemoryStats  `json:"memory,omitempty"`
+	Network   *NetworkStats `json:"network,omitempty"`
+	Fs        *FsStats      `json:"fs,omitempty"`
+}
+
+// PodStats contains resource usage of the pod and its containers.
+type PodStats struct {
+	PodRef           PodReference     `json:"podRef"`
+	StartTime        metaV1.Time      `json:"startTime"`
+	Containers       []ContainerStats `json:"containers"`
+	CPU              *CPUStats        `json:"cpu,omitempty"`
+	Memory           *MemoryStats     `json:"memory,omitempty"`
+	Network          *NetworkStats    `json:"network,omitempty"`
+	EphemeralStorage *FsStats         `json:"ephemeral-storage,omitempty"`
+}
+
+// PodReference identifies the pod of the stats.
+type PodReference struct {
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace"`
+	UID       types.UID `json:"uid"`
+}
+
+// ContainerStats contains resource usage of a single container.
+type ContainerStats struct {
+	Name   string       `json:"name"`
+	CPU    *CPUStats    `json:"cpu,omitempty"`
+	Memory *MemoryStats `json:"memory,omitempty"`
+	Rootfs *FsStats     `json:"rootfs,omitempty"`
+	Logs   *FsStats     `json:"logs,omitempty"`
+}
+
+// CPUStats contains CPU usage.
+type CPUStats struct {
+	Time                 metaV1.Time `json:"time"`
+	UsageNanoCores       *uint64     `json:"usageNanoCores,omitempty"`
+	UsageCoreNanoSeconds *uint64     `json:"usageCoreNanoSeconds,omitempty"`
+}
+
+// MemoryStats contains memory usage.
+type MemoryStats struct {
+	Time            metaV1.Time `json:"time"`
+	AvailableBytes  *uint64     `json:"availableBytes,omitempty"`
+	UsageBytes      *uint64     `json:"usageBytes,omitempty"`
+	WorkingSetBytes *uint64     `json:"workingSetBytes,omitempty"`
+	RSSBytes        *uint64     `json:"rssBytes,omitempty"`
+}
+
+// NetworkStats contains network usage of the default interface.
+type NetworkStats struct {
+	Time     metaV1.Time `json:"time"`
+	Name     string      `json:"name"`
+	RxBytes  *uint64     `json:"rxBytes,omitempty"`
+	RxErrors *uint64     `json:"rxErrors,omitempty"`
+	TxBytes  *uint64     `json:"txBytes,omitempty"`
+	TxErrors *uint64     `json:"txErrors,omitempty"`
+}
+
+// FsStats contains filesystem usage.
+type FsStats struct {
+	Time           metaV1.Time `json:"time"`
+	AvailableBytes *uint64     `json:"availableBytes,omitempty"`
+	CapacityBytes  *uint64     `json:"capacityBytes,omitempty"`
+	UsedBytes      *uint64     `json:"usedBytes,omitempty"`
+	InodesFree     *uint64     `json:"inodesFree,omitempty"`
+	Inodes         *uint64     `json:"inodes,omitempty"`
+	InodesUsed     *uint64     `json:"inodesUsed,omitempty"`
+}
+
+// GetNodeStatsSummary returns stats summary of the kubelet of the node, requested through the proxy subresource of
+// the node. Forbidden error is returned if user is not allowed to proxy to the node, and NodeUnreachable error if
+// the apiserver could not reach the kubelet.
+func GetNodeStatsSummary(client k8sClient.Interface, name string) (*StatsSummary, error) {
+	raw, err := client.CoreV1().RESTClient().Get().
+		Resource("nodes").
+		Name(name).
+		SubResource("proxy").
+		Suffix("stats", "summary").
+		Do(context.TODO()).
+		Raw()
+	if err != nil {
+		return nil, toStatsError(name, err)
+	}
+
+	summary := &StatsSummary{}
+	if err := json.Unmarshal(raw, summary); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+func toStatsError(name string, err error) error {
+	if k8serrors.IsForbidden(err) {
+		return errors.NewForbidden(fmt.Sprintf("not allowed to get stats of node %s", name))
+	}
+
+	status, ok := err.(k8serrors.APIStatus)
+	if !ok {
+		return err
+	}
+
+	switch status.Status().Code {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return errors.NewNodeUnreachable(name, status.Status().Message)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8sClient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func newStatsTestClient(t *testing.T, status int, body string) (k8sClient.Interface, *string) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := k8sClient.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client, &path
+}
+
+func TestGetNodeStatsSummary(t *testing.T) {
+	client, path := newStatsTestClient(t, http.StatusOK, `{
+		"node": {"nodeName": "node-1", "cpu": {"usageNanoCores": 250000000},
+			"memory": {"workingSetBytes": 1048576}, "systemContainers": [{"name": "kubelet"}]},
+		"pods": [{"podRef": {"name": "web", "namespace": "default", "uid": "123"},
+			"containers": [{"name": "app", "memory": {"workingSetBytes": 524288}}],
+			"ephemeral-storage": {"usedBytes": 4096}}]
+	}`)
+
+	summary, err := GetNodeStatsSummary(client, "node-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if *path != "/api/v1/nodes/node-1/proxy/stats/summary" {
+		t.Errorf("Expected request to the node proxy, got %s", *path)
+	}
+
+	if summary.Node.NodeName != "node-1" || *summary.Node.CPU.UsageNanoCores != 250000000 ||
+		*summary.Node.Memory.WorkingSetBytes != 1048576 {
+		t.Errorf("Unexpected node stats: %+v", summary.Node)
+	}
+
+	if len(summary.Pods) != 1 || summary.Pods[0].PodRef.Name != "web" ||
+		*summary.Pods[0].EphemeralStorage.UsedBytes != 4096 || len(summary.Pods[0].Containers) != 1 ||
+		*summary.Pods[0].Containers[0].Memory.WorkingSetBytes != 524288 {
+		t.Errorf("Unexpected pod stats: %+v", summary.Pods)
+	}
+}
+
+func TestGetNodeStatsSummaryErrors(t *testing.T) {
+	cases := []struct {
+		info      string
+		status    int
+		body      string
+		wantCheck func(error) bool
+	}{
+		{"permission denied", http.StatusForbidden,
+			`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Forbidden","code":403}`,
+			k8serrors.IsForbidden},
+		{"kubelet unreachable", http.StatusServiceUnavailable,
+			"error trying to reach service: dial tcp 10.0.0.1:10250: connect: connection refused",
+			errors.IsNodeUnreachable},
+		{"kubelet timeout", http.StatusGatewayTimeout, "", errors.IsNodeUnreachable},
+		{"node not found", http.StatusNotFound,
+			`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`,
+			errors.IsNotFoundError},
+	}
+
+	for _, c := range cases {
+		client, _ := newStatsTestClient(t, c.status, c.body)
+		if _, err := GetNodeStatsSummary(client, "node-1"); !c.wantCheck(err) {
+			t.Errorf("%s: unexpected error: %v", c.info, err)
+		}
+	}
+}
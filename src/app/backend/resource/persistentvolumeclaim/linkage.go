@@ -0,0 +1,181 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistentvolumeclaim
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// betaStorageClassAnnotation is the deprecated way of setting storage class of the claim, still honored by the
+// apiserver.
+const betaStorageClassAnnotation = "volume.beta.kubernetes.io/storage-class"
+
+// PVCDetail describes the persistent volume claim together with the persistent volume bound to it.
+type PVCDetail struct {
+	Name        string                          `json:"name"`
+	Namespace   string                          `json:"namespace"`
+	Phase       v1.PersistentVolumeClaimPhase   `json:"phase"`
+	AccessModes []v1.PersistentVolumeAccessMode `json:"accessModes"`
+	// RequestedCapacity is the storage requested by the claim. Capacity is the actual one, known once it is bound.
+	RequestedCapacity *resource.Quantity `json:"requestedCapacity,omitempty"`
+	Capacity          *resource.Quantity `json:"capacity,omitempty"`
+	StorageClass      string             `json:"storageClass,omitempty"`
+	// WaitingForFirstConsumer is set for pending claims of the storage class, which delays binding until a pod
+	// using the claim is scheduled.
+	WaitingForFirstConsumer bool `json:"waitingForFirstConsumer"`
+	// Volume is nil until the claim is bound or when the volume it references does not exist.
+	Volume *BoundVolume `json:"volume,omitempty"`
+	// VolumeMissing is set when the claim references the volume that does not exist anymore.
+	VolumeMissing bool `json:"volumeMissing"`
+}
+
+// BoundVolume describes the persistent volume bound to the claim.
+type BoundVolume struct {
+	Name          string                           `json:"name"`
+	Phase         v1.PersistentVolumePhase         `json:"phase"`
+	ReclaimPolicy v1.PersistentVolumeReclaimPolicy `json:"reclaimPolicy"`
+	Capacity      *resource.Quantity               `json:"capacity,omitempty"`
+	// SourceType is the name of the volume source, i.e. 'csi' or 'nfs'.
+	SourceType string                    `json:"sourceType"`
+	Source     v1.PersistentVolumeSource `json:"source"`
+}
+
+// GetPVCDetails returns the persistent volume claim linked with its persistent volume. Pending claims are reported
+// without the volume.
+func GetPVCDetails(client kubernetes.Interface, namespace, name string) (*PVCDetail, error) {
+	pvc, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &PVCDetail{
+		Name:         pvc.Name,
+		Namespace:    pvc.Namespace,
+		Phase:        pvc.Status.Phase,
+		AccessModes:  pvc.Spec.AccessModes,
+		StorageClass: getStorageClassName(pvc),
+	}
+
+	if requested, ok := pvc.Spec.Resources.Requests[v1.ResourceStorage]; ok {
+		detail.RequestedCapacity = &requested
+	}
+
+	if capacity, ok := pvc.Status.Capacity[v1.ResourceStorage]; ok {
+		detail.Capacity = &capacity
+	}
+
+	if len(pvc.Spec.VolumeName) == 0 {
+		detail.WaitingForFirstConsumer, err = isWaitingForFirstConsumer(client, pvc, detail.StorageClass)
+		return detail, err
+	}
+
+	pv, err := client.CoreV1().PersistentVolumes().Get(context.TODO(), pvc.Spec.VolumeName, metaV1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		detail.VolumeMissing = true
+		return detail, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	detail.Volume = &BoundVolume{
+		Name:          pv.Name,
+		Phase:         pv.Status.Phase,
+		ReclaimPolicy: pv.Spec.PersistentVolumeReclaimPolicy,
+		SourceType:    getVolumeSourceType(pv.Spec.PersistentVolumeSource),
+		Source:        pv.Spec.PersistentVolumeSource,
+	}
+
+	if capacity, ok := pv.Spec.Capacity[v1.ResourceStorage]; ok {
+		detail.Volume.Capacity = &capacity
+	}
+
+	return detail, nil
+}
+
+func getStorageClassName(pvc *v1.PersistentVolumeClaim) string {
+	if pvc.Spec.StorageClassName != nil {
+		return *pvc.Spec.StorageClassName
+	}
+
+	return pvc.Annotations[betaStorageClassAnnotation]
+}
+
+// Checks binding mode of the storage class of the pending claim. Missing storage class is not an error, as claim
+// can be bound to a statically provisioned volume.
+func isWaitingForFirstConsumer(client kubernetes.Interface, pvc *v1.PersistentVolumeClaim,
+	storageClass string) (bool, error) {
+	if pvc.Status.Phase != v1.ClaimPending || len(storageClass) == 0 {
+		return false, nil
+	}
+
+	class, err := client.StorageV1().StorageClasses().Get(context.TODO(), storageClass, metaV1.GetOptions{})
+	if k8serrors.IsNotFound(err) || k8serrors.IsForbidden(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return class.VolumeBindingMode != nil && *class.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer,
+		nil
+}
+
+// Returns name of the volume source that is set, as serialized in JSON.
+func getVolumeSourceType(source v1.PersistentVolumeSource) string {
+	switch {
+	case source.CSI != nil:
+		return "csi"
+	case source.NFS != nil:
+		return "nfs"
+	case source.HostPath != nil:
+		return "hostPath"
+	case source.Local != nil:
+		return "local"
+	case source.AWSElasticBlockStore != nil:
+		return "awsElasticBlockStore"
+	case source.GCEPersistentDisk != nil:
+		return "gcePersistentDisk"
+	case source.AzureDisk != nil:
+		return "azureDisk"
+	case source.AzureFile != nil:
+		return "azureFile"
+	case source.ISCSI != nil:
+		return "iscsi"
+	case source.FC != nil:
+		return "fc"
+	case source.RBD != nil:
+		return "rbd"
+	case source.CephFS != nil:
+		return "cephfs"
+	case source.Cinder != nil:
+		return "cinder"
+	case source.VsphereVolume != nil:
+		return "vsphereVolume"
+	case source.Glusterfs != nil:
+		return "glusterfs"
+	}
+
+	return "unknown"
+}
@@ -0,0 +1,108 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistentvolumeclaim
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestPVC(phase v1.PersistentVolumeClaimPhase, volumeName string, storageClass string) *v1.PersistentVolumeClaim {
+	return &v1.PersistentVolumeClaim{
+		ObjectMeta: metaV1.ObjectMeta{Name: "data", Namespace: "default"},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			StorageClassName: &storageClass,
+			VolumeName:       volumeName,
+			Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+				v1.ResourceStorage: resource.MustParse("10Gi"),
+			}},
+		},
+		Status: v1.PersistentVolumeClaimStatus{Phase: phase},
+	}
+}
+
+func TestGetPVCDetailsBound(t *testing.T) {
+	pvc := newTestPVC(v1.ClaimBound, "pv-1", "fast")
+	pvc.Status.Capacity = v1.ResourceList{v1.ResourceStorage: resource.MustParse("16Gi")}
+	pv := &v1.PersistentVolume{
+		ObjectMeta: metaV1.ObjectMeta{Name: "pv-1"},
+		Spec: v1.PersistentVolumeSpec{
+			Capacity:                      v1.ResourceList{v1.ResourceStorage: resource.MustParse("16Gi")},
+			PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimRetain,
+			PersistentVolumeSource: v1.PersistentVolumeSource{
+				CSI: &v1.CSIPersistentVolumeSource{Driver: "ebs.csi.aws.com", VolumeHandle: "vol-123"},
+			},
+		},
+		Status: v1.PersistentVolumeStatus{Phase: v1.VolumeBound},
+	}
+
+	detail, err := GetPVCDetails(fake.NewSimpleClientset(pvc, pv), "default", "data")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if detail.Phase != v1.ClaimBound || detail.StorageClass != "fast" || detail.RequestedCapacity.String() != "10Gi" ||
+		detail.Capacity.String() != "16Gi" || detail.VolumeMissing || detail.WaitingForFirstConsumer {
+		t.Errorf("Unexpected claim details: %+v", detail)
+	}
+
+	volume := detail.Volume
+	if volume == nil || volume.Name != "pv-1" || volume.Phase != v1.VolumeBound ||
+		volume.ReclaimPolicy != v1.PersistentVolumeReclaimRetain || volume.Capacity.String() != "16Gi" ||
+		volume.SourceType != "csi" || volume.Source.CSI.VolumeHandle != "vol-123" {
+		t.Errorf("Unexpected volume details: %+v", volume)
+	}
+}
+
+func TestGetPVCDetailsNotBound(t *testing.T) {
+	waitForConsumer := storagev1.VolumeBindingWaitForFirstConsumer
+	immediate := storagev1.VolumeBindingImmediate
+	classes := []runtime.Object{
+		&storagev1.StorageClass{ObjectMeta: metaV1.ObjectMeta{Name: "local"}, VolumeBindingMode: &waitForConsumer},
+		&storagev1.StorageClass{ObjectMeta: metaV1.ObjectMeta{Name: "fast"}, VolumeBindingMode: &immediate},
+	}
+
+	cases := []struct {
+		info                    string
+		pvc                     *v1.PersistentVolumeClaim
+		waitingForFirstConsumer bool
+		volumeMissing           bool
+	}{
+		{"pending claim", newTestPVC(v1.ClaimPending, "", "fast"), false, false},
+		{"claim waiting for consumer", newTestPVC(v1.ClaimPending, "", "local"), true, false},
+		{"claim with unknown storage class", newTestPVC(v1.ClaimPending, "", "missing"), false, false},
+		{"lost claim", newTestPVC(v1.ClaimLost, "deleted-pv", "fast"), false, true},
+	}
+
+	for _, c := range cases {
+		detail, err := GetPVCDetails(fake.NewSimpleClientset(append(classes, c.pvc)...), "default", "data")
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.info, err)
+			continue
+		}
+
+		if detail.Volume != nil || detail.Capacity != nil || detail.Phase != c.pvc.Status.Phase ||
+			detail.WaitingForFirstConsumer != c.waitingForFirstConsumer || detail.VolumeMissing != c.volumeMissing {
+			t.Errorf("%s: unexpected claim details: %+v", c.info, detail)
+		}
+	}
+}
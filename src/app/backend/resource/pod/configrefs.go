@@ -0,0 +1,199 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"context"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+// Places in the pod spec where config maps and secrets can be referenced.
+const (
+	ReferenceSourceEnv             = "env"
+	ReferenceSourceEnvFrom         = "envFrom"
+	ReferenceSourceVolume          = "volume"
+	ReferenceSourceImagePullSecret = "imagePullSecret"
+)
+
+// ConfigReferences contains config maps and secrets consumed by the pod, sorted by name.
+type ConfigReferences struct {
+	ConfigMaps []ConfigReference `json:"configMaps"`
+	Secrets    []ConfigReference `json:"secrets"`
+}
+
+// ConfigReference is a config map or a secret referenced by the pod.
+type ConfigReference struct {
+	Name string `json:"name"`
+	// Optional is true when all references are optional, so that pod can start without the object.
+	Optional bool `json:"optional"`
+	// Exists is nil when user is not allowed to check whether the object exists.
+	Exists  *bool             `json:"exists"`
+	Sources []ReferenceSource `json:"sources"`
+}
+
+// ReferenceSource describes where in the pod spec the object is referenced.
+type ReferenceSource struct {
+	// Type is one of env, envFrom, volume or imagePullSecret.
+	Type      string `json:"type"`
+	Container string `json:"container,omitempty"`
+	// Key of the object used by the env variable.
+	Key    string `json:"key,omitempty"`
+	Volume string `json:"volume,omitempty"`
+}
+
+// GetPodConfigReferences returns config maps and secrets referenced by env variables, env sources, volumes and image
+// pull secrets of the pod, together with information whether they exist.
+func GetPodConfigReferences(client kubernetes.Interface, namespace, podName string) (*ConfigReferences, error) {
+	pod, err := client.CoreV1().Pods(namespace).Get(context.TODO(), podName, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	configMaps, secrets := scanConfigReferences(pod)
+	result := &ConfigReferences{ConfigMaps: configMaps.list(), Secrets: secrets.list()}
+	for i := range result.ConfigMaps {
+		_, err := client.CoreV1().ConfigMaps(namespace).Get(context.TODO(), result.ConfigMaps[i].Name,
+			metaV1.GetOptions{})
+		if result.ConfigMaps[i].Exists, err = toExists(err); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range result.Secrets {
+		_, err := client.CoreV1().Secrets(namespace).Get(context.TODO(), result.Secrets[i].Name, metaV1.GetOptions{})
+		if result.Secrets[i].Exists, err = toExists(err); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func toExists(err error) (*bool, error) {
+	exists := err == nil
+	switch {
+	case err == nil, errors.IsNotFoundError(err):
+		return &exists, nil
+	case errors.IsForbiddenError(err):
+		return nil, nil
+	default:
+		return nil, err
+	}
+}
+
+// configReferences collects references to objects of a single kind, keyed by the object name.
+type configReferences map[string]*ConfigReference
+
+func (self configReferences) add(name string, optional *bool, source ReferenceSource) {
+	if len(name) == 0 {
+		return
+	}
+
+	isOptional := optional != nil && *optional
+	reference, ok := self[name]
+	if !ok {
+		reference = &ConfigReference{Name: name, Optional: isOptional, Sources: make([]ReferenceSource, 0)}
+		self[name] = reference
+	}
+
+	reference.Optional = reference.Optional && isOptional
+	reference.Sources = append(reference.Sources, source)
+}
+
+func (self configReferences) list() []ConfigReference {
+	result := make([]ConfigReference, 0, len(self))
+	for _, reference := range self {
+		result = append(result, *reference)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// Returns config maps and secrets referenced by the pod, in order of the pod spec.
+func scanConfigReferences(pod *v1.Pod) (configReferences, configReferences) {
+	configMaps, secrets := configReferences{}, configReferences{}
+
+	scanContainer := func(name string, env []v1.EnvVar, envFrom []v1.EnvFromSource) {
+		for _, e := range envFrom {
+			source := ReferenceSource{Type: ReferenceSourceEnvFrom, Container: name}
+			if e.ConfigMapRef != nil {
+				configMaps.add(e.ConfigMapRef.Name, e.ConfigMapRef.Optional, source)
+			}
+			if e.SecretRef != nil {
+				secrets.add(e.SecretRef.Name, e.SecretRef.Optional, source)
+			}
+		}
+
+		for _, e := range env {
+			if e.ValueFrom == nil {
+				continue
+			}
+
+			if ref := e.ValueFrom.ConfigMapKeyRef; ref != nil {
+				configMaps.add(ref.Name, ref.Optional,
+					ReferenceSource{Type: ReferenceSourceEnv, Container: name, Key: ref.Key})
+			}
+			if ref := e.ValueFrom.SecretKeyRef; ref != nil {
+				secrets.add(ref.Name, ref.Optional,
+					ReferenceSource{Type: ReferenceSourceEnv, Container: name, Key: ref.Key})
+			}
+		}
+	}
+
+	for _, c := range pod.Spec.InitContainers {
+		scanContainer(c.Name, c.Env, c.EnvFrom)
+	}
+	for _, c := range pod.Spec.Containers {
+		scanContainer(c.Name, c.Env, c.EnvFrom)
+	}
+	for _, c := range pod.Spec.EphemeralContainers {
+		scanContainer(c.Name, c.Env, c.EnvFrom)
+	}
+
+	for _, volume := range pod.Spec.Volumes {
+		source := ReferenceSource{Type: ReferenceSourceVolume, Volume: volume.Name}
+		if volume.ConfigMap != nil {
+			configMaps.add(volume.ConfigMap.Name, volume.ConfigMap.Optional, source)
+		}
+		if volume.Secret != nil {
+			secrets.add(volume.Secret.SecretName, volume.Secret.Optional, source)
+		}
+		if volume.Projected != nil {
+			for _, projection := range volume.Projected.Sources {
+				if projection.ConfigMap != nil {
+					configMaps.add(projection.ConfigMap.Name, projection.ConfigMap.Optional, source)
+				}
+				if projection.Secret != nil {
+					secrets.add(projection.Secret.Name, projection.Secret.Optional, source)
+				}
+			}
+		}
+	}
+
+	for _, ref := range pod.Spec.ImagePullSecrets {
+		// Pod starts even if the image pull secret is missing, as long as the image can be pulled without it.
+		optional := true
+		secrets.add(ref.Name, &optional, ReferenceSource{Type: ReferenceSourceImagePullSecret})
+	}
+
+	return configMaps, secrets
+}
@@ -0,0 +1,106 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientTesting "k8s.io/client-go/testing"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+func TestGetPodConfigReferences(t *testing.T) {
+	optional := true
+	pod := &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name: "app",
+				EnvFrom: []v1.EnvFromSource{
+					{ConfigMapRef: &v1.ConfigMapEnvSource{
+						LocalObjectReference: v1.LocalObjectReference{Name: "settings"}}},
+				},
+				Env: []v1.EnvVar{
+					{Name: "LEVEL", ValueFrom: &v1.EnvVarSource{ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: "settings"}, Key: "level"}}},
+					{Name: "TOKEN", ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: "token"}, Key: "token",
+						Optional: &optional}}},
+					{Name: "PLAIN", Value: "value"},
+				},
+			}},
+			Volumes: []v1.Volume{
+				{Name: "certs", VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "certs"}}},
+				{Name: "extra", VolumeSource: v1.VolumeSource{Projected: &v1.ProjectedVolumeSource{
+					Sources: []v1.VolumeProjection{{ConfigMap: &v1.ConfigMapProjection{
+						LocalObjectReference: v1.LocalObjectReference{Name: "extra"}, Optional: &optional}}}}}},
+			},
+			ImagePullSecrets: []v1.LocalObjectReference{{Name: "registry"}},
+		},
+	}
+	client := fake.NewSimpleClientset(pod,
+		&v1.ConfigMap{ObjectMeta: metaV1.ObjectMeta{Name: "settings", Namespace: "default"}},
+		&v1.Secret{ObjectMeta: metaV1.ObjectMeta{Name: "certs", Namespace: "default"}},
+	)
+	client.PrependReactor("get", "secrets", func(action clientTesting.Action) (bool, runtime.Object, error) {
+		if action.(clientTesting.GetAction).GetName() == "registry" {
+			return true, nil, errors.NewForbidden("forbidden")
+		}
+		return false, nil, nil
+	})
+
+	actual, err := GetPodConfigReferences(client, "default", "web")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	exists, missing := true, false
+	expected := &ConfigReferences{
+		ConfigMaps: []ConfigReference{
+			{Name: "extra", Optional: true, Exists: &missing, Sources: []ReferenceSource{
+				{Type: ReferenceSourceVolume, Volume: "extra"}}},
+			{Name: "settings", Exists: &exists, Sources: []ReferenceSource{
+				{Type: ReferenceSourceEnvFrom, Container: "app"},
+				{Type: ReferenceSourceEnv, Container: "app", Key: "level"}}},
+		},
+		Secrets: []ConfigReference{
+			{Name: "certs", Exists: &exists, Sources: []ReferenceSource{
+				{Type: ReferenceSourceVolume, Volume: "certs"}}},
+			{Name: "registry", Optional: true, Sources: []ReferenceSource{{Type: ReferenceSourceImagePullSecret}}},
+			{Name: "token", Optional: true, Exists: &missing, Sources: []ReferenceSource{
+				{Type: ReferenceSourceEnv, Container: "app", Key: "token"}}},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected references %+v, got %+v", expected, actual)
+	}
+}
+
+func TestConfigReferenceOptional(t *testing.T) {
+	optional := true
+	references := configReferences{}
+	references.add("settings", &optional, ReferenceSource{Type: ReferenceSourceVolume, Volume: "config"})
+	references.add("settings", nil, ReferenceSource{Type: ReferenceSourceEnvFrom, Container: "app"})
+
+	if list := references.list(); len(list) != 1 || list[0].Optional {
+		t.Errorf("Expected reference to be required when any of its sources is required, got %+v", list)
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/api"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
 	errorHandler "github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
 	metricapi "github.com/CAPS-Cloud/dashboard/src/app/backend/integration/metric/api"
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/common"
@@ -59,6 +60,9 @@ type PodDetail struct {
 	PersistentvolumeclaimList persistentvolumeclaim.PersistentVolumeClaimList `json:"persistentVolumeClaimList"`
 	SecurityContext           *v1.PodSecurityContext                          `json:"securityContext"`
 
+	// Current usage reported by the metrics API, set only when requested and available.
+	Usage *PodMetrics `json:"usage,omitempty"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -107,6 +111,10 @@ type EnvVar struct {
 	// Note that this is an API struct. This is intentional, as EnvVarSources are plain struct
 	// references.
 	ValueFrom *v1.EnvVarSource `json:"valueFrom"`
+
+	// Masked is true when the value is replaced by MaskedEnvValue, because it comes from a secret or the name of the
+	// variable is sensitive.
+	Masked bool `json:"masked,omitempty"`
 }
 
 type VolumeMount struct {
@@ -126,9 +134,11 @@ type VolumeMount struct {
 	Volume v1.Volume `json:"volume"`
 }
 
-// GetPodDetail returns the details of a named Pod from a particular namespace.
-func GetPodDetail(client kubernetes.Interface, metricClient metricapi.MetricClient, namespace, name string) (
-	*PodDetail, error) {
+// GetPodDetail returns the details of a named Pod from a particular namespace. Unless revealSecrets is set, values
+// of the environment variables sourced from secrets or matching the 'sensitive-env-patterns' argument are masked.
+// Callers are responsible for checking that the user can get secrets before revealing them.
+func GetPodDetail(client kubernetes.Interface, metricClient metricapi.MetricClient, namespace, name string,
+	revealSecrets bool) (*PodDetail, error) {
 	log.Printf("Getting details of %s pod in %s namespace", name, namespace)
 
 	channels := &common.ResourceChannels{
@@ -180,6 +190,10 @@ func GetPodDetail(client kubernetes.Interface, metricClient metricapi.MetricClie
 
 	podDetail := toPodDetail(pod, metrics, configMapList, secretList, podController,
 		eventList, persistentVolumeClaimList, nonCriticalErrors)
+	if !revealSecrets {
+		MaskContainerEnv(podDetail.Containers, args.Holder.GetSensitiveEnvPatterns())
+		MaskContainerEnv(podDetail.InitContainers, args.Holder.GetSensitiveEnvPatterns())
+	}
 	return &podDetail, nil
 }
 
@@ -0,0 +1,53 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"path"
+	"strings"
+)
+
+// MaskedEnvValue replaces values of the masked environment variables.
+const MaskedEnvValue = "********"
+
+// MaskContainerEnv masks values of the environment variables of the containers that are sourced from secrets or
+// whose names match any of the sensitive name patterns. Patterns are case-insensitive and '*' matches any characters,
+// i.e. '*PASSWORD*' matches 'DB_PASSWORD'.
+func MaskContainerEnv(containers []Container, patterns []string) {
+	for i := range containers {
+		for j := range containers[i].Env {
+			variable := &containers[i].Env[j]
+			if isSecretEnvVar(*variable) || IsSensitiveEnvName(variable.Name, patterns) {
+				variable.Value = MaskedEnvValue
+				variable.Masked = true
+			}
+		}
+	}
+}
+
+// IsSensitiveEnvName returns true if the name matches any of the patterns. Invalid patterns do not match any name.
+func IsSensitiveEnvName(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(strings.ToUpper(pattern), strings.ToUpper(name)); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSecretEnvVar(variable EnvVar) bool {
+	return variable.ValueFrom != nil && variable.ValueFrom.SecretKeyRef != nil
+}
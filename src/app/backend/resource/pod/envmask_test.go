@@ -0,0 +1,138 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/args"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/dataselect"
+)
+
+func TestIsSensitiveEnvName(t *testing.T) {
+	patterns := []string{"*PASSWORD*", "*token*", "API_KEY", "["}
+	cases := []struct {
+		name     string
+		expected bool
+	}{
+		{"DB_PASSWORD", true},
+		{"password", true},
+		{"PASSWORD_FILE", true},
+		{"GITHUB_TOKEN", true},
+		{"api_key", true},
+		{"API_KEY_ID", false},
+		{"USERNAME", false},
+		{"", false},
+	}
+
+	for _, c := range cases {
+		if actual := IsSensitiveEnvName(c.name, patterns); actual != c.expected {
+			t.Errorf("IsSensitiveEnvName(%q) == %v, expected %v", c.name, actual, c.expected)
+		}
+	}
+}
+
+func TestMaskContainerEnv(t *testing.T) {
+	secretRef := &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+		LocalObjectReference: v1.LocalObjectReference{Name: "db"}, Key: "password"}}
+	configMapRef := &v1.EnvVarSource{ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+		LocalObjectReference: v1.LocalObjectReference{Name: "settings"}, Key: "url"}}
+	containers := []Container{{Name: "app", Env: []EnvVar{
+		{Name: "DB_USER", Value: "YWRtaW4=", ValueFrom: secretRef},
+		{Name: "DB_URL", Value: "postgres://db", ValueFrom: configMapRef},
+		{Name: "ADMIN_PASSWORD", Value: "hunter2"},
+		{Name: "LOG_LEVEL", Value: "debug"},
+	}}}
+
+	MaskContainerEnv(containers, []string{"*PASSWORD*"})
+
+	expected := []EnvVar{
+		{Name: "DB_USER", Value: MaskedEnvValue, ValueFrom: secretRef, Masked: true},
+		{Name: "DB_URL", Value: "postgres://db", ValueFrom: configMapRef},
+		{Name: "ADMIN_PASSWORD", Value: MaskedEnvValue, Masked: true},
+		{Name: "LOG_LEVEL", Value: "debug"},
+	}
+	if !reflect.DeepEqual(containers[0].Env, expected) {
+		t.Errorf("MaskContainerEnv() == \ngot %#v, \nexpected %#v", containers[0].Env, expected)
+	}
+}
+
+func TestGetPodDetailMasksEnv(t *testing.T) {
+	args.GetHolderBuilder().SetSensitiveEnvPatterns([]string{"*TOKEN*"})
+	defer args.GetHolderBuilder().SetSensitiveEnvPatterns(nil)
+	dataselect.DefaultDataSelectWithMetrics.MetricQuery = dataselect.NoMetrics
+
+	pod := &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "init", Env: []v1.EnvVar{{Name: "REGISTRY_TOKEN", Value: "abc"}}}},
+			Containers: []v1.Container{{
+				Name: "app",
+				Env: []v1.EnvVar{
+					{Name: "DB_PASSWORD", ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: "db"}, Key: "password"}}},
+					{Name: "LOG_LEVEL", Value: "debug"},
+				},
+				EnvFrom: []v1.EnvFromSource{{Prefix: "DB_", SecretRef: &v1.SecretEnvSource{
+					LocalObjectReference: v1.LocalObjectReference{Name: "db"}}}},
+			}},
+		},
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{Name: "db", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("hunter2")},
+	}
+
+	cases := []struct {
+		revealSecrets bool
+		app           []string
+		init          []string
+	}{
+		{false, []string{MaskedEnvValue, "debug", MaskedEnvValue}, []string{MaskedEnvValue}},
+		{true, []string{base64.StdEncoding.EncodeToString([]byte("hunter2")), "debug",
+			base64.StdEncoding.EncodeToString([]byte("hunter2"))}, []string{"abc"}},
+	}
+
+	for _, c := range cases {
+		detail, err := GetPodDetail(fake.NewSimpleClientset(pod, secret), nil, "default", "web", c.revealSecrets)
+		if err != nil {
+			t.Fatalf("GetPodDetail() returned error: %v", err)
+		}
+
+		if actual := envValues(detail.Containers[0].Env); !reflect.DeepEqual(actual, c.app) {
+			t.Errorf("GetPodDetail(revealSecrets: %v) container env == %v, expected %v", c.revealSecrets, actual,
+				c.app)
+		}
+		if actual := envValues(detail.InitContainers[0].Env); !reflect.DeepEqual(actual, c.init) {
+			t.Errorf("GetPodDetail(revealSecrets: %v) init container env == %v, expected %v", c.revealSecrets,
+				actual, c.init)
+		}
+	}
+}
+
+func envValues(vars []EnvVar) []string {
+	result := make([]string, 0, len(vars))
+	for _, variable := range vars {
+		result = append(result, variable.Value)
+	}
+
+	return result
+}
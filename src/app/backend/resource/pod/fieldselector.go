@@ -0,0 +1,59 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// PodFieldSelectors are the fields that can be used to select pods on the apiserver side.
+var PodFieldSelectors = map[string]bool{
+	"metadata.name":            true,
+	"metadata.namespace":       true,
+	"spec.nodeName":            true,
+	"spec.restartPolicy":       true,
+	"spec.schedulerName":       true,
+	"spec.serviceAccountName":  true,
+	"status.phase":             true,
+	"status.podIP":             true,
+	"status.nominatedNodeName": true,
+}
+
+// ParseFieldSelector parses pod field selector, i.e. 'spec.nodeName=node-1,status.phase!=Failed', and checks that
+// it uses only fields supported by the apiserver, so that invalid selectors are rejected before the request.
+func ParseFieldSelector(selector string) (fields.Selector, error) {
+	parsed, err := fields.ParseSelector(selector)
+	if err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid field selector: %s", err.Error()))
+	}
+
+	unsupported := make([]string, 0)
+	for _, requirement := range parsed.Requirements() {
+		if !PodFieldSelectors[requirement.Field] {
+			unsupported = append(unsupported, requirement.Field)
+		}
+	}
+
+	if len(unsupported) > 0 {
+		return nil, errors.NewBadRequest(fmt.Sprintf("unsupported pod field selector fields: %s",
+			strings.Join(unsupported, ", ")))
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod_test
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientTesting "k8s.io/client-go/testing"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/common"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/dataselect"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/pod"
+)
+
+func TestParseFieldSelector(t *testing.T) {
+	cases := []struct {
+		selector string
+		valid    bool
+	}{
+		{"spec.nodeName=node-1", true},
+		{"status.phase=Running", true},
+		{"spec.nodeName=node-1,status.phase!=Failed", true},
+		{"metadata.name==web", true},
+		{"spec.containers.image=nginx", false},
+		{"status.phase=Running,metadata.labels=app", false},
+		{"status.phase", false},
+	}
+
+	for _, c := range cases {
+		_, err := pod.ParseFieldSelector(c.selector)
+		if c.valid && err != nil {
+			t.Errorf("Expected %q to be valid, got %v", c.selector, err)
+		}
+
+		if !c.valid && !errors.IsBadRequest(err) {
+			t.Errorf("Expected %q to be rejected with bad request error, got %v", c.selector, err)
+		}
+	}
+}
+
+func TestGetPodListFieldSelector(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	var fieldSelector string
+	client.PrependReactor("list", "pods", func(action clientTesting.Action) (bool, runtime.Object, error) {
+		fieldSelector = action.(clientTesting.ListActionImpl).GetListRestrictions().Fields.String()
+		return false, nil, nil
+	})
+
+	_, err := pod.GetPodList(client, nil, common.NewNamespaceQuery(nil), dataselect.NoDataSelect,
+		"spec.nodeName=node-1,status.phase=Running", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if fieldSelector != "spec.nodeName=node-1,status.phase=Running" {
+		t.Errorf("Expected field selector to be passed to the apiserver, got %q", fieldSelector)
+	}
+
+	if _, err := pod.GetPodList(client, nil, common.NewNamespaceQuery(nil), dataselect.NoDataSelect,
+		"spec.containers=nginx", nil); !errors.IsBadRequest(err) {
+		t.Errorf("Expected bad request error for unsupported field, got %v", err)
+	}
+}
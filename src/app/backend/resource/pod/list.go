@@ -25,6 +25,7 @@ import (
 	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/event"
 	v1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	k8sClient "k8s.io/client-go/kubernetes"
 )
 
@@ -82,13 +83,34 @@ var EmptyPodList = &PodList{
 	},
 }
 
-// GetPodList returns a list of all Pods in the cluster.
+// GetPodList returns a list of all Pods in the cluster. Pods can be filtered on the apiserver side with the field
+// selector, see ParseFieldSelector for the supported fields. Empty selector lists all pods. Only pods in the given
+// phases are listed, nil phases list pods in all phases.
 func GetPodList(client k8sClient.Interface, metricClient metricapi.MetricClient, nsQuery *common.NamespaceQuery,
-	dsQuery *dataselect.DataSelectQuery) (*PodList, error) {
+	dsQuery *dataselect.DataSelectQuery, fieldSelector string, phases []v1.PodPhase) (*PodList, error) {
 	log.Print("Getting list of all pods in the cluster")
 
+	options := metaV1.ListOptions{}
+	selectors := make([]fields.Selector, 0)
+	if len(fieldSelector) > 0 {
+		selector, err := ParseFieldSelector(fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+
+		selectors = append(selectors, selector)
+	}
+
+	if selector := phasesFieldSelector(phases); selector != nil {
+		selectors = append(selectors, selector)
+	}
+
+	if len(selectors) > 0 {
+		options.FieldSelector = fields.AndSelectors(selectors...).String()
+	}
+
 	channels := &common.ResourceChannels{
-		PodList:   common.GetPodListChannelWithOptions(client, nsQuery, metaV1.ListOptions{}, 1),
+		PodList:   filterPodListChannel(common.GetPodListChannelWithOptions(client, nsQuery, options, 1), phases),
 		EventList: common.GetEventListChannel(client, nsQuery, 1),
 	}
 
@@ -0,0 +1,139 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/common"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// PodPhases are all phases a pod can be in.
+var PodPhases = []v1.PodPhase{v1.PodPending, v1.PodRunning, v1.PodSucceeded, v1.PodFailed, v1.PodUnknown}
+
+// TerminalPodPhases are phases of the pods whose containers will not be restarted anymore.
+var TerminalPodPhases = []v1.PodPhase{v1.PodSucceeded, v1.PodFailed}
+
+// ParsePhases parses comma-separated list of pod phases, i.e. 'Running,Pending'. Phases are matched case-insensitively.
+// Empty list returns nil, which means that pods in all phases are listed.
+func ParsePhases(phases string) ([]v1.PodPhase, error) {
+	if len(strings.TrimSpace(phases)) == 0 {
+		return nil, nil
+	}
+
+	result := make([]v1.PodPhase, 0)
+	for _, phase := range strings.Split(phases, ",") {
+		parsed, ok := parsePhase(strings.TrimSpace(phase))
+		if !ok {
+			return nil, errors.NewBadRequest(fmt.Sprintf("unknown pod phase: %s", phase))
+		}
+
+		result = append(result, parsed)
+	}
+
+	return result, nil
+}
+
+func parsePhase(phase string) (v1.PodPhase, bool) {
+	for _, p := range PodPhases {
+		if strings.EqualFold(string(p), phase) {
+			return p, true
+		}
+	}
+
+	return "", false
+}
+
+// DefaultPhases returns phases listed when the pod list does not specify them. Nil means all phases.
+func DefaultPhases(showTerminalPods bool) []v1.PodPhase {
+	if showTerminalPods {
+		return nil
+	}
+
+	result := make([]v1.PodPhase, 0, len(PodPhases))
+	for _, phase := range PodPhases {
+		if !containsPhase(TerminalPodPhases, phase) {
+			result = append(result, phase)
+		}
+	}
+
+	return result
+}
+
+// Returns field selector that excludes phases not included in the given ones, as field selectors cannot match any of
+// multiple values. Returns nil when all phases are included.
+func phasesFieldSelector(phases []v1.PodPhase) fields.Selector {
+	if phases == nil {
+		return nil
+	}
+
+	selectors := make([]fields.Selector, 0)
+	for _, phase := range PodPhases {
+		if !containsPhase(phases, phase) {
+			selectors = append(selectors, fields.OneTermNotEqualSelector("status.phase", string(phase)))
+		}
+	}
+
+	if len(selectors) == 0 {
+		return nil
+	}
+
+	return fields.AndSelectors(selectors...)
+}
+
+// Filters pods of the channel by phases once the list is read. It ensures that phases are honored also when field
+// selector could not be applied, i.e. when list is served by a client that ignores field selectors.
+func filterPodListChannel(channel common.PodListChannel, phases []v1.PodPhase) common.PodListChannel {
+	if phases == nil {
+		return channel
+	}
+
+	list := <-channel.List
+	err := <-channel.Error
+	if list != nil {
+		filtered := make([]v1.Pod, 0, len(list.Items))
+		for _, pod := range list.Items {
+			// Phase is set by the apiserver on creation, pods without it are pending.
+			phase := pod.Status.Phase
+			if len(phase) == 0 {
+				phase = v1.PodPending
+			}
+
+			if containsPhase(phases, phase) {
+				filtered = append(filtered, pod)
+			}
+		}
+		list.Items = filtered
+	}
+
+	result := common.PodListChannel{List: make(chan *v1.PodList, 1), Error: make(chan error, 1)}
+	result.List <- list
+	result.Error <- err
+	return result
+}
+
+func containsPhase(phases []v1.PodPhase, phase v1.PodPhase) bool {
+	for _, p := range phases {
+		if p == phase {
+			return true
+		}
+	}
+
+	return false
+}
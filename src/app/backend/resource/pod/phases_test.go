@@ -0,0 +1,106 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientTesting "k8s.io/client-go/testing"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/common"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/dataselect"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/pod"
+)
+
+func TestParsePhases(t *testing.T) {
+	phases, err := pod.ParsePhases("running, Pending")
+	if err != nil || !reflect.DeepEqual(phases, []v1.PodPhase{v1.PodRunning, v1.PodPending}) {
+		t.Errorf("Expected running and pending phases, got %v, %v", phases, err)
+	}
+
+	if phases, err := pod.ParsePhases(""); err != nil || phases != nil {
+		t.Errorf("Expected empty phases to list all phases, got %v, %v", phases, err)
+	}
+
+	if _, err := pod.ParsePhases("Running,Completed"); !errors.IsBadRequest(err) {
+		t.Errorf("Expected bad request error for unknown phase, got %v", err)
+	}
+}
+
+func newPhasePod(name string, phase v1.PodPhase) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"},
+		Status:     v1.PodStatus{Phase: phase},
+	}
+}
+
+func TestGetPodListPhases(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		newPhasePod("running", v1.PodRunning),
+		newPhasePod("pending", ""),
+		newPhasePod("succeeded", v1.PodSucceeded),
+		newPhasePod("failed", v1.PodFailed),
+	)
+	var fieldSelector string
+	client.PrependReactor("list", "pods", func(action clientTesting.Action) (bool, runtime.Object, error) {
+		fieldSelector = action.(clientTesting.ListActionImpl).GetListRestrictions().Fields.String()
+		return false, nil, nil
+	})
+
+	cases := []struct {
+		info          string
+		phases        []v1.PodPhase
+		fieldSelector string
+		expected      []string
+	}{
+		{"should include terminal pods", pod.DefaultPhases(true), "",
+			[]string{"failed", "pending", "running", "succeeded"}},
+		{"should exclude terminal pods", pod.DefaultPhases(false),
+			"status.phase!=Failed,status.phase!=Succeeded", []string{"pending", "running"}},
+		{"should include only given phases", []v1.PodPhase{v1.PodFailed},
+			"status.phase!=Pending,status.phase!=Running,status.phase!=Succeeded,status.phase!=Unknown",
+			[]string{"failed"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.info, func(t *testing.T) {
+			list, err := pod.GetPodList(client, nil, common.NewNamespaceQuery(nil), dataselect.NoDataSelect, "",
+				c.phases)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if fieldSelector != c.fieldSelector {
+				t.Errorf("Expected field selector %q, got %q", c.fieldSelector, fieldSelector)
+			}
+
+			names := make([]string, 0)
+			for _, p := range list.Pods {
+				names = append(names, p.ObjectMeta.Name)
+			}
+			sort.Strings(names)
+			if !reflect.DeepEqual(names, c.expected) {
+				t.Errorf("Expected pods %v, got %v", c.expected, names)
+			}
+		})
+	}
+}
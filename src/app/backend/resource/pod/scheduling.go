@@ -0,0 +1,220 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+// FailedSchedulingReason is the reason of the events emitted by the scheduler when pod cannot be scheduled.
+const FailedSchedulingReason = "FailedScheduling"
+
+// Types of the causes that prevent the pod from being scheduled.
+const (
+	SchedulingCauseInsufficientResources = "InsufficientResources"
+	SchedulingCauseNodeAffinity          = "NodeAffinity"
+	SchedulingCausePodAffinity           = "PodAffinity"
+	SchedulingCauseTaint                 = "Taint"
+	SchedulingCauseVolume                = "Volume"
+	SchedulingCauseOther                 = "Other"
+)
+
+// SchedulingStatus explains whether the pod is scheduled and, if not, why.
+type SchedulingStatus struct {
+	Scheduled bool   `json:"scheduled"`
+	NodeName  string `json:"nodeName,omitempty"`
+	// Reason and message of the PodScheduled condition.
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+	// Causes are parsed from the latest scheduler message, i.e. '0/3 nodes are available: 3 Insufficient cpu.'.
+	Causes []SchedulingCause `json:"causes"`
+	// Events are FailedScheduling events of the pod, the most recent first.
+	Events []SchedulingEvent `json:"events"`
+}
+
+// SchedulingCause is a single reason why nodes were filtered out by the scheduler.
+type SchedulingCause struct {
+	// Type is one of InsufficientResources, NodeAffinity, PodAffinity, Taint, Volume or Other.
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	// Nodes is the number of nodes filtered out for this reason.
+	Nodes int `json:"nodes"`
+}
+
+// SchedulingEvent is a FailedScheduling event of the pod.
+type SchedulingEvent struct {
+	Message  string      `json:"message"`
+	Count    int32       `json:"count"`
+	LastSeen metaV1.Time `json:"lastSeen"`
+}
+
+// GetPodSchedulingStatus returns scheduling status of the pod based on its PodScheduled condition and
+// FailedScheduling events. Events are correlated with the pod by involved object, events of an older pod with the
+// same name are skipped.
+func GetPodSchedulingStatus(client kubernetes.Interface, namespace, podName string) (*SchedulingStatus, error) {
+	pod, err := client.CoreV1().Pods(namespace).Get(context.TODO(), podName, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	selector := fields.AndSelectors(
+		fields.OneTermEqualSelector("involvedObject.kind", "Pod"),
+		fields.OneTermEqualSelector("involvedObject.name", podName),
+		fields.OneTermEqualSelector("reason", FailedSchedulingReason),
+	)
+	events, err := client.CoreV1().Events(namespace).List(context.TODO(),
+		metaV1.ListOptions{FieldSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	return toSchedulingStatus(pod, events.Items), nil
+}
+
+func toSchedulingStatus(pod *v1.Pod, events []v1.Event) *SchedulingStatus {
+	status := &SchedulingStatus{
+		NodeName: pod.Spec.NodeName,
+		Causes:   make([]SchedulingCause, 0),
+		Events:   make([]SchedulingEvent, 0),
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodScheduled {
+			status.Scheduled = condition.Status == v1.ConditionTrue
+			status.Reason = condition.Reason
+			status.Message = condition.Message
+		}
+	}
+	status.Scheduled = status.Scheduled || len(pod.Spec.NodeName) > 0
+
+	for _, event := range events {
+		if !isPodSchedulingEvent(pod, event) {
+			continue
+		}
+
+		lastSeen := event.LastTimestamp
+		if lastSeen.IsZero() {
+			lastSeen = metaV1.NewTime(event.EventTime.Time)
+		}
+		count := event.Count
+		if count == 0 {
+			count = 1
+		}
+		status.Events = append(status.Events, SchedulingEvent{Message: event.Message, Count: count,
+			LastSeen: lastSeen})
+	}
+
+	sort.SliceStable(status.Events, func(i, j int) bool {
+		return status.Events[j].LastSeen.Before(&status.Events[i].LastSeen)
+	})
+
+	if status.Scheduled {
+		return status
+	}
+
+	message := status.Message
+	if len(message) == 0 && len(status.Events) > 0 {
+		message = status.Events[0].Message
+	}
+	status.Causes = parseSchedulingMessage(message)
+	return status
+}
+
+func isPodSchedulingEvent(pod *v1.Pod, event v1.Event) bool {
+	object := event.InvolvedObject
+	if event.Reason != FailedSchedulingReason || object.Kind != "Pod" || object.Name != pod.Name ||
+		object.Namespace != pod.Namespace {
+		return false
+	}
+
+	return len(object.UID) == 0 || len(pod.UID) == 0 || object.UID == pod.UID
+}
+
+// Parses scheduler message, i.e. '0/3 nodes are available: 1 node(s) had untolerated taint {key: value}, 2
+// Insufficient cpu. preemption: ...', into causes. Message in unknown format is returned as a single cause.
+func parseSchedulingMessage(message string) []SchedulingCause {
+	result := make([]SchedulingCause, 0)
+	if len(message) == 0 {
+		return result
+	}
+
+	const available = "nodes are available: "
+	start := strings.Index(message, available)
+	if start < 0 {
+		return append(result, SchedulingCause{Type: classifySchedulingCause(message), Message: message})
+	}
+
+	reasons := message[start+len(available):]
+	if end := strings.Index(reasons, " preemption:"); end >= 0 {
+		reasons = reasons[:end]
+	}
+	reasons = strings.TrimSuffix(strings.TrimSpace(reasons), ".")
+
+	for _, part := range strings.Split(reasons, ", ") {
+		count, text, ok := splitSchedulingReason(part)
+		if !ok && len(result) > 0 {
+			// Continuation of the previous reason, i.e. "had taint {key: value}, that the pod didn't tolerate".
+			last := &result[len(result)-1]
+			last.Message += ", " + part
+			last.Type = classifySchedulingCause(last.Message)
+			continue
+		}
+
+		result = append(result, SchedulingCause{Type: classifySchedulingCause(text), Message: text, Nodes: count})
+	}
+
+	return result
+}
+
+// Splits reason into number of nodes and the text, i.e. '2 Insufficient cpu'.
+func splitSchedulingReason(reason string) (int, string, bool) {
+	parts := strings.SplitN(strings.TrimSpace(reason), " ", 2)
+	if len(parts) != 2 {
+		return 0, reason, false
+	}
+
+	count, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, reason, false
+	}
+
+	return count, parts[1], true
+}
+
+func classifySchedulingCause(message string) string {
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "insufficient") || strings.Contains(lower, "too many pods"):
+		return SchedulingCauseInsufficientResources
+	case strings.Contains(lower, "taint"):
+		return SchedulingCauseTaint
+	case strings.Contains(lower, "node affinity") || strings.Contains(lower, "node selector"):
+		return SchedulingCauseNodeAffinity
+	case strings.Contains(lower, "pod affinity") || strings.Contains(lower, "anti-affinity"):
+		return SchedulingCausePodAffinity
+	case strings.Contains(lower, "volume") || strings.Contains(lower, "persistentvolumeclaim"):
+		return SchedulingCauseVolume
+	default:
+		return SchedulingCauseOther
+	}
+}
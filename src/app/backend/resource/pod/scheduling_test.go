@@ -0,0 +1,140 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/errors"
+)
+
+func schedulingEvent(name, uid, message string, lastSeen time.Time) *v1.Event {
+	return &v1.Event{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"},
+		InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "web", Namespace: "default",
+			UID: types.UID("uid-" + uid)},
+		Reason:        FailedSchedulingReason,
+		Message:       message,
+		Count:         1,
+		LastTimestamp: metaV1.NewTime(lastSeen),
+	}
+}
+
+func TestGetPodSchedulingStatus(t *testing.T) {
+	now := time.Date(2022, time.June, 1, 12, 0, 0, 0, time.UTC)
+	pod := &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default", UID: "uid-web"},
+		Status: v1.PodStatus{
+			Phase: v1.PodPending,
+			Conditions: []v1.PodCondition{{
+				Type:   v1.PodScheduled,
+				Status: v1.ConditionFalse,
+				Reason: v1.PodReasonUnschedulable,
+				Message: "0/4 nodes are available: 1 node(s) had untolerated taint " +
+					"{node-role.kubernetes.io/master: }, 1 node(s) didn't match Pod's node affinity/selector, " +
+					"2 Insufficient cpu. preemption: 0/4 nodes are available: 4 No preemption victims found.",
+			}},
+		},
+	}
+	older := schedulingEvent("web.1", "web", "0/4 nodes are available: 4 Insufficient memory.", now.Add(-time.Hour))
+	latest := schedulingEvent("web.2", "web", pod.Status.Conditions[0].Message, now)
+	stale := schedulingEvent("web.3", "old", "0/1 nodes are available: 1 Too many pods.", now)
+	other := schedulingEvent("api.1", "api", "0/1 nodes are available: 1 Too many pods.", now)
+	other.InvolvedObject.Name = "api"
+	scheduled := schedulingEvent("web.4", "web", "Successfully assigned default/web to node-1", now)
+	scheduled.Reason = "Scheduled"
+
+	client := fake.NewSimpleClientset(pod, older, latest, stale, other, scheduled)
+	status, err := GetPodSchedulingStatus(client, "default", "web")
+	if err != nil {
+		t.Fatalf("GetPodSchedulingStatus() returned error: %v", err)
+	}
+
+	if status.Scheduled || status.Reason != v1.PodReasonUnschedulable {
+		t.Errorf("expected unschedulable pod, got scheduled %v, reason %q", status.Scheduled, status.Reason)
+	}
+
+	expectedCauses := []SchedulingCause{
+		{Type: SchedulingCauseTaint, Nodes: 1,
+			Message: "node(s) had untolerated taint {node-role.kubernetes.io/master: }"},
+		{Type: SchedulingCauseNodeAffinity, Nodes: 1, Message: "node(s) didn't match Pod's node affinity/selector"},
+		{Type: SchedulingCauseInsufficientResources, Nodes: 2, Message: "Insufficient cpu"},
+	}
+	if !reflect.DeepEqual(status.Causes, expectedCauses) {
+		t.Errorf("expected causes %#v, got %#v", expectedCauses, status.Causes)
+	}
+
+	expectedEvents := []SchedulingEvent{
+		{Message: latest.Message, Count: 1, LastSeen: latest.LastTimestamp},
+		{Message: older.Message, Count: 1, LastSeen: older.LastTimestamp},
+	}
+	if !reflect.DeepEqual(status.Events, expectedEvents) {
+		t.Errorf("expected events %#v, got %#v", expectedEvents, status.Events)
+	}
+}
+
+func TestGetPodSchedulingStatusFromEvents(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default", UID: "uid-web"},
+		Status:     v1.PodStatus{Phase: v1.PodPending},
+	}
+	event := schedulingEvent("web.1", "web", "0/2 nodes are available: 2 node(s) had taint "+
+		"{dedicated: gpu}, that the pod didn't tolerate.", time.Now())
+	event.InvolvedObject.UID = ""
+
+	status, err := GetPodSchedulingStatus(fake.NewSimpleClientset(pod, event), "default", "web")
+	if err != nil {
+		t.Fatalf("GetPodSchedulingStatus() returned error: %v", err)
+	}
+
+	expected := []SchedulingCause{{Type: SchedulingCauseTaint, Nodes: 2,
+		Message: "node(s) had taint {dedicated: gpu}, that the pod didn't tolerate"}}
+	if !reflect.DeepEqual(status.Causes, expected) {
+		t.Errorf("expected causes %#v, got %#v", expected, status.Causes)
+	}
+}
+
+func TestGetPodSchedulingStatusScheduled(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       v1.PodSpec{NodeName: "node-1"},
+		Status: v1.PodStatus{Conditions: []v1.PodCondition{{Type: v1.PodScheduled,
+			Status: v1.ConditionTrue}}},
+	}
+	event := schedulingEvent("web.1", "web", "0/1 nodes are available: 1 Insufficient cpu.", time.Now())
+
+	status, err := GetPodSchedulingStatus(fake.NewSimpleClientset(pod, event), "default", "web")
+	if err != nil {
+		t.Fatalf("GetPodSchedulingStatus() returned error: %v", err)
+	}
+
+	if !status.Scheduled || status.NodeName != "node-1" || len(status.Causes) != 0 || len(status.Events) != 1 {
+		t.Errorf("expected scheduled pod with past event and no causes, got %#v", status)
+	}
+}
+
+func TestGetPodSchedulingStatusNotFound(t *testing.T) {
+	_, err := GetPodSchedulingStatus(fake.NewSimpleClientset(), "default", "web")
+	if !errors.IsNotFoundError(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
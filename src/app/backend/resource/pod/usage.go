@@ -0,0 +1,97 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"context"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// PodMetricsGVR identifies current usage of the pods served by metrics-server through the metrics API.
+var PodMetricsGVR = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+
+// PodUsage contains current usage of the pods keyed by their namespace and name.
+type PodUsage map[types.NamespacedName]PodMetrics
+
+// GetPodUsage lists current usage of all pods in the namespace with a single request to the metrics API, empty
+// namespace lists pods from all namespaces. CPU usage is reported in millicores and memory usage in bytes, summed
+// over the containers. Returns nil usage when metrics API is not available or user cannot access it, so that pods
+// can be shown without metrics.
+func GetPodUsage(client dynamic.Interface, namespace string) (PodUsage, error) {
+	list, err := client.Resource(PodMetricsGVR).Namespace(namespace).List(context.TODO(), metaV1.ListOptions{})
+	if k8serrors.IsNotFound(err) || k8serrors.IsServiceUnavailable(err) || k8serrors.IsForbidden(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	result := make(PodUsage, len(list.Items))
+	for _, item := range list.Items {
+		result[types.NamespacedName{Namespace: item.GetNamespace(), Name: item.GetName()}] = toPodUsageMetrics(item)
+	}
+
+	return result, nil
+}
+
+func toPodUsageMetrics(item unstructured.Unstructured) PodMetrics {
+	cpu, memory := resource.Quantity{}, resource.Quantity{}
+	containers, _, _ := unstructured.NestedSlice(item.Object, "containers")
+	for _, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		usage, _, _ := unstructured.NestedStringMap(container, "usage")
+		if quantity, err := resource.ParseQuantity(usage["cpu"]); err == nil {
+			cpu.Add(quantity)
+		}
+		if quantity, err := resource.ParseQuantity(usage["memory"]); err == nil {
+			memory.Add(quantity)
+		}
+	}
+
+	cpuUsage, memoryUsage := uint64(cpu.MilliValue()), uint64(memory.Value())
+	return PodMetrics{CPUUsage: &cpuUsage, MemoryUsage: &memoryUsage}
+}
+
+// Get returns usage of the given pod or nil if usage is not known.
+func (self PodUsage) Get(namespace, name string) *PodMetrics {
+	metrics, ok := self[types.NamespacedName{Namespace: namespace, Name: name}]
+	if !ok {
+		return nil
+	}
+
+	return &metrics
+}
+
+// MergePodUsage sets metrics of the listed pods from the usage, joined by namespace and name. Pods that already have
+// metrics from the metric client are left unchanged, as well as pods without usage.
+func MergePodUsage(pods []Pod, usage PodUsage) {
+	for i := range pods {
+		if pods[i].Metrics != nil {
+			continue
+		}
+
+		pods[i].Metrics = usage.Get(pods[i].ObjectMeta.Namespace, pods[i].ObjectMeta.Name)
+	}
+}
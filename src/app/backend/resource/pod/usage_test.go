@@ -0,0 +1,120 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"testing"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clientTesting "k8s.io/client-go/testing"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/api"
+)
+
+func newPodMetrics(namespace, name string, usage ...map[string]interface{}) *unstructured.Unstructured {
+	containers := make([]interface{}, 0)
+	for _, u := range usage {
+		containers = append(containers, map[string]interface{}{"name": "container", "usage": u})
+	}
+
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "metrics.k8s.io/v1beta1",
+		"kind":       "PodMetrics",
+		"metadata":   map[string]interface{}{"name": name, "namespace": namespace},
+		"containers": containers,
+	}}
+}
+
+// Returns client serving given pod metrics. Metrics are added to the tracker directly, as resource of the PodMetrics
+// kind would be guessed incorrectly.
+func newPodUsageClient(t *testing.T, metrics ...*unstructured.Unstructured) *dynamicfake.FakeDynamicClient {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{PodMetricsGVR: "PodMetricsList"})
+	for _, m := range metrics {
+		if err := client.Tracker().Create(PodMetricsGVR, m, m.GetNamespace()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return client
+}
+
+func TestGetPodUsage(t *testing.T) {
+	client := newPodUsageClient(t,
+		newPodMetrics("default", "web",
+			map[string]interface{}{"cpu": "250m", "memory": "64Mi"},
+			map[string]interface{}{"cpu": "1000000n", "memory": "1Mi"}),
+		newPodMetrics("default", "db", map[string]interface{}{"cpu": "1", "memory": "1Gi"}),
+	)
+	var lists int
+	client.PrependReactor("list", "pods", func(action clientTesting.Action) (bool, runtime.Object, error) {
+		lists++
+		return false, nil, nil
+	})
+
+	usage, err := GetPodUsage(client, "default")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	pods := []Pod{
+		{ObjectMeta: api.ObjectMeta{Name: "web", Namespace: "default"}},
+		{ObjectMeta: api.ObjectMeta{Name: "db", Namespace: "default"}},
+		{ObjectMeta: api.ObjectMeta{Name: "new", Namespace: "default"}},
+	}
+	MergePodUsage(pods, usage)
+
+	if lists != 1 {
+		t.Errorf("Expected usage of all pods to be listed with a single request, got %d", lists)
+	}
+
+	if m := pods[0].Metrics; m == nil || *m.CPUUsage != 251 || *m.MemoryUsage != 65*1024*1024 {
+		t.Errorf("Expected usage of containers to be summed, got %+v", m)
+	}
+
+	if m := pods[1].Metrics; m == nil || *m.CPUUsage != 1000 || *m.MemoryUsage != 1024*1024*1024 {
+		t.Errorf("Expected usage of the pod, got %+v", m)
+	}
+
+	if pods[2].Metrics != nil {
+		t.Errorf("Expected pod without usage to have no metrics, got %+v", pods[2].Metrics)
+	}
+}
+
+func TestGetPodUsageMetricsAPIAbsent(t *testing.T) {
+	for _, err := range []error{
+		k8serrors.NewNotFound(PodMetricsGVR.GroupResource(), ""),
+		k8serrors.NewServiceUnavailable("metrics-server is not available"),
+	} {
+		client := newPodUsageClient(t)
+		client.PrependReactor("list", "pods", func(action clientTesting.Action) (bool, runtime.Object, error) {
+			return true, nil, err
+		})
+
+		usage, e := GetPodUsage(client, "default")
+		if e != nil || usage != nil {
+			t.Errorf("Expected no usage without error when metrics API is absent, got %v, %v", usage, e)
+		}
+
+		pods := []Pod{{ObjectMeta: api.ObjectMeta{Name: "web", Namespace: "default"}}}
+		MergePodUsage(pods, usage)
+		if pods[0].Metrics != nil {
+			t.Errorf("Expected pod to have no metrics, got %+v", pods[0].Metrics)
+		}
+	}
+}
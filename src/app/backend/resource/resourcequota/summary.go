@@ -0,0 +1,94 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcequota
+
+import (
+	"context"
+
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/api"
+	"github.com/CAPS-Cloud/dashboard/src/app/backend/resource/limitrange"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes"
+)
+
+// QuotaUsage provides usage of the single resource limited by the resource quotas.
+type QuotaUsage struct {
+	Used string `json:"used"`
+	Hard string `json:"hard"`
+	// Percentage of the hard limit that is already used.
+	Percentage float64 `json:"percentage"`
+	// Name of the resource quota that sets the limit.
+	QuotaName string `json:"quotaName"`
+}
+
+// QuotaSummary provides usage of the resources limited by the resource quotas and limit ranges of a namespace.
+type QuotaSummary struct {
+	Resources   map[v1.ResourceName]QuotaUsage `json:"resources"`
+	LimitRanges []limitrange.LimitRangeItem    `json:"limitRanges"`
+}
+
+// GetNamespaceQuota returns summary of the resource quotas and limit ranges in the namespace. When resource is
+// limited by multiple quotas, usage of the most utilized one is reported, as it is the first one to block new
+// resources.
+func GetNamespaceQuota(client kubernetes.Interface, namespace string) (*QuotaSummary, error) {
+	quotas, err := client.CoreV1().ResourceQuotas(namespace).List(context.TODO(), api.ListEverything)
+	if err != nil {
+		return nil, err
+	}
+
+	limitRanges, err := client.CoreV1().LimitRanges(namespace).List(context.TODO(), api.ListEverything)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &QuotaSummary{
+		Resources:   make(map[v1.ResourceName]QuotaUsage),
+		LimitRanges: make([]limitrange.LimitRangeItem, 0),
+	}
+
+	for _, quota := range quotas.Items {
+		for name, hard := range quota.Status.Hard {
+			used := quota.Status.Used[name]
+			usage := QuotaUsage{
+				Used:       used.String(),
+				Hard:       hard.String(),
+				Percentage: usagePercentage(used, hard),
+				QuotaName:  quota.Name,
+			}
+
+			if current, exists := summary.Resources[name]; !exists || usage.Percentage > current.Percentage {
+				summary.Resources[name] = usage
+			}
+		}
+	}
+
+	for i := range limitRanges.Items {
+		summary.LimitRanges = append(summary.LimitRanges, limitrange.ToLimitRanges(&limitRanges.Items[i])...)
+	}
+
+	return summary, nil
+}
+
+func usagePercentage(used, hard resource.Quantity) float64 {
+	if hard.IsZero() {
+		if used.IsZero() {
+			return 0
+		}
+		return 100
+	}
+
+	return float64(used.MilliValue()) / float64(hard.MilliValue()) * 100
+}
@@ -0,0 +1,82 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcequota
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newQuota(name string, hard, used v1.ResourceList) *v1.ResourceQuota {
+	return &v1.ResourceQuota{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"},
+		Status:     v1.ResourceQuotaStatus{Hard: hard, Used: used},
+	}
+}
+
+func TestGetNamespaceQuota(t *testing.T) {
+	otherNamespaceQuota := newQuota("full", v1.ResourceList{v1.ResourcePods: resource.MustParse("1")},
+		v1.ResourceList{v1.ResourcePods: resource.MustParse("1")})
+	otherNamespaceQuota.Namespace = "other"
+	client := fake.NewSimpleClientset(
+		newQuota("compute",
+			v1.ResourceList{v1.ResourcePods: resource.MustParse("10"), v1.ResourceRequestsCPU: resource.MustParse("4")},
+			v1.ResourceList{v1.ResourcePods: resource.MustParse("9"), v1.ResourceRequestsCPU: resource.MustParse("1500m")}),
+		newQuota("pods",
+			v1.ResourceList{v1.ResourcePods: resource.MustParse("20")},
+			v1.ResourceList{v1.ResourcePods: resource.MustParse("9")}),
+		newQuota("secrets",
+			v1.ResourceList{v1.ResourceSecrets: resource.MustParse("0")},
+			v1.ResourceList{}),
+		&v1.LimitRange{
+			ObjectMeta: metaV1.ObjectMeta{Name: "limits", Namespace: "default"},
+			Spec: v1.LimitRangeSpec{Limits: []v1.LimitRangeItem{{
+				Type: v1.LimitTypeContainer,
+				Max:  v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")},
+			}}},
+		},
+		otherNamespaceQuota,
+	)
+
+	summary, err := GetNamespaceQuota(client, "default")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[v1.ResourceName]QuotaUsage{
+		v1.ResourcePods:        {Used: "9", Hard: "10", Percentage: 90, QuotaName: "compute"},
+		v1.ResourceRequestsCPU: {Used: "1500m", Hard: "4", Percentage: 37.5, QuotaName: "compute"},
+		v1.ResourceSecrets:     {Used: "0", Hard: "0", Percentage: 0, QuotaName: "secrets"},
+	}
+
+	if len(summary.Resources) != len(expected) {
+		t.Errorf("Expected %d resources, got %v", len(expected), summary.Resources)
+	}
+
+	for name, usage := range expected {
+		if summary.Resources[name] != usage {
+			t.Errorf("Expected usage of %s to be %+v, got %+v", name, usage, summary.Resources[name])
+		}
+	}
+
+	if len(summary.LimitRanges) != 1 || summary.LimitRanges[0].ResourceName != "memory" ||
+		summary.LimitRanges[0].Max != "1Gi" {
+		t.Errorf("Expected limit range of the namespace, got %+v", summary.LimitRanges)
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sClient "k8s.io/client-go/kubernetes"
+)
+
+// PodRef is a reference to the pod backing the service.
+type PodRef struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	NodeName  string `json:"nodeName,omitempty"`
+	// Ready is true when the pod is ready to receive traffic sent to the service.
+	Ready bool `json:"ready"`
+}
+
+// GetServiceBackends returns pods backing the service sorted by name. Pods of the services with selector are
+// resolved using the selector and their readiness is taken from the pod ready condition. Selector-less services
+// have their endpoints managed manually, so pods are resolved from the target references of the EndpointSlices or,
+// if there are none, of the Endpoints object.
+func GetServiceBackends(client k8sClient.Interface, namespace, name string) ([]PodRef, error) {
+	service, err := client.CoreV1().Services(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var backends []PodRef
+	if len(service.Spec.Selector) > 0 {
+		backends, err = getSelectedBackends(client, namespace, service.Spec.Selector)
+	} else {
+		backends, err = getEndpointSliceBackends(client, namespace, name)
+		if err == nil && len(backends) == 0 {
+			backends, err = getEndpointsBackends(client, namespace, name)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(backends, func(i, j int) bool {
+		if backends[i].Namespace != backends[j].Namespace {
+			return backends[i].Namespace < backends[j].Namespace
+		}
+		return backends[i].Name < backends[j].Name
+	})
+	return backends, nil
+}
+
+func getSelectedBackends(client k8sClient.Interface, namespace string, selector map[string]string) ([]PodRef,
+	error) {
+	pods, err := client.CoreV1().Pods(namespace).List(context.TODO(), metaV1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(selector).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	backends := make([]PodRef, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		backends = append(backends, PodRef{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+			NodeName:  pod.Spec.NodeName,
+			Ready:     isPodReady(pod) && pod.DeletionTimestamp == nil,
+		})
+	}
+
+	return backends, nil
+}
+
+// Collects pods referenced by the EndpointSlices of the service. Pod is reported only once even if it is listed in
+// multiple slices, i.e. one per address family, and it is ready if any of its endpoints is ready. Clusters that do
+// not serve discovery.k8s.io/v1 are treated as having no slices.
+func getEndpointSliceBackends(client k8sClient.Interface, namespace, name string) ([]PodRef, error) {
+	slices, err := client.DiscoveryV1().EndpointSlices(namespace).List(context.TODO(), metaV1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(map[string]string{discovery.LabelServiceName: name}).String(),
+	})
+	if k8serrors.IsNotFound(err) {
+		return []PodRef{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	collector := newBackendCollector()
+	for _, slice := range slices.Items {
+		for _, endpoint := range slice.Endpoints {
+			nodeName := ""
+			if endpoint.NodeName != nil {
+				nodeName = *endpoint.NodeName
+			}
+			// Nil ready condition should be interpreted as ready.
+			ready := endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+			collector.add(endpoint.TargetRef, namespace, nodeName, ready)
+		}
+	}
+
+	return collector.backends, nil
+}
+
+func getEndpointsBackends(client k8sClient.Interface, namespace, name string) ([]PodRef, error) {
+	endpoints, err := client.CoreV1().Endpoints(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return []PodRef{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	collector := newBackendCollector()
+	for _, subset := range endpoints.Subsets {
+		for _, address := range subset.Addresses {
+			collector.add(address.TargetRef, namespace, nodeNameOf(address), true)
+		}
+		for _, address := range subset.NotReadyAddresses {
+			collector.add(address.TargetRef, namespace, nodeNameOf(address), false)
+		}
+	}
+
+	return collector.backends, nil
+}
+
+func nodeNameOf(address v1.EndpointAddress) string {
+	if address.NodeName == nil {
+		return ""
+	}
+	return *address.NodeName
+}
+
+// backendCollector deduplicates pods referenced by multiple endpoints.
+type backendCollector struct {
+	backends []PodRef
+	indexes  map[string]int
+}
+
+func newBackendCollector() *backendCollector {
+	return &backendCollector{backends: []PodRef{}, indexes: map[string]int{}}
+}
+
+// Adds pod referenced by the endpoint. Endpoints that do not point to pods, i.e. external IPs, are ignored.
+func (self *backendCollector) add(ref *v1.ObjectReference, namespace, nodeName string, ready bool) {
+	if ref == nil || ref.Kind != "Pod" {
+		return
+	}
+
+	if len(ref.Namespace) > 0 {
+		namespace = ref.Namespace
+	}
+
+	key := namespace + "/" + ref.Name
+	if index, ok := self.indexes[key]; ok {
+		self.backends[index].Ready = self.backends[index].Ready || ready
+		return
+	}
+
+	self.indexes[key] = len(self.backends)
+	self.backends = append(self.backends, PodRef{Name: ref.Name, Namespace: namespace, NodeName: nodeName,
+		Ready: ready})
+}
+
+func isPodReady(pod v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
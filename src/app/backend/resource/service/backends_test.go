@@ -0,0 +1,135 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetServiceBackends(t *testing.T) {
+	ready, notReady := true, false
+	node := "node-1"
+	podRef := func(name string) *v1.ObjectReference {
+		return &v1.ObjectReference{Kind: "Pod", Name: name, Namespace: "ns-1"}
+	}
+
+	cases := []struct {
+		info     string
+		objects  []runtime.Object
+		expected []PodRef
+	}{
+		{
+			"selector-based service",
+			[]runtime.Object{
+				&v1.Service{
+					ObjectMeta: metaV1.ObjectMeta{Name: "svc-1", Namespace: "ns-1"},
+					Spec:       v1.ServiceSpec{Selector: map[string]string{"app": "web"}},
+				},
+				&v1.Pod{
+					ObjectMeta: metaV1.ObjectMeta{Name: "web-b", Namespace: "ns-1", Labels: map[string]string{"app": "web"}},
+					Spec:       v1.PodSpec{NodeName: node},
+					Status: v1.PodStatus{Conditions: []v1.PodCondition{
+						{Type: v1.PodReady, Status: v1.ConditionTrue},
+					}},
+				},
+				&v1.Pod{
+					ObjectMeta: metaV1.ObjectMeta{Name: "web-a", Namespace: "ns-1", Labels: map[string]string{"app": "web"}},
+					Status: v1.PodStatus{Conditions: []v1.PodCondition{
+						{Type: v1.PodReady, Status: v1.ConditionFalse},
+					}},
+				},
+				&v1.Pod{
+					ObjectMeta: metaV1.ObjectMeta{Name: "other", Namespace: "ns-1", Labels: map[string]string{"app": "db"}},
+				},
+			},
+			[]PodRef{
+				{Name: "web-a", Namespace: "ns-1"},
+				{Name: "web-b", Namespace: "ns-1", NodeName: node, Ready: true},
+			},
+		},
+		{
+			"EndpointSlice-based service",
+			[]runtime.Object{
+				&v1.Service{ObjectMeta: metaV1.ObjectMeta{Name: "svc-1", Namespace: "ns-1"}},
+				&discovery.EndpointSlice{
+					ObjectMeta: metaV1.ObjectMeta{Name: "svc-1-ipv4", Namespace: "ns-1",
+						Labels: map[string]string{discovery.LabelServiceName: "svc-1"}},
+					Endpoints: []discovery.Endpoint{
+						{TargetRef: podRef("pod-b"), NodeName: &node, Conditions: discovery.EndpointConditions{Ready: &notReady}},
+						{TargetRef: podRef("pod-a")},
+						{Addresses: []string{"10.0.0.1"}},
+					},
+				},
+				&discovery.EndpointSlice{
+					ObjectMeta: metaV1.ObjectMeta{Name: "svc-1-ipv6", Namespace: "ns-1",
+						Labels: map[string]string{discovery.LabelServiceName: "svc-1"}},
+					Endpoints: []discovery.Endpoint{
+						{TargetRef: podRef("pod-b"), NodeName: &node, Conditions: discovery.EndpointConditions{Ready: &ready}},
+					},
+				},
+				&discovery.EndpointSlice{
+					ObjectMeta: metaV1.ObjectMeta{Name: "svc-2-ipv4", Namespace: "ns-1",
+						Labels: map[string]string{discovery.LabelServiceName: "svc-2"}},
+					Endpoints: []discovery.Endpoint{{TargetRef: podRef("pod-c")}},
+				},
+			},
+			[]PodRef{
+				{Name: "pod-a", Namespace: "ns-1", Ready: true},
+				{Name: "pod-b", Namespace: "ns-1", NodeName: node, Ready: true},
+			},
+		},
+		{
+			"Endpoints-based service",
+			[]runtime.Object{
+				&v1.Service{ObjectMeta: metaV1.ObjectMeta{Name: "svc-1", Namespace: "ns-1"}},
+				&v1.Endpoints{
+					ObjectMeta: metaV1.ObjectMeta{Name: "svc-1", Namespace: "ns-1"},
+					Subsets: []v1.EndpointSubset{{
+						Addresses:         []v1.EndpointAddress{{IP: "10.0.0.2", TargetRef: podRef("pod-b"), NodeName: &node}},
+						NotReadyAddresses: []v1.EndpointAddress{{IP: "10.0.0.1", TargetRef: podRef("pod-a")}},
+					}},
+				},
+			},
+			[]PodRef{
+				{Name: "pod-a", Namespace: "ns-1"},
+				{Name: "pod-b", Namespace: "ns-1", NodeName: node, Ready: true},
+			},
+		},
+		{
+			"service without endpoints",
+			[]runtime.Object{&v1.Service{ObjectMeta: metaV1.ObjectMeta{Name: "svc-1", Namespace: "ns-1"}}},
+			[]PodRef{},
+		},
+	}
+
+	for _, c := range cases {
+		backends, err := GetServiceBackends(fake.NewSimpleClientset(c.objects...), "ns-1", "svc-1")
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.info, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(backends, c.expected) {
+			t.Errorf("%s: GetServiceBackends() == %+v, expected %+v", c.info, backends, c.expected)
+		}
+	}
+}
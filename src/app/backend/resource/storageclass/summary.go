@@ -0,0 +1,102 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storageclass
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	storage "k8s.io/api/storage/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// IsDefaultClassAnnotation marks the storage class used by claims that do not request any class.
+	IsDefaultClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+	// BetaIsDefaultClassAnnotation is the deprecated version of the annotation, still honored by the apiserver.
+	BetaIsDefaultClassAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
+)
+
+// StorageClassSummary describes the storage class and whether it is the default one.
+type StorageClassSummary struct {
+	Name                 string                           `json:"name"`
+	Provisioner          string                           `json:"provisioner"`
+	ReclaimPolicy        v1.PersistentVolumeReclaimPolicy `json:"reclaimPolicy"`
+	VolumeBindingMode    storage.VolumeBindingMode        `json:"volumeBindingMode"`
+	AllowVolumeExpansion bool                             `json:"allowVolumeExpansion"`
+	Default              bool                             `json:"default"`
+	// Warning is set on default classes when more than one class is marked as default.
+	Warning string `json:"warning,omitempty"`
+}
+
+// GetStorageClassSummaries returns all storage classes sorted by name. Reclaim policy and volume binding mode are
+// reported with their default values when they are not set.
+func GetStorageClassSummaries(client kubernetes.Interface) ([]StorageClassSummary, error) {
+	list, err := client.StorageV1().StorageClasses().List(context.TODO(), metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]StorageClassSummary, 0, len(list.Items))
+	defaults := make([]string, 0)
+	for _, class := range list.Items {
+		summary := StorageClassSummary{
+			Name:                 class.Name,
+			Provisioner:          class.Provisioner,
+			ReclaimPolicy:        v1.PersistentVolumeReclaimDelete,
+			VolumeBindingMode:    storage.VolumeBindingImmediate,
+			AllowVolumeExpansion: class.AllowVolumeExpansion != nil && *class.AllowVolumeExpansion,
+			Default:              IsDefaultClass(class),
+		}
+
+		if class.ReclaimPolicy != nil {
+			summary.ReclaimPolicy = *class.ReclaimPolicy
+		}
+
+		if class.VolumeBindingMode != nil {
+			summary.VolumeBindingMode = *class.VolumeBindingMode
+		}
+
+		if summary.Default {
+			defaults = append(defaults, class.Name)
+		}
+
+		result = append(result, summary)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	if len(defaults) > 1 {
+		sort.Strings(defaults)
+		warning := fmt.Sprintf("multiple storage classes are marked as default: %s", strings.Join(defaults, ", "))
+		for i := range result {
+			if result[i].Default {
+				result[i].Warning = warning
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// IsDefaultClass returns true if the storage class is marked as default with either the current or the beta
+// annotation, the same way as the apiserver does.
+func IsDefaultClass(class storage.StorageClass) bool {
+	return class.Annotations[IsDefaultClassAnnotation] == "true" ||
+		class.Annotations[BetaIsDefaultClassAnnotation] == "true"
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storageclass
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	storage "k8s.io/api/storage/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newStorageClass(name string, annotations map[string]string) *storage.StorageClass {
+	return &storage.StorageClass{
+		ObjectMeta:  metaV1.ObjectMeta{Name: name, Annotations: annotations},
+		Provisioner: "ebs.csi.aws.com",
+	}
+}
+
+func TestGetStorageClassSummaries(t *testing.T) {
+	retain := v1.PersistentVolumeReclaimRetain
+	waitForFirstConsumer := storage.VolumeBindingWaitForFirstConsumer
+	expansion := true
+	fast := newStorageClass("fast", nil)
+	fast.ReclaimPolicy = &retain
+	fast.VolumeBindingMode = &waitForFirstConsumer
+	fast.AllowVolumeExpansion = &expansion
+
+	client := fake.NewSimpleClientset(
+		newStorageClass("standard", map[string]string{IsDefaultClassAnnotation: "true"}),
+		fast,
+		newStorageClass("legacy", map[string]string{IsDefaultClassAnnotation: "false"}),
+	)
+
+	actual, err := GetStorageClassSummaries(client)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []StorageClassSummary{
+		{Name: "fast", Provisioner: "ebs.csi.aws.com", ReclaimPolicy: retain, VolumeBindingMode: waitForFirstConsumer,
+			AllowVolumeExpansion: true},
+		{Name: "legacy", Provisioner: "ebs.csi.aws.com", ReclaimPolicy: v1.PersistentVolumeReclaimDelete,
+			VolumeBindingMode: storage.VolumeBindingImmediate},
+		{Name: "standard", Provisioner: "ebs.csi.aws.com", ReclaimPolicy: v1.PersistentVolumeReclaimDelete,
+			VolumeBindingMode: storage.VolumeBindingImmediate, Default: true},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, actual)
+	}
+}
+
+func TestGetStorageClassSummariesMultipleDefaults(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		newStorageClass("standard", map[string]string{IsDefaultClassAnnotation: "true"}),
+		newStorageClass("old", map[string]string{BetaIsDefaultClassAnnotation: "true"}),
+		newStorageClass("fast", nil),
+	)
+
+	actual, err := GetStorageClassSummaries(client)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	warning := "multiple storage classes are marked as default: old, standard"
+	for _, class := range actual {
+		expectedDefault := class.Name != "fast"
+		if class.Default != expectedDefault {
+			t.Errorf("Expected class %s default to be %v", class.Name, expectedDefault)
+		}
+
+		if expectedDefault && class.Warning != warning || !expectedDefault && len(class.Warning) > 0 {
+			t.Errorf("Unexpected warning of class %s: %q", class.Name, class.Warning)
+		}
+	}
+}
@@ -41,7 +41,7 @@ type LoginStatus struct {
 // ValidateLoginStatus returns information about user login status and if request was made over HTTPS.
 func ValidateLoginStatus(request *restful.Request) *LoginStatus {
 	authHeader := request.HeaderParameter("Authorization")
-	tokenHeader := request.HeaderParameter(client.JWETokenHeader)
+	tokenHeader := request.HeaderParameter(client.GetJWETokenHeader())
 	impersonationHeader := request.HeaderParameter("Impersonate-User")
 
 	httpsMode := request.Request.TLS != nil